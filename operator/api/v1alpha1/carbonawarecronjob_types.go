@@ -0,0 +1,152 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConcurrencyPolicy describes how runs of a CarbonAwareCronJob are handled when
+// a previous run is still active. Only one of the following policies may be specified.
+// +kubebuilder:validation:Enum=Allow;Forbid;Replace
+type ConcurrencyPolicy string
+
+const (
+	// AllowConcurrent allows CarbonAwareJobs of the same CarbonAwareCronJob to run concurrently.
+	AllowConcurrent ConcurrencyPolicy = "Allow"
+
+	// ForbidConcurrent forbids concurrent runs, skipping the next run if the previous
+	// one hasn't finished yet.
+	ForbidConcurrent ConcurrencyPolicy = "Forbid"
+
+	// ReplaceConcurrent cancels the currently active run and replaces it with a new one.
+	ReplaceConcurrent ConcurrencyPolicy = "Replace"
+)
+
+// CarbonAwareCronJobSpec defines the desired state of CarbonAwareCronJob
+type CarbonAwareCronJobSpec struct {
+	// Schedule is the schedule in Cron format, see https://en.wikipedia.org/wiki/Cron.
+	// Each tick opens a new scheduling window of JobTemplate.Spec.MaxDelay
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+
+	// TimeZone is the name of the time zone for the given schedule, see https://en.wikipedia.org/wiki/List_of_tz_database_time_zones.
+	// If not specified, this will default to the time zone of the controller process.
+	// +optional
+	TimeZone *string `json:"timeZone,omitempty"`
+
+	// StartingDeadlineSeconds is the deadline in seconds for starting a run if it misses its
+	// scheduled time for any reason. Runs missed by more than the deadline are skipped, and no
+	// CarbonAwareJob is created for them.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	StartingDeadlineSeconds *int64 `json:"startingDeadlineSeconds,omitempty"`
+
+	// ConcurrencyPolicy specifies how to treat concurrent runs of a CarbonAwareJob.
+	// A run is considered active until its CarbonAwareJob has completed or failed,
+	// including while it is waiting for its optimal start time.
+	// Valid values are:
+	// - "Allow" (default): allows CarbonAwareJobs to run concurrently;
+	// - "Forbid": forbids concurrent runs, skipping next run if previous run hasn't finished yet;
+	// - "Replace": cancels currently running CarbonAwareJob and replaces it with a new one
+	// +kubebuilder:default=Allow
+	// +optional
+	ConcurrencyPolicy ConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+
+	// Suspend tells the controller to suspend subsequent runs, it does
+	// not apply to already started runs. Defaults to false.
+	// +optional
+	Suspend *bool `json:"suspend,omitempty"`
+
+	// JobTemplate is the CarbonAwareJob that will be created for every run of the schedule
+	// +kubebuilder:validation:Required
+	JobTemplate CarbonAwareJobTemplateSpec `json:"jobTemplate"`
+
+	// SuccessfulJobsHistoryLimit is the number of successful finished CarbonAwareJobs to retain.
+	// Defaults to 3.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=3
+	// +optional
+	SuccessfulJobsHistoryLimit *int32 `json:"successfulJobsHistoryLimit,omitempty"`
+
+	// FailedJobsHistoryLimit is the number of failed finished CarbonAwareJobs to retain.
+	// Defaults to 1.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=1
+	// +optional
+	FailedJobsHistoryLimit *int32 `json:"failedJobsHistoryLimit,omitempty"`
+}
+
+// CarbonAwareJobTemplateSpec describes the CarbonAwareJob that will be created
+// for every run of a CarbonAwareCronJob
+type CarbonAwareJobTemplateSpec struct {
+	// Standard object's metadata of the CarbonAwareJobs created from this template.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	// +optional
+	Metadata metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Specification of the desired behavior of each CarbonAwareJob, including the
+	// per-run MaxDelay window that starts at the scheduled tick
+	Spec CarbonAwareJobSpec `json:"spec"`
+}
+
+// CarbonAwareCronJobStatus defines the observed state of CarbonAwareCronJob
+type CarbonAwareCronJobStatus struct {
+	// Active is a list of pointers to CarbonAwareJobs that have not finished yet
+	// +optional
+	// +listType=atomic
+	Active []corev1.ObjectReference `json:"active,omitempty"`
+
+	// LastScheduleTime is the last time a run was successfully scheduled
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// LastSuccessfulTime is the last time a run completed successfully
+	// +optional
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Schedule",type="string",JSONPath=".spec.schedule",description="Cron schedule"
+// +kubebuilder:printcolumn:name="Suspend",type="boolean",JSONPath=".spec.suspend",description="Whether subsequent runs are suspended"
+// +kubebuilder:printcolumn:name="Last Schedule",type="date",JSONPath=".status.lastScheduleTime",description="Time of the last scheduled run"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:shortName=cacronjob;carboncronjob
+
+// CarbonAwareCronJob is the Schema for the carbonawarecronjobs API
+type CarbonAwareCronJob struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CarbonAwareCronJobSpec   `json:"spec,omitempty"`
+	Status CarbonAwareCronJobStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CarbonAwareCronJobList contains a list of CarbonAwareCronJob
+type CarbonAwareCronJobList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CarbonAwareCronJob `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CarbonAwareCronJob{}, &CarbonAwareCronJobList{})
+}
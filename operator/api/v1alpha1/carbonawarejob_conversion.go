@@ -0,0 +1,329 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/carbon-aware-kube/operator/api/v1beta1"
+)
+
+// intensityUnknown is the display intensity of decisions made without a forecast
+const intensityUnknown = "unknown"
+
+// ConvertTo converts this CarbonAwareJob to the hub version (v1beta1). Intensities and savings
+// of jobs scheduled before the numeric status fields existed are parsed from their display strings.
+func (src *CarbonAwareJob) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1beta1.CarbonAwareJob)
+	if !ok {
+		return fmt.Errorf("expected a v1beta1 CarbonAwareJob but got %T", dstRaw)
+	}
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec = convertSpecToHub(&src.Spec)
+
+	status := &src.Status
+	dst.Status = v1beta1.CarbonAwareJobStatus{
+		SubmissionTime:             status.SubmissionTime.DeepCopy(),
+		ScheduledTime:              status.ScheduledTime.DeepCopy(),
+		JobName:                    status.JobName,
+		JobStatus:                  status.JobStatus.DeepCopy(),
+		ReadyTime:                  status.ReadyTime.DeepCopy(),
+		SchedulingState:            v1beta1.SchedulingState(status.SchedulingState),
+		CarbonIntensity:            status.CarbonIntensity,
+		CarbonIntensityGramsPerKWh: intensityQuantity(status.CarbonIntensityGramsPerKWh, status.CarbonIntensity),
+		LastForecastTime:           status.LastForecastTime.DeepCopy(),
+		Conditions:                 status.DeepCopy().Conditions,
+	}
+	if window := status.SchedulingWindow; window != nil {
+		dst.Status.SchedulingWindow = &v1beta1.SchedulingWindow{Start: window.Start, End: window.End}
+	}
+	if savings := status.CarbonSavings; savings != nil {
+		dst.Status.CarbonSavings = &v1beta1.CarbonSavings{
+			VsWorstCase:         savings.VsWorstCase,
+			VsNaiveCase:         savings.VsNaiveCase,
+			VsMedianCase:        savings.VsMedianCase,
+			VsWorstCasePercent:  savingsQuantity(savings.VsWorstCasePercent, savings.VsWorstCase),
+			VsNaiveCasePercent:  savingsQuantity(savings.VsNaiveCasePercent, savings.VsNaiveCase),
+			VsMedianCasePercent: savingsQuantity(savings.VsMedianCasePercent, savings.VsMedianCase),
+		}
+	}
+	if decision := status.SchedulingDecision; decision != nil {
+		dst.Status.SchedulingDecision = &v1beta1.SchedulingDecision{
+			OptimalTime:        decision.OptimalTime.DeepCopy(),
+			WorstCaseTime:      decision.WorstCaseTime.DeepCopy(),
+			WorstCaseIntensity: intensityQuantity(decision.WorstCaseIntensityGramsPerKWh, decision.WorstCaseIntensity),
+			ImmediateIntensity: intensityQuantity(decision.ImmediateIntensityGramsPerKWh, decision.ImmediateIntensity),
+			OptimalIntensity:   intensityQuantity(decision.OptimalIntensityGramsPerKWh, decision.OptimalIntensity),
+			MedianIntensity:    copyQuantity(decision.MedianIntensityGramsPerKWh),
+			ForecastSource:     decision.ForecastSource,
+			DecisionReason:     decision.DecisionReason,
+			Strategy:           v1beta1.DecisionStrategy(decision.Strategy),
+			Region:             decision.Region,
+			GridZone:           decision.GridZone,
+		}
+	}
+	if policy := status.Policy; policy != nil {
+		dst.Status.Policy = &v1beta1.PolicyReference{Kind: policy.Kind, Name: policy.Name}
+	}
+	if emissions := status.Emissions; emissions != nil {
+		dst.Status.Emissions = &v1beta1.EmissionsEstimate{
+			StartTime:            emissions.StartTime,
+			EndTime:              emissions.EndTime,
+			EnergyKWh:            emissions.EnergyKWh.DeepCopy(),
+			IntensityGramsPerKWh: copyQuantity(emissions.IntensityGramsPerKWh),
+			IntensitySource:      emissions.IntensitySource,
+			EmittedGrams:         copyQuantity(emissions.EmittedGrams),
+			AvoidedGrams:         copyQuantity(emissions.AvoidedGrams),
+		}
+	}
+	for _, revision := range status.ScheduleRevisions {
+		dst.Status.ScheduleRevisions = append(dst.Status.ScheduleRevisions, v1beta1.ScheduleRevision{
+			RevisionTime: revision.RevisionTime,
+			PreviousTime: revision.PreviousTime,
+			NewTime:      revision.NewTime,
+			Reason:       revision.Reason,
+		})
+	}
+	for _, alternative := range status.Alternatives {
+		dst.Status.Alternatives = append(dst.Status.Alternatives, v1beta1.ScheduleAlternative{
+			Time:            alternative.Time,
+			Region:          alternative.Region,
+			GridZone:        alternative.GridZone,
+			CarbonIntensity: copyQuantity(alternative.CarbonIntensityGramsPerKWh),
+		})
+	}
+	return nil
+}
+
+// ConvertFrom converts from the hub version (v1beta1) to this version. The display strings of
+// intensities are formatted from their numeric values.
+func (dst *CarbonAwareJob) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1beta1.CarbonAwareJob)
+	if !ok {
+		return fmt.Errorf("expected a v1beta1 CarbonAwareJob but got %T", srcRaw)
+	}
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec = convertSpecFromHub(&src.Spec)
+
+	status := &src.Status
+	dst.Status = CarbonAwareJobStatus{
+		SubmissionTime:             status.SubmissionTime.DeepCopy(),
+		ScheduledTime:              status.ScheduledTime.DeepCopy(),
+		JobName:                    status.JobName,
+		JobStatus:                  status.JobStatus.DeepCopy(),
+		ReadyTime:                  status.ReadyTime.DeepCopy(),
+		SchedulingState:            string(status.SchedulingState),
+		CarbonIntensity:            status.CarbonIntensity,
+		CarbonIntensityGramsPerKWh: copyQuantity(status.CarbonIntensityGramsPerKWh),
+		LastForecastTime:           status.LastForecastTime.DeepCopy(),
+		Conditions:                 status.DeepCopy().Conditions,
+	}
+	if window := status.SchedulingWindow; window != nil {
+		dst.Status.SchedulingWindow = &SchedulingWindow{Start: window.Start, End: window.End}
+	}
+	if savings := status.CarbonSavings; savings != nil {
+		dst.Status.CarbonSavings = &CarbonSavings{
+			VsWorstCase:         savings.VsWorstCase,
+			VsNaiveCase:         savings.VsNaiveCase,
+			VsMedianCase:        savings.VsMedianCase,
+			VsWorstCasePercent:  copyQuantity(savings.VsWorstCasePercent),
+			VsNaiveCasePercent:  copyQuantity(savings.VsNaiveCasePercent),
+			VsMedianCasePercent: copyQuantity(savings.VsMedianCasePercent),
+		}
+	}
+	if decision := status.SchedulingDecision; decision != nil {
+		// Decisions of the fallback policy show their unknown intensities as such
+		unknown := ""
+		if decision.Strategy == v1beta1.DecisionFallback {
+			unknown = intensityUnknown
+		}
+		dst.Status.SchedulingDecision = &SchedulingDecision{
+			OptimalTime:                   decision.OptimalTime.DeepCopy(),
+			WorstCaseTime:                 decision.WorstCaseTime.DeepCopy(),
+			WorstCaseIntensity:            formatIntensity(decision.WorstCaseIntensity, unknown),
+			ImmediateIntensity:            formatIntensity(decision.ImmediateIntensity, unknown),
+			OptimalIntensity:              formatIntensity(decision.OptimalIntensity, unknown),
+			WorstCaseIntensityGramsPerKWh: copyQuantity(decision.WorstCaseIntensity),
+			ImmediateIntensityGramsPerKWh: copyQuantity(decision.ImmediateIntensity),
+			OptimalIntensityGramsPerKWh:   copyQuantity(decision.OptimalIntensity),
+			MedianIntensityGramsPerKWh:    copyQuantity(decision.MedianIntensity),
+			ForecastSource:                decision.ForecastSource,
+			DecisionReason:                decision.DecisionReason,
+			Strategy:                      string(decision.Strategy),
+			Region:                        decision.Region,
+			GridZone:                      decision.GridZone,
+		}
+	}
+	if policy := status.Policy; policy != nil {
+		dst.Status.Policy = &PolicyReference{Kind: policy.Kind, Name: policy.Name}
+	}
+	if emissions := status.Emissions; emissions != nil {
+		dst.Status.Emissions = &EmissionsEstimate{
+			StartTime:            emissions.StartTime,
+			EndTime:              emissions.EndTime,
+			EnergyKWh:            emissions.EnergyKWh.DeepCopy(),
+			IntensityGramsPerKWh: copyQuantity(emissions.IntensityGramsPerKWh),
+			IntensitySource:      emissions.IntensitySource,
+			EmittedGrams:         copyQuantity(emissions.EmittedGrams),
+			AvoidedGrams:         copyQuantity(emissions.AvoidedGrams),
+		}
+	}
+	for _, revision := range status.ScheduleRevisions {
+		dst.Status.ScheduleRevisions = append(dst.Status.ScheduleRevisions, ScheduleRevision{
+			RevisionTime: revision.RevisionTime,
+			PreviousTime: revision.PreviousTime,
+			NewTime:      revision.NewTime,
+			Reason:       revision.Reason,
+		})
+	}
+	for _, alternative := range status.Alternatives {
+		dst.Status.Alternatives = append(dst.Status.Alternatives, ScheduleAlternative{
+			Time:                       alternative.Time,
+			Region:                     alternative.Region,
+			GridZone:                   alternative.GridZone,
+			CarbonIntensityGramsPerKWh: copyQuantity(alternative.CarbonIntensity),
+		})
+	}
+	return nil
+}
+
+// convertSpecToHub converts a CarbonAwareJobSpec to v1beta1, where it has the same fields
+func convertSpecToHub(src *CarbonAwareJobSpec) v1beta1.CarbonAwareJobSpec {
+	src = src.DeepCopy()
+	dst := v1beta1.CarbonAwareJobSpec{
+		Template: v1beta1.JobTemplateSpec{
+			Metadata: src.Template.Metadata,
+			Spec:     src.Template.Spec,
+		},
+		MaxDelay:    src.MaxDelay,
+		MaxDuration: src.MaxDuration,
+		Deadline:    src.Deadline,
+		JobCreation: v1beta1.JobCreationPolicy(src.JobCreation),
+	}
+	if fallback := src.Fallback; fallback != nil {
+		dst.Fallback = &v1beta1.FallbackPolicy{
+			Strategy:   v1beta1.FallbackStrategy(fallback.Strategy),
+			LowHourUTC: fallback.LowHourUTC,
+		}
+	}
+	if placement := src.Placement; placement != nil {
+		dst.Placement = &v1beta1.Placement{
+			Regions:       placement.Regions,
+			Zones:         placement.Zones,
+			AnyNodeRegion: placement.AnyNodeRegion,
+		}
+	}
+	if strategy := src.Strategy; strategy != nil {
+		dst.Strategy = &v1beta1.SchedulingStrategy{
+			Type:               v1beta1.SchedulingStrategyType(strategy.Type),
+			ThresholdIntensity: strategy.ThresholdIntensity,
+			Percentile:         strategy.Percentile,
+		}
+	}
+	return dst
+}
+
+// convertSpecFromHub converts a v1beta1 CarbonAwareJobSpec to this version
+func convertSpecFromHub(src *v1beta1.CarbonAwareJobSpec) CarbonAwareJobSpec {
+	src = src.DeepCopy()
+	dst := CarbonAwareJobSpec{
+		Template: JobTemplateSpec{
+			Metadata: src.Template.Metadata,
+			Spec:     src.Template.Spec,
+		},
+		MaxDelay:    src.MaxDelay,
+		MaxDuration: src.MaxDuration,
+		Deadline:    src.Deadline,
+		JobCreation: JobCreationPolicy(src.JobCreation),
+	}
+	if fallback := src.Fallback; fallback != nil {
+		dst.Fallback = &FallbackPolicy{
+			Strategy:   FallbackStrategy(fallback.Strategy),
+			LowHourUTC: fallback.LowHourUTC,
+		}
+	}
+	if placement := src.Placement; placement != nil {
+		dst.Placement = &Placement{
+			Regions:       placement.Regions,
+			Zones:         placement.Zones,
+			AnyNodeRegion: placement.AnyNodeRegion,
+		}
+	}
+	if strategy := src.Strategy; strategy != nil {
+		dst.Strategy = &SchedulingStrategy{
+			Type:               SchedulingStrategyType(strategy.Type),
+			ThresholdIntensity: strategy.ThresholdIntensity,
+			Percentile:         strategy.Percentile,
+		}
+	}
+	return dst
+}
+
+// intensityQuantity returns a numeric intensity, parsing the display string, e.g.
+// "123.45 gCO2eq/kWh", if it is unset. Nil is returned for unknown intensities.
+func intensityQuantity(quantity *resource.Quantity, display string) *resource.Quantity {
+	if quantity != nil {
+		return copyQuantity(quantity)
+	}
+	value, _, _ := strings.Cut(display, " ")
+	return parseDecimal(value)
+}
+
+// savingsQuantity returns a numeric savings percentage, parsing the display string, e.g.
+// "-12.00%" for 12% saved, if it is unset
+func savingsQuantity(quantity *resource.Quantity, display string) *resource.Quantity {
+	if quantity != nil {
+		return copyQuantity(quantity)
+	}
+	change := parseDecimal(strings.TrimSuffix(display, "%"))
+	if change != nil {
+		change.Neg()
+	}
+	return change
+}
+
+// parseDecimal parses a decimal number into a quantity rounded to three decimal places, or
+// returns nil if it is not a number
+func parseDecimal(s string) *resource.Quantity {
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil
+	}
+	return resource.NewMilliQuantity(int64(math.Round(value*1000)), resource.DecimalSI)
+}
+
+// formatIntensity formats a numeric intensity for display, or returns unknown if it is unset
+func formatIntensity(quantity *resource.Quantity, unknown string) string {
+	if quantity == nil {
+		return unknown
+	}
+	return fmt.Sprintf("%.2f gCO2eq/kWh", quantity.AsApproximateFloat64())
+}
+
+func copyQuantity(quantity *resource.Quantity) *resource.Quantity {
+	if quantity == nil {
+		return nil
+	}
+	copied := quantity.DeepCopy()
+	return &copied
+}
@@ -17,14 +17,43 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"time"
+
 	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// DefaultMaxDuration is the expected run time of a CarbonAwareJob that does not set MaxDuration,
+// unless the cluster configures a different default
+const DefaultMaxDuration = time.Hour
+
+// Annotations and scheduling gate that make arbitrary Pods carbon-aware without a CarbonAwareJob
+const (
+	// AnnotationMaxDelay is the maximum time a Pod may be held back from scheduling
+	AnnotationMaxDelay = "carbonaware.dev/max-delay"
+
+	// AnnotationMaxDuration is the time a Pod is expected to run. Defaults to the cluster's
+	// default MaxDuration
+	AnnotationMaxDuration = "carbonaware.dev/max-duration"
+
+	// PodSchedulingGate holds an annotated Pod back from scheduling until its start time
+	PodSchedulingGate = "carbonaware.dev/carbon-aware"
+
+	// LabelSchedulingGate opts a Pod in to the scheduling gate when set to "true". The Pod
+	// webhook only receives Pods with this label, so other Pods never wait on the operator
+	LabelSchedulingGate = "carbonaware.dev/scheduling-gate"
+
+	// LabelSchedulingGated marks Pods carrying the scheduling gate, so that the operator only
+	// caches those Pods rather than every Pod in the cluster
+	LabelSchedulingGated = "carbonaware.dev/gated"
+)
+
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
 // CarbonAwareJobSpec defines the desired state of CarbonAwareJob
+// +kubebuilder:validation:XValidation:rule="has(self.maxDelay) || has(self.deadline)",message="at least one of maxDelay or deadline must be set"
 type CarbonAwareJobSpec struct {
 	// Template is the job template that will be created when the carbon intensity is optimal
 	// This follows the same structure as the Kubernetes Job template
@@ -33,14 +62,145 @@ type CarbonAwareJobSpec struct {
 
 	// MaxDelay defines the maximum time to delay the job execution from submission time
 	// The controller will schedule the job at the optimal time within this delay window
-	// based on carbon intensity forecasts
-	// +kubebuilder:validation:Required
-	MaxDelay metav1.Duration `json:"maxDelay"`
+	// based on carbon intensity forecasts. Required unless Deadline is set
+	// +optional
+	MaxDelay metav1.Duration `json:"maxDelay,omitempty"`
 
 	// MaxDuration is the maximum duration the job is expected to run
 	// This is used to calculate the optimal start time to minimize carbon emissions
 	// +optional
 	MaxDuration *metav1.Duration `json:"maxDuration,omitempty"`
+
+	// Deadline is the absolute wall-clock time by which the job must have finished
+	// The latest start time is Deadline minus MaxDuration. If MaxDelay is also set,
+	// the earlier of the two window ends is used
+	// +optional
+	Deadline *metav1.Time `json:"deadline,omitempty"`
+
+	// Fallback defines when the job runs if no forecast could be obtained before
+	// the operator's retry cutoff. Defaults to running immediately
+	// +optional
+	Fallback *FallbackPolicy `json:"fallback,omitempty"`
+
+	// Placement restricts the regions and zones the job may run in. When set, forecasts
+	// are requested for every allowed region and the Job is pinned to the greenest one
+	// +optional
+	Placement *Placement `json:"placement,omitempty"`
+
+	// Strategy determines how the start time is chosen from the forecast. Defaults to
+	// starting at the time with the lowest forecast carbon intensity
+	// +optional
+	Strategy *SchedulingStrategy `json:"strategy,omitempty"`
+
+	// JobCreation determines when the Job is created. Deferred creates it at the scheduled
+	// time, Suspended creates it right away with spec.suspend set and resumes it at the
+	// scheduled time, so that the Job is validated and visible to queueing tools up front
+	// +kubebuilder:default=Deferred
+	// +optional
+	JobCreation JobCreationPolicy `json:"jobCreation,omitempty"`
+}
+
+// JobCreationPolicy determines when the Job of a CarbonAwareJob is created
+// +kubebuilder:validation:Enum=Deferred;Suspended
+type JobCreationPolicy string
+
+const (
+	// JobCreationDeferred creates the Job at the scheduled time
+	JobCreationDeferred JobCreationPolicy = "Deferred"
+
+	// JobCreationSuspended creates the Job suspended when the CarbonAwareJob is scheduled
+	// and resumes it at the scheduled time
+	JobCreationSuspended JobCreationPolicy = "Suspended"
+)
+
+// SchedulingStrategyType determines how the start time of a job is chosen from the forecast
+// +kubebuilder:validation:Enum=Optimal;Threshold;FirstBelowPercentile
+type SchedulingStrategyType string
+
+const (
+	// StrategyOptimal starts the job at the time with the lowest forecast carbon intensity
+	StrategyOptimal SchedulingStrategyType = "Optimal"
+
+	// StrategyThreshold starts the job at the first time the forecast carbon intensity is
+	// at or below ThresholdIntensity
+	StrategyThreshold SchedulingStrategyType = "Threshold"
+
+	// StrategyFirstBelowPercentile starts the job at the first time the forecast carbon
+	// intensity is at or below the given percentile of the forecast within the window
+	StrategyFirstBelowPercentile SchedulingStrategyType = "FirstBelowPercentile"
+)
+
+// SchedulingStrategy defines how the start time of a job is chosen from the forecast. The
+// Threshold and FirstBelowPercentile strategies start at the optimal time if the forecast
+// never reaches their limit within the window
+// +kubebuilder:validation:XValidation:rule="self.type != 'Threshold' || has(self.thresholdIntensity)",message="thresholdIntensity is required for the Threshold strategy"
+// +kubebuilder:validation:XValidation:rule="self.type != 'FirstBelowPercentile' || has(self.percentile)",message="percentile is required for the FirstBelowPercentile strategy"
+type SchedulingStrategy struct {
+	// Type is the strategy used to choose the start time
+	// +kubebuilder:default=Optimal
+	// +optional
+	Type SchedulingStrategyType `json:"type,omitempty"`
+
+	// ThresholdIntensity is the carbon intensity in gCO2eq/kWh below which the
+	// Threshold strategy starts the job
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ThresholdIntensity *int32 `json:"thresholdIntensity,omitempty"`
+
+	// Percentile is the percentile of the forecast intensities within the window below
+	// which the FirstBelowPercentile strategy starts the job
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=99
+	// +optional
+	Percentile *int32 `json:"percentile,omitempty"`
+}
+
+// FallbackStrategy determines the start time of a job when no forecast is available
+// +kubebuilder:validation:Enum=Immediate;WindowEnd;HistoricalLow
+type FallbackStrategy string
+
+const (
+	// FallbackImmediate starts the job as soon as the operator gives up on the forecast
+	FallbackImmediate FallbackStrategy = "Immediate"
+
+	// FallbackWindowEnd starts the job at the end of its scheduling window
+	FallbackWindowEnd FallbackStrategy = "WindowEnd"
+
+	// FallbackHistoricalLow starts the job at the hour of day that previous forecasts
+	// for the same zone most often found to have the lowest carbon intensity
+	FallbackHistoricalLow FallbackStrategy = "HistoricalLow"
+)
+
+// FallbackPolicy defines how a job is scheduled when the forecast is unavailable
+type FallbackPolicy struct {
+	// Strategy is the fallback to apply once the operator stops retrying the forecast
+	// +kubebuilder:default=Immediate
+	// +optional
+	Strategy FallbackStrategy `json:"strategy,omitempty"`
+
+	// LowHourUTC is the hour of day (UTC) used by the HistoricalLow strategy while the
+	// operator has not yet observed any forecasts for the zone
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=23
+	// +optional
+	LowHourUTC *int32 `json:"lowHourUTC,omitempty"`
+}
+
+// Placement defines where a job may run
+type Placement struct {
+	// Regions lists the cloud regions (topology.kubernetes.io/region) the job may run in
+	// +optional
+	Regions []string `json:"regions,omitempty"`
+
+	// Zones lists the availability zones (topology.kubernetes.io/zone) the job may run in.
+	// The regions of these zones are looked up from the cluster's nodes, and a job placed
+	// in such a region is restricted to the listed zones within it
+	// +optional
+	Zones []string `json:"zones,omitempty"`
+
+	// AnyNodeRegion allows every region the cluster has nodes in
+	// +optional
+	AnyNodeRegion bool `json:"anyNodeRegion,omitempty"`
 }
 
 // JobTemplateSpec is a subset of the Kubernetes batch/v1.JobTemplateSpec
@@ -56,19 +216,36 @@ type JobTemplateSpec struct {
 	Spec batchv1.JobSpec `json:"spec"`
 }
 
-// CarbonSavings represents the carbon savings compared to different scenarios
+// CarbonSavings represents the carbon savings compared to different scenarios. The string
+// fields are for display and show the change in emissions, e.g. "-12.00%" for a 12% saving.
+// The Percent fields hold the same values as the percentage of emissions saved, e.g. 12.
 type CarbonSavings struct {
-	// VsWorstCase is the percentage of carbon saved compared to worst case
+	// VsWorstCase is the change in emissions compared to worst case
 	// +optional
 	VsWorstCase string `json:"vsWorstCase,omitempty"`
 
-	// VsNaiveCase is the percentage of carbon saved compared to naive case
+	// VsNaiveCase is the change in emissions compared to naive case
 	// +optional
 	VsNaiveCase string `json:"vsNaiveCase,omitempty"`
 
-	// VsMedianCase is the percentage of carbon saved compared to median case
+	// VsMedianCase is the change in emissions compared to median case
 	// +optional
 	VsMedianCase string `json:"vsMedianCase,omitempty"`
+
+	// VsWorstCasePercent is the percentage of emissions saved compared to worst case.
+	// Negative if the chosen time emits more
+	// +optional
+	VsWorstCasePercent *resource.Quantity `json:"vsWorstCasePercent,omitempty"`
+
+	// VsNaiveCasePercent is the percentage of emissions saved compared to running immediately.
+	// Negative if the chosen time emits more
+	// +optional
+	VsNaiveCasePercent *resource.Quantity `json:"vsNaiveCasePercent,omitempty"`
+
+	// VsMedianCasePercent is the percentage of emissions saved compared to median case.
+	// Negative if the chosen time emits more
+	// +optional
+	VsMedianCasePercent *resource.Quantity `json:"vsMedianCasePercent,omitempty"`
 }
 
 // SchedulingDecision contains details about the carbon-aware scheduling decision
@@ -93,6 +270,23 @@ type SchedulingDecision struct {
 	// +optional
 	OptimalIntensity string `json:"optimalIntensity,omitempty"`
 
+	// WorstCaseIntensityGramsPerKWh is WorstCaseIntensity in gCO2eq/kWh, unset if unknown
+	// +optional
+	WorstCaseIntensityGramsPerKWh *resource.Quantity `json:"worstCaseIntensityGramsPerKWh,omitempty"`
+
+	// ImmediateIntensityGramsPerKWh is ImmediateIntensity in gCO2eq/kWh, unset if unknown
+	// +optional
+	ImmediateIntensityGramsPerKWh *resource.Quantity `json:"immediateIntensityGramsPerKWh,omitempty"`
+
+	// OptimalIntensityGramsPerKWh is OptimalIntensity in gCO2eq/kWh, unset if unknown
+	// +optional
+	OptimalIntensityGramsPerKWh *resource.Quantity `json:"optimalIntensityGramsPerKWh,omitempty"`
+
+	// MedianIntensityGramsPerKWh is the median carbon intensity of the start times within the
+	// scheduling window in gCO2eq/kWh, unset if unknown
+	// +optional
+	MedianIntensityGramsPerKWh *resource.Quantity `json:"medianIntensityGramsPerKWh,omitempty"`
+
 	// ForecastSource indicates the source of the carbon intensity forecast data
 	// +optional
 	ForecastSource string `json:"forecastSource,omitempty"`
@@ -100,6 +294,121 @@ type SchedulingDecision struct {
 	// DecisionReason provides the reason for the scheduling decision
 	// +optional
 	DecisionReason string `json:"decisionReason,omitempty"`
+
+	// Strategy is the scheduling strategy that produced the scheduled time, or Fallback
+	// if the fallback policy chose it
+	// +optional
+	Strategy string `json:"strategy,omitempty"`
+
+	// Region is the cloud region the scheduler chose to run the job in
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// GridZone is the electricity grid zone whose forecast the job was optimized for,
+	// e.g. "US-MIDA-PJM"
+	// +optional
+	GridZone string `json:"gridZone,omitempty"`
+}
+
+// Sources of the carbon intensity an EmissionsEstimate is based on
+const (
+	// IntensitySourceRealized is the intensity reported for the interval the job actually ran
+	IntensitySourceRealized = "Realized"
+
+	// IntensitySourceForecast is the forecast intensity at the scheduled time, used when the
+	// realized intensity is not available
+	IntensitySourceForecast = "Forecast"
+)
+
+// EmissionsEstimate is the estimated energy use and emissions of a finished job. Energy is
+// estimated from the resource requests of its pods and its actual run time with the operator's
+// power model.
+type EmissionsEstimate struct {
+	// StartTime is when the job started running
+	StartTime metav1.Time `json:"startTime"`
+
+	// EndTime is when the job completed or failed
+	EndTime metav1.Time `json:"endTime"`
+
+	// EnergyKWh is the estimated energy used by the job in kWh
+	EnergyKWh resource.Quantity `json:"energyKWh"`
+
+	// IntensityGramsPerKWh is the average carbon intensity in gCO2eq/kWh the emissions are
+	// based on
+	// +optional
+	IntensityGramsPerKWh *resource.Quantity `json:"intensityGramsPerKWh,omitempty"`
+
+	// IntensitySource is Realized if IntensityGramsPerKWh and the intensity AvoidedGrams
+	// compares it to were measured for the interval the job ran and for as long from its
+	// submission, or Forecast if both are the forecasts at the scheduled and submission time.
+	// It is unset if the intensity is unknown
+	// +optional
+	// +kubebuilder:validation:Enum=Realized;Forecast
+	IntensitySource string `json:"intensitySource,omitempty"`
+
+	// EmittedGrams is the estimated gCO2eq emitted by the job
+	// +optional
+	EmittedGrams *resource.Quantity `json:"emittedGrams,omitempty"`
+
+	// AvoidedGrams is the estimated gCO2eq avoided compared to running the job when it was
+	// submitted, with both intensities taken from IntensitySource. It is negative if the job
+	// emitted more than it would have.
+	// +optional
+	AvoidedGrams *resource.Quantity `json:"avoidedGrams,omitempty"`
+}
+
+// SchedulingWindow is the range of start times the job was optimized over
+type SchedulingWindow struct {
+	// Start is the earliest time the job may start
+	Start metav1.Time `json:"start"`
+
+	// End is the latest time the job may start
+	End metav1.Time `json:"end"`
+}
+
+// ScheduleRevision records a change of ScheduledTime after a forecast re-evaluation
+type ScheduleRevision struct {
+	// RevisionTime is when the schedule was changed
+	RevisionTime metav1.Time `json:"revisionTime"`
+
+	// PreviousTime is the scheduled time before the change
+	PreviousTime metav1.Time `json:"previousTime"`
+
+	// NewTime is the scheduled time after the change
+	NewTime metav1.Time `json:"newTime"`
+
+	// Reason explains why the schedule was changed
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+// ScheduleAlternative is a start time of the forecast that a CarbonAwareJob moves to if its Job
+// cannot be started at the scheduled time
+type ScheduleAlternative struct {
+	// Time is the start time of the alternative
+	Time metav1.Time `json:"time"`
+
+	// Region is the cloud region of the alternative
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// GridZone is the electricity grid zone of the alternative, e.g. "US-MIDA-PJM"
+	// +optional
+	GridZone string `json:"gridZone,omitempty"`
+
+	// CarbonIntensityGramsPerKWh is the forecast carbon intensity of the alternative in gCO2eq/kWh
+	// +optional
+	CarbonIntensityGramsPerKWh *resource.Quantity `json:"carbonIntensityGramsPerKWh,omitempty"`
+}
+
+// PolicyReference identifies the CarbonPolicy or ClusterCarbonPolicy applied to a CarbonAwareJob
+type PolicyReference struct {
+	// Kind is CarbonPolicy or ClusterCarbonPolicy
+	// +kubebuilder:validation:Enum=CarbonPolicy;ClusterCarbonPolicy
+	Kind string `json:"kind"`
+
+	// Name is the name of the policy. A CarbonPolicy is in the namespace of the CarbonAwareJob
+	Name string `json:"name"`
 }
 
 // CarbonAwareJobStatus defines the observed state of CarbonAwareJob
@@ -112,6 +421,11 @@ type CarbonAwareJobStatus struct {
 	// +optional
 	ScheduledTime *metav1.Time `json:"scheduledTime,omitempty"`
 
+	// SchedulingWindow is the effective window of start times derived from
+	// MaxDelay, Deadline and MaxDuration
+	// +optional
+	SchedulingWindow *SchedulingWindow `json:"schedulingWindow,omitempty"`
+
 	// JobName is the name of the Kubernetes Job that was created
 	// +optional
 	JobName string `json:"jobName,omitempty"`
@@ -120,14 +434,23 @@ type CarbonAwareJobStatus struct {
 	// +optional
 	JobStatus *batchv1.JobStatus `json:"jobStatus,omitempty"`
 
+	// ReadyTime is when a pod of the Job was first seen ready. A Job that was ready once is
+	// not restarted at an alternative start time
+	// +optional
+	ReadyTime *metav1.Time `json:"readyTime,omitempty"`
+
 	// SchedulingState represents the current state of the carbon-aware scheduling process
 	// +optional
 	SchedulingState string `json:"schedulingState,omitempty"`
 
-	// CarbonIntensity is the forecasted carbon intensity at the scheduled time
+	// CarbonIntensity is the forecasted carbon intensity at the scheduled time, for display
 	// +optional
 	CarbonIntensity string `json:"carbonIntensity,omitempty"`
 
+	// CarbonIntensityGramsPerKWh is CarbonIntensity in gCO2eq/kWh, unset if unknown
+	// +optional
+	CarbonIntensityGramsPerKWh *resource.Quantity `json:"carbonIntensityGramsPerKWh,omitempty"`
+
 	// CarbonSavings is the estimated carbon savings compared to running at peak intensity
 	// +optional
 	CarbonSavings *CarbonSavings `json:"carbonSavings,omitempty"`
@@ -136,6 +459,31 @@ type CarbonAwareJobStatus struct {
 	// +optional
 	SchedulingDecision *SchedulingDecision `json:"schedulingDecision,omitempty"`
 
+	// Policy is the CarbonPolicy or ClusterCarbonPolicy merged into the spec while the job
+	// was scheduled
+	// +optional
+	Policy *PolicyReference `json:"policy,omitempty"`
+
+	// Emissions is the estimated energy use and emissions of the Job once it has finished
+	// +optional
+	Emissions *EmissionsEstimate `json:"emissions,omitempty"`
+
+	// LastForecastTime is when the carbon intensity forecast was last evaluated
+	// +optional
+	LastForecastTime *metav1.Time `json:"lastForecastTime,omitempty"`
+
+	// ScheduleRevisions lists the most recent changes of ScheduledTime made while
+	// the job was pending, oldest first
+	// +optional
+	// +listType=atomic
+	ScheduleRevisions []ScheduleRevision `json:"scheduleRevisions,omitempty"`
+
+	// Alternatives are the next best start times of the forecast, best first. If the Job
+	// cannot be started at ScheduledTime, the job moves to the first one that has not passed
+	// +optional
+	// +listType=atomic
+	Alternatives []ScheduleAlternative `json:"alternatives,omitempty"`
+
 	// Conditions represent the latest available observations of the job's current state
 	// +optional
 	// +patchMergeKey=type
@@ -148,6 +496,8 @@ type CarbonAwareJobStatus struct {
 // +kubebuilder:printcolumn:name="Scheduled",type="string",JSONPath=".status.scheduledTime",description="Time when the job is scheduled to run"
 // +kubebuilder:printcolumn:name="Job",type="string",JSONPath=".status.jobName",description="Name of the created Kubernetes Job"
 // +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.schedulingState",description="Current scheduling state"
+// +kubebuilder:printcolumn:name="Intensity",type="string",JSONPath=".status.carbonIntensity",description="Forecast carbon intensity at the scheduled time"
+// +kubebuilder:printcolumn:name="Savings",type="string",JSONPath=".status.carbonSavings.vsNaiveCase",description="Change in emissions compared to running immediately"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:resource:shortName=cajob;carbonjob
 // +kubebuilder:storageversion
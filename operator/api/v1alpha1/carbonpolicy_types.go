@@ -0,0 +1,139 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CarbonPolicySpec defines the carbon behavior a platform team enforces on CarbonAwareJobs
+type CarbonPolicySpec struct {
+	// Selector limits the policy to CarbonAwareJobs with matching labels. Selects all
+	// CarbonAwareJobs if not set
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// MaxDelay is the MaxDelay of CarbonAwareJobs that set neither MaxDelay nor Deadline.
+	// Applied by the defaulting webhook when the job is created
+	// +optional
+	MaxDelay *metav1.Duration `json:"maxDelay,omitempty"`
+
+	// MaxDuration is the MaxDuration of CarbonAwareJobs that do not set it, instead of the
+	// cluster default
+	// +optional
+	MaxDuration *metav1.Duration `json:"maxDuration,omitempty"`
+
+	// AllowedRegions lists the cloud regions CarbonAwareJobs may run in. Regions of a job's
+	// placement or of its nodes outside this list are not considered. Allows all regions if empty
+	// +optional
+	AllowedRegions []string `json:"allowedRegions,omitempty"`
+
+	// MinSavingsPercent is the reduction in carbon intensity compared to running immediately
+	// that a delay has to achieve. Jobs whose best start time saves less start immediately
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MinSavingsPercent *int32 `json:"minSavingsPercent,omitempty"`
+
+	// ForbiddenTimeRanges are recurring times of day at which CarbonAwareJobs are not started.
+	// A job whose window only allows forbidden start times starts at the end of its window
+	// +optional
+	// +listType=atomic
+	ForbiddenTimeRanges []TimeRange `json:"forbiddenTimeRanges,omitempty"`
+
+	// Fallback is the fallback policy of CarbonAwareJobs that do not set one
+	// +optional
+	Fallback *FallbackPolicy `json:"fallback,omitempty"`
+
+	// Concurrency limits how many of the CarbonAwareJobs the policy applies to run at the same
+	// time, so that they do not all start at the greenest time of a shared window
+	// +optional
+	Concurrency *ConcurrencyLimit `json:"concurrency,omitempty"`
+}
+
+// ConcurrencyScope determines which CarbonAwareJobs share a concurrency limit
+// +kubebuilder:validation:Enum=Policy;Namespace
+type ConcurrencyScope string
+
+const (
+	// ConcurrencyScopePolicy limits all CarbonAwareJobs the policy applies to together
+	ConcurrencyScopePolicy ConcurrencyScope = "Policy"
+
+	// ConcurrencyScopeNamespace limits the CarbonAwareJobs the policy applies to in each
+	// namespace separately
+	ConcurrencyScopeNamespace ConcurrencyScope = "Namespace"
+)
+
+// ConcurrencyLimit caps the number of CarbonAwareJobs running at the same time. Jobs whose best
+// start time is taken start at the next best forecast option with a free slot, and jobs whose start
+// time has come wait for a free slot until the end of their window
+type ConcurrencyLimit struct {
+	// MaxJobs is the number of CarbonAwareJobs that may run at the same time
+	// +kubebuilder:validation:Minimum=1
+	MaxJobs int32 `json:"maxJobs"`
+
+	// Scope determines whether the limit applies to all jobs of the policy or to the jobs of
+	// each namespace
+	// +kubebuilder:default=Policy
+	// +optional
+	Scope ConcurrencyScope `json:"scope,omitempty"`
+}
+
+// TimeRange is a recurring range of the day. A range whose end is before its start spans midnight
+// +kubebuilder:validation:XValidation:rule="self.start != self.end",message="start and end must differ"
+type TimeRange struct {
+	// Start is the time of day the range begins, in HH:MM format
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+
+	// End is the time of day the range ends, exclusive, in HH:MM format
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	End string `json:"end"`
+
+	// TimeZone is the name of the time zone of Start and End, see
+	// https://en.wikipedia.org/wiki/List_of_tz_database_time_zones. Defaults to UTC
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Max Delay",type="string",JSONPath=".spec.maxDelay",description="Default scheduling window"
+// +kubebuilder:printcolumn:name="Min Savings",type="integer",JSONPath=".spec.minSavingsPercent",description="Savings in percent required before delaying"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:shortName=cpol
+
+// CarbonPolicy is the Schema for the carbonpolicies API. It applies to the CarbonAwareJobs
+// in its namespace and takes precedence over ClusterCarbonPolicies
+type CarbonPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CarbonPolicySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CarbonPolicyList contains a list of CarbonPolicy
+type CarbonPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CarbonPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CarbonPolicy{}, &CarbonPolicyList{})
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterCarbonPolicySpec defines the carbon behavior enforced on CarbonAwareJobs across namespaces
+type ClusterCarbonPolicySpec struct {
+	// NamespaceSelector limits the policy to CarbonAwareJobs in namespaces with matching labels.
+	// Selects all namespaces if not set
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	CarbonPolicySpec `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName=ccpol
+// +kubebuilder:printcolumn:name="Max Delay",type="string",JSONPath=".spec.maxDelay",description="Default scheduling window"
+// +kubebuilder:printcolumn:name="Min Savings",type="integer",JSONPath=".spec.minSavingsPercent",description="Savings in percent required before delaying"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ClusterCarbonPolicy is the Schema for the clustercarbonpolicies API. It applies to the
+// CarbonAwareJobs of namespaces without a matching CarbonPolicy
+type ClusterCarbonPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ClusterCarbonPolicySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterCarbonPolicyList contains a list of ClusterCarbonPolicy
+type ClusterCarbonPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterCarbonPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterCarbonPolicy{}, &ClusterCarbonPolicyList{})
+}
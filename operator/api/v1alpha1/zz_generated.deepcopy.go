@@ -22,10 +22,139 @@ package v1alpha1
 
 import (
 	batchv1 "k8s.io/api/batch/v1"
-	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CarbonAwareCronJob) DeepCopyInto(out *CarbonAwareCronJob) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CarbonAwareCronJob.
+func (in *CarbonAwareCronJob) DeepCopy() *CarbonAwareCronJob {
+	if in == nil {
+		return nil
+	}
+	out := new(CarbonAwareCronJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CarbonAwareCronJob) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CarbonAwareCronJobList) DeepCopyInto(out *CarbonAwareCronJobList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CarbonAwareCronJob, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CarbonAwareCronJobList.
+func (in *CarbonAwareCronJobList) DeepCopy() *CarbonAwareCronJobList {
+	if in == nil {
+		return nil
+	}
+	out := new(CarbonAwareCronJobList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CarbonAwareCronJobList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CarbonAwareCronJobSpec) DeepCopyInto(out *CarbonAwareCronJobSpec) {
+	*out = *in
+	if in.TimeZone != nil {
+		in, out := &in.TimeZone, &out.TimeZone
+		*out = new(string)
+		**out = **in
+	}
+	if in.StartingDeadlineSeconds != nil {
+		in, out := &in.StartingDeadlineSeconds, &out.StartingDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Suspend != nil {
+		in, out := &in.Suspend, &out.Suspend
+		*out = new(bool)
+		**out = **in
+	}
+	in.JobTemplate.DeepCopyInto(&out.JobTemplate)
+	if in.SuccessfulJobsHistoryLimit != nil {
+		in, out := &in.SuccessfulJobsHistoryLimit, &out.SuccessfulJobsHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailedJobsHistoryLimit != nil {
+		in, out := &in.FailedJobsHistoryLimit, &out.FailedJobsHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CarbonAwareCronJobSpec.
+func (in *CarbonAwareCronJobSpec) DeepCopy() *CarbonAwareCronJobSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CarbonAwareCronJobSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CarbonAwareCronJobStatus) DeepCopyInto(out *CarbonAwareCronJobStatus) {
+	*out = *in
+	if in.Active != nil {
+		in, out := &in.Active, &out.Active
+		*out = make([]v1.ObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSuccessfulTime != nil {
+		in, out := &in.LastSuccessfulTime, &out.LastSuccessfulTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CarbonAwareCronJobStatus.
+func (in *CarbonAwareCronJobStatus) DeepCopy() *CarbonAwareCronJobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CarbonAwareCronJobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CarbonAwareJob) DeepCopyInto(out *CarbonAwareJob) {
 	*out = *in
@@ -92,9 +221,28 @@ func (in *CarbonAwareJobSpec) DeepCopyInto(out *CarbonAwareJobSpec) {
 	out.MaxDelay = in.MaxDelay
 	if in.MaxDuration != nil {
 		in, out := &in.MaxDuration, &out.MaxDuration
-		*out = new(v1.Duration)
+		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.Deadline != nil {
+		in, out := &in.Deadline, &out.Deadline
+		*out = (*in).DeepCopy()
+	}
+	if in.Fallback != nil {
+		in, out := &in.Fallback, &out.Fallback
+		*out = new(FallbackPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Placement != nil {
+		in, out := &in.Placement, &out.Placement
+		*out = new(Placement)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(SchedulingStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CarbonAwareJobSpec.
@@ -118,24 +266,66 @@ func (in *CarbonAwareJobStatus) DeepCopyInto(out *CarbonAwareJobStatus) {
 		in, out := &in.ScheduledTime, &out.ScheduledTime
 		*out = (*in).DeepCopy()
 	}
+	if in.SchedulingWindow != nil {
+		in, out := &in.SchedulingWindow, &out.SchedulingWindow
+		*out = new(SchedulingWindow)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.JobStatus != nil {
 		in, out := &in.JobStatus, &out.JobStatus
 		*out = new(batchv1.JobStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ReadyTime != nil {
+		in, out := &in.ReadyTime, &out.ReadyTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CarbonIntensityGramsPerKWh != nil {
+		in, out := &in.CarbonIntensityGramsPerKWh, &out.CarbonIntensityGramsPerKWh
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 	if in.CarbonSavings != nil {
 		in, out := &in.CarbonSavings, &out.CarbonSavings
 		*out = new(CarbonSavings)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.SchedulingDecision != nil {
 		in, out := &in.SchedulingDecision, &out.SchedulingDecision
 		*out = new(SchedulingDecision)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(PolicyReference)
+		**out = **in
+	}
+	if in.Emissions != nil {
+		in, out := &in.Emissions, &out.Emissions
+		*out = new(EmissionsEstimate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastForecastTime != nil {
+		in, out := &in.LastForecastTime, &out.LastForecastTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ScheduleRevisions != nil {
+		in, out := &in.ScheduleRevisions, &out.ScheduleRevisions
+		*out = make([]ScheduleRevision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Alternatives != nil {
+		in, out := &in.Alternatives, &out.Alternatives
+		*out = make([]ScheduleAlternative, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
+		*out = make([]metav1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -152,9 +342,154 @@ func (in *CarbonAwareJobStatus) DeepCopy() *CarbonAwareJobStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CarbonAwareJobTemplateSpec) DeepCopyInto(out *CarbonAwareJobTemplateSpec) {
+	*out = *in
+	in.Metadata.DeepCopyInto(&out.Metadata)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CarbonAwareJobTemplateSpec.
+func (in *CarbonAwareJobTemplateSpec) DeepCopy() *CarbonAwareJobTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CarbonAwareJobTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CarbonPolicy) DeepCopyInto(out *CarbonPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CarbonPolicy.
+func (in *CarbonPolicy) DeepCopy() *CarbonPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(CarbonPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CarbonPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CarbonPolicyList) DeepCopyInto(out *CarbonPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CarbonPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CarbonPolicyList.
+func (in *CarbonPolicyList) DeepCopy() *CarbonPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(CarbonPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CarbonPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CarbonPolicySpec) DeepCopyInto(out *CarbonPolicySpec) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxDelay != nil {
+		in, out := &in.MaxDelay, &out.MaxDelay
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxDuration != nil {
+		in, out := &in.MaxDuration, &out.MaxDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.AllowedRegions != nil {
+		in, out := &in.AllowedRegions, &out.AllowedRegions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MinSavingsPercent != nil {
+		in, out := &in.MinSavingsPercent, &out.MinSavingsPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ForbiddenTimeRanges != nil {
+		in, out := &in.ForbiddenTimeRanges, &out.ForbiddenTimeRanges
+		*out = make([]TimeRange, len(*in))
+		copy(*out, *in)
+	}
+	if in.Fallback != nil {
+		in, out := &in.Fallback, &out.Fallback
+		*out = new(FallbackPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Concurrency != nil {
+		in, out := &in.Concurrency, &out.Concurrency
+		*out = new(ConcurrencyLimit)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CarbonPolicySpec.
+func (in *CarbonPolicySpec) DeepCopy() *CarbonPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CarbonPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CarbonSavings) DeepCopyInto(out *CarbonSavings) {
 	*out = *in
+	if in.VsWorstCasePercent != nil {
+		in, out := &in.VsWorstCasePercent, &out.VsWorstCasePercent
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.VsNaiveCasePercent != nil {
+		in, out := &in.VsNaiveCasePercent, &out.VsNaiveCasePercent
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.VsMedianCasePercent != nil {
+		in, out := &in.VsMedianCasePercent, &out.VsMedianCasePercent
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CarbonSavings.
@@ -167,6 +502,153 @@ func (in *CarbonSavings) DeepCopy() *CarbonSavings {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterCarbonPolicy) DeepCopyInto(out *ClusterCarbonPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCarbonPolicy.
+func (in *ClusterCarbonPolicy) DeepCopy() *ClusterCarbonPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCarbonPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterCarbonPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterCarbonPolicyList) DeepCopyInto(out *ClusterCarbonPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterCarbonPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCarbonPolicyList.
+func (in *ClusterCarbonPolicyList) DeepCopy() *ClusterCarbonPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCarbonPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterCarbonPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterCarbonPolicySpec) DeepCopyInto(out *ClusterCarbonPolicySpec) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	in.CarbonPolicySpec.DeepCopyInto(&out.CarbonPolicySpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCarbonPolicySpec.
+func (in *ClusterCarbonPolicySpec) DeepCopy() *ClusterCarbonPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCarbonPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConcurrencyLimit) DeepCopyInto(out *ConcurrencyLimit) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConcurrencyLimit.
+func (in *ConcurrencyLimit) DeepCopy() *ConcurrencyLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(ConcurrencyLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmissionsEstimate) DeepCopyInto(out *EmissionsEstimate) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.EndTime.DeepCopyInto(&out.EndTime)
+	out.EnergyKWh = in.EnergyKWh.DeepCopy()
+	if in.IntensityGramsPerKWh != nil {
+		in, out := &in.IntensityGramsPerKWh, &out.IntensityGramsPerKWh
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.EmittedGrams != nil {
+		in, out := &in.EmittedGrams, &out.EmittedGrams
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.AvoidedGrams != nil {
+		in, out := &in.AvoidedGrams, &out.AvoidedGrams
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmissionsEstimate.
+func (in *EmissionsEstimate) DeepCopy() *EmissionsEstimate {
+	if in == nil {
+		return nil
+	}
+	out := new(EmissionsEstimate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FallbackPolicy) DeepCopyInto(out *FallbackPolicy) {
+	*out = *in
+	if in.LowHourUTC != nil {
+		in, out := &in.LowHourUTC, &out.LowHourUTC
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FallbackPolicy.
+func (in *FallbackPolicy) DeepCopy() *FallbackPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(FallbackPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JobTemplateSpec) DeepCopyInto(out *JobTemplateSpec) {
 	*out = *in
@@ -184,6 +666,85 @@ func (in *JobTemplateSpec) DeepCopy() *JobTemplateSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Placement) DeepCopyInto(out *Placement) {
+	*out = *in
+	if in.Regions != nil {
+		in, out := &in.Regions, &out.Regions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Placement.
+func (in *Placement) DeepCopy() *Placement {
+	if in == nil {
+		return nil
+	}
+	out := new(Placement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyReference) DeepCopyInto(out *PolicyReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyReference.
+func (in *PolicyReference) DeepCopy() *PolicyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduleAlternative) DeepCopyInto(out *ScheduleAlternative) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.CarbonIntensityGramsPerKWh != nil {
+		in, out := &in.CarbonIntensityGramsPerKWh, &out.CarbonIntensityGramsPerKWh
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleAlternative.
+func (in *ScheduleAlternative) DeepCopy() *ScheduleAlternative {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduleAlternative)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduleRevision) DeepCopyInto(out *ScheduleRevision) {
+	*out = *in
+	in.RevisionTime.DeepCopyInto(&out.RevisionTime)
+	in.PreviousTime.DeepCopyInto(&out.PreviousTime)
+	in.NewTime.DeepCopyInto(&out.NewTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleRevision.
+func (in *ScheduleRevision) DeepCopy() *ScheduleRevision {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduleRevision)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SchedulingDecision) DeepCopyInto(out *SchedulingDecision) {
 	*out = *in
@@ -195,6 +756,26 @@ func (in *SchedulingDecision) DeepCopyInto(out *SchedulingDecision) {
 		in, out := &in.WorstCaseTime, &out.WorstCaseTime
 		*out = (*in).DeepCopy()
 	}
+	if in.WorstCaseIntensityGramsPerKWh != nil {
+		in, out := &in.WorstCaseIntensityGramsPerKWh, &out.WorstCaseIntensityGramsPerKWh
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.ImmediateIntensityGramsPerKWh != nil {
+		in, out := &in.ImmediateIntensityGramsPerKWh, &out.ImmediateIntensityGramsPerKWh
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.OptimalIntensityGramsPerKWh != nil {
+		in, out := &in.OptimalIntensityGramsPerKWh, &out.OptimalIntensityGramsPerKWh
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MedianIntensityGramsPerKWh != nil {
+		in, out := &in.MedianIntensityGramsPerKWh, &out.MedianIntensityGramsPerKWh
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulingDecision.
@@ -206,3 +787,60 @@ func (in *SchedulingDecision) DeepCopy() *SchedulingDecision {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulingStrategy) DeepCopyInto(out *SchedulingStrategy) {
+	*out = *in
+	if in.ThresholdIntensity != nil {
+		in, out := &in.ThresholdIntensity, &out.ThresholdIntensity
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Percentile != nil {
+		in, out := &in.Percentile, &out.Percentile
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulingStrategy.
+func (in *SchedulingStrategy) DeepCopy() *SchedulingStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulingStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulingWindow) DeepCopyInto(out *SchedulingWindow) {
+	*out = *in
+	in.Start.DeepCopyInto(&out.Start)
+	in.End.DeepCopyInto(&out.End)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulingWindow.
+func (in *SchedulingWindow) DeepCopy() *SchedulingWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulingWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TimeRange) DeepCopyInto(out *TimeRange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimeRange.
+func (in *TimeRange) DeepCopy() *TimeRange {
+	if in == nil {
+		return nil
+	}
+	out := new(TimeRange)
+	in.DeepCopyInto(out)
+	return out
+}
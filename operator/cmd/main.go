@@ -25,10 +25,12 @@ import (
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
@@ -36,7 +38,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	batchv1alpha1 "github.com/carbon-aware-kube/operator/api/v1alpha1"
+	batchv1beta1 "github.com/carbon-aware-kube/operator/api/v1beta1"
+	"github.com/carbon-aware-kube/operator/internal/config"
 	"github.com/carbon-aware-kube/operator/internal/controller"
+	webhookcorev1 "github.com/carbon-aware-kube/operator/internal/webhook/v1"
+	webhookbatchv1alpha1 "github.com/carbon-aware-kube/operator/internal/webhook/v1alpha1"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -49,6 +55,7 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(batchv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(batchv1beta1.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
@@ -58,6 +65,7 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var configFile string
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -69,6 +77,9 @@ func main() {
 		"If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.StringVar(&configFile, "config", "",
+		"The operator configuration file. Changes to the file are reloaded while running. "+
+			"If unset, the default configuration is used.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -77,6 +88,12 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	operatorConfig, err := config.NewWatcher(configFile)
+	if err != nil {
+		setupLog.Error(err, "unable to load config file", "path", configFile)
+		os.Exit(1)
+	}
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancellation and
@@ -120,8 +137,15 @@ func main() {
 		metricsServerOptions.FilterProvider = filters.WithAuthenticationAndAuthorization
 	}
 
+	// Only gated Pods are cached. Without the Pod controller, Pods are never read.
+	var cacheOptions cache.Options
+	if operatorConfig.Current().PodSchedulingGates.Enabled {
+		cacheOptions.ByObject = controller.GatedPodCacheOptions()
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
+		Cache:                  cacheOptions,
 		Metrics:                metricsServerOptions,
 		WebhookServer:          webhookServer,
 		HealthProbeBindAddress: probeAddr,
@@ -143,12 +167,64 @@ func main() {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
+	if err := mgr.Add(operatorConfig); err != nil {
+		setupLog.Error(err, "unable to watch config file")
+		os.Exit(1)
+	}
+	// Rejected changes of the config file are reported as events on the operator's Pod, which
+	// the deployment passes in POD_NAME and POD_NAMESPACE
+	if podName, podNamespace := os.Getenv("POD_NAME"), os.Getenv("POD_NAMESPACE"); podName != "" && podNamespace != "" {
+		recorder := mgr.GetEventRecorderFor("operator-config")
+		pod := &corev1.ObjectReference{APIVersion: "v1", Kind: "Pod", Name: podName, Namespace: podNamespace}
+		operatorConfig.OnRejected(func(err error) {
+			recorder.Event(pod, corev1.EventTypeWarning, "ConfigRejected", err.Error())
+		})
+	}
 
-	if err = (&controller.CarbonAwareJobReconciler{
+	jobReconciler := &controller.CarbonAwareJobReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("carbonawarejob-controller"),
+		Config:   operatorConfig,
+	}
+	if err = jobReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "CarbonAwareJob")
+		os.Exit(1)
+	}
+	// nolint:goconst
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = webhookbatchv1alpha1.SetupCarbonAwareJobWebhookWithManager(mgr, operatorConfig); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "CarbonAwareJob")
+			os.Exit(1)
+		}
+	}
+	// Pods labelled carbonaware.dev/scheduling-gate=true and annotated with carbonaware.dev/max-delay
+	// are gated by the webhook and released by the controller, sharing the node inventory and grid
+	// zones of the CarbonAwareJob controller.
+	// Gates cannot be turned on or off by a reload, as the controller is only registered here.
+	if operatorConfig.Current().PodSchedulingGates.Enabled {
+		if err = (&controller.PodSchedulingGateReconciler{
+			Client:    mgr.GetClient(),
+			Recorder:  mgr.GetEventRecorderFor("podschedulinggate-controller"),
+			Nodes:     jobReconciler.Nodes,
+			GridZones: jobReconciler.GridZones,
+			Config:    operatorConfig,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "PodSchedulingGate")
+			os.Exit(1)
+		}
+		if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+			if err = webhookcorev1.SetupPodWebhookWithManager(mgr, operatorConfig); err != nil {
+				setupLog.Error(err, "unable to create webhook", "webhook", "Pod")
+				os.Exit(1)
+			}
+		}
+	}
+	if err = (&controller.CarbonAwareCronJobReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
 	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "CarbonAwareJob")
+		setupLog.Error(err, "unable to create controller", "controller", "CarbonAwareCronJob")
 		os.Exit(1)
 	}
 	// +kubebuilder:scaffold:builder
@@ -0,0 +1,22 @@
+#!/usr/bin/env bash
+# Copies the generated CRDs into the Helm chart. The CarbonAwareJob CRD is rendered as a
+# template instead, so that its conversion webhook points at the release's webhook service
+# and v1beta1 is only served when the webhook is installed.
+set -euo pipefail
+
+bases=config/crd/bases
+chart=../helm/carbon-aware-kube
+
+cp "$bases/batch.carbonaware.dev_carbonawarecronjobs.yaml" \
+  "$bases/batch.carbonaware.dev_carbonpolicies.yaml" \
+  "$bases/batch.carbonaware.dev_clustercarbonpolicies.yaml" \
+  "$chart/crds/"
+
+{
+  echo '{{- /* Generated from config/crd/bases by "make helm-crds". DO NOT EDIT. */}}'
+  perl -0pe '
+    s/^  annotations:\n/  annotations:\n    helm.sh\/resource-policy: keep\n    {{- if .Values.webhooks.enabled }}\n    cert-manager.io\/inject-ca-from: {{ .Release.Namespace }}\/{{ include "carbon-aware-kube.fullname" . }}-serving-cert\n    {{- end }}\n/m;
+    s/^spec:\n/spec:\n  {{- if .Values.webhooks.enabled }}\n  conversion:\n    strategy: Webhook\n    webhook:\n      clientConfig:\n        service:\n          name: {{ include "carbon-aware-kube.fullname" . }}-webhook-service\n          namespace: {{ .Release.Namespace }}\n          path: \/convert\n      conversionReviewVersions:\n      - v1\n  {{- end }}\n/m;
+    s/^    served: true\n    storage: false\n/    served: {{ .Values.webhooks.enabled }}\n    storage: false\n/m;
+  ' "$bases/batch.carbonaware.dev_carbonawarejobs.yaml"
+} > "$chart/templates/crd-carbonawarejobs.yaml"
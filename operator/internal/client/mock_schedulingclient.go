@@ -8,28 +8,40 @@ import (
 // MockSchedulingClient is a mock implementation of the scheduling client for testing
 type MockSchedulingClient struct {
 	// MockGetOptimalSchedule is a function that will be called by GetOptimalSchedule
-	MockGetOptimalSchedule func(ctx context.Context, startTime time.Time, maxDelay time.Duration, jobDuration time.Duration, location CloudZone) (*ScheduleResponse, error)
+	MockGetOptimalSchedule func(ctx context.Context, startTime time.Time, maxDelay time.Duration, jobDuration time.Duration, locations []CloudZone) (*ScheduleResponse, error)
+	// MockRealizedIntensity is a function that will be called by RealizedIntensity
+	MockRealizedIntensity func(ctx context.Context, zone CloudZone, start, end time.Time) (float64, error)
 }
 
-// Ensure MockSchedulingClient implements SchedulingClientInterface
-var _ SchedulingClientInterface = (*MockSchedulingClient)(nil)
+// Ensure MockSchedulingClient implements SchedulingClientInterface and RealizedIntensityClient
+var (
+	_ SchedulingClientInterface = (*MockSchedulingClient)(nil)
+	_ RealizedIntensityClient   = (*MockSchedulingClient)(nil)
+)
+
+// RealizedIntensity calls the mock function, or reports that no realized intensity is available
+func (m *MockSchedulingClient) RealizedIntensity(ctx context.Context, zone CloudZone, start, end time.Time) (float64, error) {
+	if m.MockRealizedIntensity != nil {
+		return m.MockRealizedIntensity(ctx, zone, start, end)
+	}
+	return 0, ErrRealizedIntensityUnavailable
+}
 
 // GetOptimalSchedule calls the mock function
-func (m *MockSchedulingClient) GetOptimalSchedule(ctx context.Context, startTime time.Time, maxDelay time.Duration, jobDuration time.Duration, location CloudZone) (*ScheduleResponse, error) {
+func (m *MockSchedulingClient) GetOptimalSchedule(ctx context.Context, startTime time.Time, maxDelay time.Duration, jobDuration time.Duration, locations []CloudZone) (*ScheduleResponse, error) {
 	if m.MockGetOptimalSchedule != nil {
-		return m.MockGetOptimalSchedule(ctx, startTime, maxDelay, jobDuration, location)
+		return m.MockGetOptimalSchedule(ctx, startTime, maxDelay, jobDuration, locations)
 	}
-	
+
 	// Default implementation if no mock function is provided
 	now := time.Now()
-	
-	// Parse the location string (format: "provider:region")
+
+	// Place every option in the first requested zone
 	var zone CloudZone
-	zone = CloudZone{
-		Provider: location.Provider,
-		Region:   location.Region,
+	if len(locations) > 0 {
+		zone = locations[0]
 	}
-	
+
 	return &ScheduleResponse{
 		Ideal: ScheduleOption{
 			Time:         now.Add(1 * time.Hour),
@@ -4,11 +4,32 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"time"
 )
 
+// RetryConfig controls how failed requests to the scheduling API are retried
+type RetryConfig struct {
+	// MaxRetries is the number of retries after the first attempt. Zero disables retries
+	MaxRetries int
+	// InitialBackoff is the wait before the first retry. It doubles after every retry
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between retries
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryConfig returns the retry behavior used by NewSchedulingClient
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxRetries:     3,
+		InitialBackoff: 1 * time.Second,
+		MaxBackoff:     10 * time.Second,
+	}
+}
+
 // toISO8601Duration converts a time.Duration to an ISO 8601 duration string (e.g., "PT1H30M")
 func toISO8601Duration(d time.Duration) string {
 	if d < 0 {
@@ -33,13 +54,27 @@ func toISO8601Duration(d time.Duration) string {
 
 // SchedulingClientInterface defines the interface for the carbon-aware scheduling client
 type SchedulingClientInterface interface {
-	GetOptimalSchedule(ctx context.Context, startTime time.Time, maxDelay time.Duration, jobDuration time.Duration, location CloudZone) (*ScheduleResponse, error)
+	GetOptimalSchedule(ctx context.Context, startTime time.Time, maxDelay time.Duration, jobDuration time.Duration, locations []CloudZone) (*ScheduleResponse, error)
+}
+
+// ErrRealizedIntensityUnavailable is returned when no measured carbon intensity is available
+// for a past interval
+var ErrRealizedIntensityUnavailable = errors.New("realized carbon intensity not available")
+
+// RealizedIntensityClient is implemented by scheduling clients that can report the carbon
+// intensity measured over a past interval, as opposed to forecasting it
+type RealizedIntensityClient interface {
+	RealizedIntensity(ctx context.Context, zone CloudZone, start, end time.Time) (float64, error)
 }
 
 // SchedulingClient is a client for the carbon-aware scheduling API
 type SchedulingClient struct {
 	BaseURL    string
 	HTTPClient *http.Client
+	Retry      RetryConfig
+	// NumOptions is the number of ranked options requested in ScheduleResponse.Options. Zero
+	// leaves it to the scheduler
+	NumOptions int
 }
 
 // TimeRange represents a time window with a start and end time
@@ -52,13 +87,15 @@ type TimeRange struct {
 type CloudZone struct {
 	Provider string `json:"provider"`
 	Region   string `json:"region"`
+	// GridZone is the electricity grid zone of the region, e.g. "US-MIDA-PJM", if known
+	GridZone string `json:"gridZone,omitempty"`
 }
 
 // ScheduleRequest represents the input for the /v0/schedule endpoint
 type ScheduleRequest struct {
 	Windows    []TimeRange `json:"windows"`
 	Duration   string      `json:"duration"`
-	Zones      []CloudZone  `json:"zones"`
+	Zones      []CloudZone `json:"zones"`
 	NumOptions *int        `json:"num_options,omitempty"`
 }
 
@@ -84,20 +121,42 @@ type ScheduleResponse struct {
 	NaiveCase     ScheduleOption   `json:"naive_case"`
 	MedianCase    ScheduleOption   `json:"median_case"`
 	CarbonSavings CarbonSavings    `json:"carbon_savings"`
+
+	// Candidates are all evaluated start times in chronological order, ties broken by intensity.
+	// They are only known to clients that optimize in-process. The scheduler does not report
+	// them, so they are empty for SchedulingClient
+	Candidates []ScheduleOption `json:"-"`
+}
+
+// SortChronologically sorts options by start time, and options at the same time by intensity
+func SortChronologically(options []ScheduleOption) {
+	sort.SliceStable(options, func(i, j int) bool {
+		if !options[i].Time.Equal(options[j].Time) {
+			return options[i].Time.Before(options[j].Time)
+		}
+		return options[i].CO2Intensity < options[j].CO2Intensity
+	})
 }
 
 // NewSchedulingClient creates a new client for the carbon-aware scheduling API
 func NewSchedulingClient(baseURL string) SchedulingClientInterface {
+	return NewSchedulingClientWithRetry(baseURL, DefaultRetryConfig())
+}
+
+// NewSchedulingClientWithRetry creates a new client for the carbon-aware scheduling API
+// that retries failed requests with exponential backoff
+func NewSchedulingClientWithRetry(baseURL string, retry RetryConfig) *SchedulingClient {
 	return &SchedulingClient{
 		BaseURL: baseURL,
 		HTTPClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
+		Retry: retry,
 	}
 }
 
 // GetOptimalSchedule calculates the optimal schedule for a job based on carbon intensity forecasts
-func (c *SchedulingClient) GetOptimalSchedule(ctx context.Context, startTime time.Time, maxDelay time.Duration, jobDuration time.Duration, location CloudZone) (*ScheduleResponse, error) {
+func (c *SchedulingClient) GetOptimalSchedule(ctx context.Context, startTime time.Time, maxDelay time.Duration, jobDuration time.Duration, locations []CloudZone) (*ScheduleResponse, error) {
 	// Create the scheduling window from start time to start time + max delay
 	window := TimeRange{
 		Start: startTime,
@@ -111,7 +170,10 @@ func (c *SchedulingClient) GetOptimalSchedule(ctx context.Context, startTime tim
 	req := ScheduleRequest{
 		Windows:  []TimeRange{window},
 		Duration: durationStr,
-		Zones:    []CloudZone{{Provider: location.Provider, Region: location.Region}},
+		Zones:    locations,
+	}
+	if c.NumOptions > 0 {
+		req.NumOptions = &c.NumOptions
 	}
 
 	// Convert the request to JSON
@@ -121,35 +183,68 @@ func (c *SchedulingClient) GetOptimalSchedule(ctx context.Context, startTime tim
 	}
 	fmt.Printf("[DEBUG] ScheduleRequest payload: %s\n", string(reqBody))
 
+	// Send the request, retrying transient failures with exponential backoff
+	backoff := c.Retry.InitialBackoff
+	var lastErr error
+	for attempt := 0; attempt <= c.Retry.MaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("gave up retrying after %d attempts: %w", attempt, lastErr)
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+			if c.Retry.MaxBackoff > 0 && backoff > c.Retry.MaxBackoff {
+				backoff = c.Retry.MaxBackoff
+			}
+		}
+
+		scheduleResp, retryable, err := c.postSchedule(ctx, reqBody)
+		if err == nil {
+			return scheduleResp, nil
+		}
+		lastErr = err
+		if !retryable {
+			return nil, err
+		}
+	}
+
+	return nil, fmt.Errorf("failed after %d attempts: %w", c.Retry.MaxRetries+1, lastErr)
+}
+
+// postSchedule sends a single request to the /v0/schedule endpoint. The returned bool
+// reports whether the failure is transient and the request may be retried.
+func (c *SchedulingClient) postSchedule(ctx context.Context, reqBody []byte) (*ScheduleResponse, bool, error) {
 	// Create the HTTP request
 	httpReq, err := http.NewRequestWithContext(
 		ctx,
 		http.MethodPost,
 		fmt.Sprintf("%s/v0/schedule/", c.BaseURL),
-		bytes.NewBuffer(reqBody),
+		bytes.NewReader(reqBody),
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, false, fmt.Errorf("failed to create request: %w", err)
 	}
 	httpReq.Header.Set("Content-Type", "application/json")
 
 	// Send the request
 	resp, err := c.HTTPClient.Do(httpReq)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+		return nil, ctx.Err() == nil, fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close()
 
-	// Check the response status
+	// Check the response status; server errors and throttling are worth retrying
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		retryable := resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests
+		return nil, retryable, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
 
 	// Parse the response
 	var scheduleResp ScheduleResponse
 	if err := json.NewDecoder(resp.Body).Decode(&scheduleResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+		return nil, false, fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	return &scheduleResp, nil
+	return &scheduleResp, false, nil
 }
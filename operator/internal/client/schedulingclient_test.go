@@ -0,0 +1,148 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestSchedulingClient(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "SchedulingClient Suite")
+}
+
+var _ = Describe("SchedulingClient", func() {
+	var (
+		ctx      context.Context
+		requests atomic.Int32
+		statuses []int
+		server   *httptest.Server
+		retry    RetryConfig
+		received ScheduleRequest
+		options  []ScheduleOption
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		requests.Store(0)
+		statuses = nil
+		options = nil
+		retry = RetryConfig{
+			MaxRetries:     3,
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     5 * time.Millisecond,
+		}
+
+		// Respond with the configured status codes in order, then succeed
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			Expect(json.NewDecoder(r.Body).Decode(&received)).To(Succeed())
+			n := int(requests.Add(1)) - 1
+			if n < len(statuses) {
+				w.WriteHeader(statuses[n])
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			Expect(json.NewEncoder(w).Encode(ScheduleResponse{
+				Ideal:   ScheduleOption{CO2Intensity: 100.0},
+				Options: options,
+			})).To(Succeed())
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	getSchedule := func() (*ScheduleResponse, error) {
+		c := NewSchedulingClientWithRetry(server.URL, retry)
+		return c.GetOptimalSchedule(ctx, time.Now(), time.Hour, time.Hour, []CloudZone{{Provider: "aws", Region: "us-east-1"}})
+	}
+
+	It("should retry server errors until the request succeeds", func() {
+		statuses = []int{http.StatusServiceUnavailable, http.StatusInternalServerError}
+
+		resp, err := getSchedule()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Ideal.CO2Intensity).To(Equal(100.0))
+		Expect(requests.Load()).To(Equal(int32(3)))
+	})
+
+	It("should retry throttled requests", func() {
+		statuses = []int{http.StatusTooManyRequests}
+
+		_, err := getSchedule()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(requests.Load()).To(Equal(int32(2)))
+	})
+
+	It("should give up after the configured number of retries", func() {
+		statuses = []int{500, 500, 500, 500, 500}
+
+		_, err := getSchedule()
+		Expect(err).To(HaveOccurred())
+		Expect(requests.Load()).To(Equal(int32(4)))
+	})
+
+	It("should not retry client errors", func() {
+		statuses = []int{http.StatusBadRequest}
+
+		_, err := getSchedule()
+		Expect(err).To(HaveOccurred())
+		Expect(requests.Load()).To(Equal(int32(1)))
+	})
+
+	It("should request the configured number of options", func() {
+		_, err := getSchedule()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(received.NumOptions).To(BeNil())
+
+		c := NewSchedulingClientWithRetry(server.URL, retry)
+		c.NumOptions = 5
+		_, err = c.GetOptimalSchedule(ctx, time.Now(), time.Hour, time.Hour, []CloudZone{{Provider: "aws", Region: "us-east-1"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(received.NumOptions).To(HaveValue(Equal(5)))
+	})
+
+	It("should return the ranked options without candidates", func() {
+		start := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+		options = []ScheduleOption{
+			{Time: start.Add(30 * time.Minute), CO2Intensity: 100},
+			{Time: start.Add(10 * time.Minute), CO2Intensity: 150},
+			{Time: start, CO2Intensity: 200},
+		}
+
+		// The scheduler does not report every start time it evaluated
+		resp, err := getSchedule()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Options).To(Equal(options))
+		Expect(resp.Candidates).To(BeEmpty())
+	})
+
+	It("should stop retrying when the context is cancelled", func() {
+		statuses = []int{500, 500, 500, 500, 500}
+		retry.InitialBackoff = time.Hour
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+
+		_, err := getSchedule()
+		Expect(err).To(HaveOccurred())
+		Expect(requests.Load()).To(Equal(int32(1)))
+	})
+})
+
+var _ = Describe("toISO8601Duration", func() {
+	It("should format hours, minutes and seconds", func() {
+		Expect(toISO8601Duration(90 * time.Minute)).To(Equal("PT1H30M"))
+		Expect(toISO8601Duration(45 * time.Second)).To(Equal("PT45S"))
+		Expect(toISO8601Duration(0)).To(Equal("PT0S"))
+	})
+})
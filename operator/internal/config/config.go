@@ -0,0 +1,358 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package config loads the operator configuration file passed with --config.
+//
+// The file is versioned by apiVersion and kind like a Kubernetes object, unknown fields are
+// rejected, and omitted fields keep their defaults:
+//
+//	apiVersion: config.carbonaware.dev/v1alpha1
+//	kind: OperatorConfig
+//	forecast:
+//	  reevaluationInterval: 30m
+//	jobs:
+//	  maxDelayLimit: 72h
+//
+// Credentials of forecast providers are not part of the file and are read from the
+// environment, so that the file can be kept in a ConfigMap.
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/yaml"
+
+	batchv1alpha1 "github.com/carbon-aware-kube/operator/api/v1alpha1"
+	"github.com/carbon-aware-kube/operator/internal/forecast"
+)
+
+const (
+	// APIVersion is the only supported apiVersion of the configuration file
+	APIVersion = "config.carbonaware.dev/v1alpha1"
+
+	// Kind is the kind of the configuration file
+	Kind = "OperatorConfig"
+
+	// DefaultCloudProvider is the cloud provider assumed when it cannot be detected
+	DefaultCloudProvider = "aws"
+
+	// DefaultCloudRegion is the cloud region assumed when it cannot be detected
+	DefaultCloudRegion = "us-east-1"
+)
+
+// OperatorConfig is the configuration of the operator
+type OperatorConfig struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Scheduler configures the carbon-aware scheduler API
+	Scheduler SchedulerConfig `json:"scheduler"`
+
+	// Forecast configures where forecasts come from and how often they are requested
+	Forecast ForecastConfig `json:"forecast"`
+
+	// Jobs configures the defaults and limits of scheduled workloads
+	Jobs JobsConfig `json:"jobs"`
+
+	// CloudEnvironment configures the cloud region forecasts are requested for
+	CloudEnvironment CloudEnvironmentConfig `json:"cloudEnvironment"`
+
+	// PodSchedulingGates enables gating Pods labelled carbonaware.dev/scheduling-gate=true and
+	// annotated with carbonaware.dev/max-delay
+	PodSchedulingGates PodSchedulingGatesConfig `json:"podSchedulingGates"`
+
+	// Emissions configures the power model used to estimate the emissions of completed jobs
+	Emissions EmissionsConfig `json:"emissions"`
+}
+
+// SchedulerConfig configures the carbon-aware scheduler API
+type SchedulerConfig struct {
+	// URL is the base URL of the scheduler
+	URL string `json:"url"`
+
+	// MaxRetries is the number of retries of a failed request, with exponential backoff
+	MaxRetries int `json:"maxRetries"`
+
+	// InitialBackoff is the wait before the first retry
+	InitialBackoff metav1.Duration `json:"initialBackoff"`
+
+	// MaxBackoff caps the wait between retries
+	MaxBackoff metav1.Duration `json:"maxBackoff"`
+}
+
+// ForecastConfig configures the forecast provider and how often forecasts are requested
+type ForecastConfig struct {
+	// Provider is "scheduler" or the name of a forecast provider queried directly
+	Provider string `json:"provider"`
+
+	// URL overrides the provider's public API endpoint
+	URL string `json:"url,omitempty"`
+
+	// Locations maps "provider:region" cloud regions or grid zones to provider locations
+	Locations map[string]string `json:"locations,omitempty"`
+
+	// GridZones maps "provider:region" cloud regions to grid zones, taking precedence over the
+	// built-in table
+	GridZones map[string]string `json:"gridZones,omitempty"`
+
+	// StaticPath is the file or directory the static provider reads forecasts from
+	StaticPath string `json:"staticPath,omitempty"`
+
+	// ReevaluationInterval is how often pending jobs re-query the forecast. Zero disables
+	// re-evaluation
+	ReevaluationInterval metav1.Duration `json:"reevaluationInterval"`
+
+	// RetryInterval is how often an unavailable forecast is retried
+	RetryInterval metav1.Duration `json:"retryInterval"`
+
+	// RetryCutoff is how long before the end of the window retries stop and the fallback
+	// policy applies
+	RetryCutoff metav1.Duration `json:"retryCutoff"`
+
+	// NumOptions is the number of ranked start times requested with each forecast. The best
+	// of them are kept as alternatives for jobs that cannot start at their scheduled time
+	NumOptions int `json:"numOptions"`
+}
+
+// JobsConfig configures the defaults and limits of scheduled workloads
+type JobsConfig struct {
+	// DefaultMaxDuration is the expected run time of jobs that do not set one
+	DefaultMaxDuration metav1.Duration `json:"defaultMaxDuration"`
+
+	// MaxDelayLimit is the longest scheduling window a job may request through MaxDelay or
+	// Deadline. Zero means no limit
+	MaxDelayLimit metav1.Duration `json:"maxDelayLimit"`
+
+	// StatusCheckInterval is how often the status of running Jobs is checked
+	StatusCheckInterval metav1.Duration `json:"statusCheckInterval"`
+
+	// StartTimeout is how long a started Job may go without a ready or finished pod before the
+	// job moves to its next alternative start time. A Job that had a ready pod once is never
+	// restarted. Zero, the default, disables the check
+	StartTimeout metav1.Duration `json:"startTimeout"`
+}
+
+// CloudEnvironmentConfig configures the cloud region forecasts are requested for
+type CloudEnvironmentConfig struct {
+	// Override uses Provider and Region instead of detecting them from the cluster's nodes
+	Override bool `json:"override"`
+
+	// Provider is the cloud provider when overridden or not detected
+	Provider string `json:"provider"`
+
+	// Region is the cloud region when overridden or not detected
+	Region string `json:"region"`
+}
+
+// PodSchedulingGatesConfig configures carbon-aware scheduling gates on Pods
+type PodSchedulingGatesConfig struct {
+	// Enabled runs the controller that releases gated Pods and the webhook that gates them
+	Enabled bool `json:"enabled"`
+}
+
+// EmissionsConfig is the power model that turns the resource requests and run time of a
+// job into energy. The defaults are the average coefficients of the Cloud Carbon Footprint
+// methodology.
+type EmissionsConfig struct {
+	// WattsPerCPU is the average power draw of one requested CPU
+	WattsPerCPU float64 `json:"wattsPerCPU"`
+
+	// WattsPerGiBMemory is the power draw of one GiB of requested memory
+	WattsPerGiBMemory float64 `json:"wattsPerGiBMemory"`
+
+	// WattsPerGPU is the average power draw of one requested GPU
+	WattsPerGPU float64 `json:"wattsPerGPU"`
+
+	// PowerUsageEffectiveness is the ratio of the data center's total energy to the energy
+	// used by its servers
+	PowerUsageEffectiveness float64 `json:"powerUsageEffectiveness"`
+}
+
+// Defaults returns the configuration used when no file is given
+func Defaults() *OperatorConfig {
+	return &OperatorConfig{
+		TypeMeta: metav1.TypeMeta{APIVersion: APIVersion, Kind: Kind},
+		Scheduler: SchedulerConfig{
+			URL:            "http://carbon-aware-scheduler:8080",
+			MaxRetries:     3,
+			InitialBackoff: metav1.Duration{Duration: time.Second},
+			MaxBackoff:     metav1.Duration{Duration: 10 * time.Second},
+		},
+		Forecast: ForecastConfig{
+			Provider:             forecast.ProviderScheduler,
+			ReevaluationInterval: metav1.Duration{Duration: time.Hour},
+			RetryInterval:        metav1.Duration{Duration: time.Minute},
+			RetryCutoff:          metav1.Duration{Duration: 15 * time.Minute},
+			NumOptions:           10,
+		},
+		Jobs: JobsConfig{
+			DefaultMaxDuration:  metav1.Duration{Duration: batchv1alpha1.DefaultMaxDuration},
+			StatusCheckInterval: metav1.Duration{Duration: 30 * time.Second},
+		},
+		CloudEnvironment: CloudEnvironmentConfig{
+			Provider: DefaultCloudProvider,
+			Region:   DefaultCloudRegion,
+		},
+		Emissions: EmissionsConfig{
+			WattsPerCPU:             2.12,
+			WattsPerGiBMemory:       0.392,
+			WattsPerGPU:             250,
+			PowerUsageEffectiveness: 1,
+		},
+	}
+}
+
+// Load reads and validates the configuration file at path. Fields missing from the file keep
+// their defaults. An empty path yields the default configuration.
+func Load(path string) (*OperatorConfig, error) {
+	if path == "" {
+		return Defaults(), nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse decodes and validates a configuration file
+func Parse(data []byte) (*OperatorConfig, error) {
+	cfg := Defaults()
+	cfg.TypeMeta = metav1.TypeMeta{}
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config file: %w", err)
+	}
+	return cfg, nil
+}
+
+// Validate checks the version and values of a configuration
+func (c *OperatorConfig) Validate() error {
+	var allErrs field.ErrorList
+
+	if c.APIVersion != APIVersion {
+		allErrs = append(allErrs, field.NotSupported(field.NewPath("apiVersion"), c.APIVersion, []string{APIVersion}))
+	}
+	if c.Kind != Kind {
+		allErrs = append(allErrs, field.NotSupported(field.NewPath("kind"), c.Kind, []string{Kind}))
+	}
+
+	schedulerPath := field.NewPath("scheduler")
+	if u, err := url.Parse(c.Scheduler.URL); err != nil || u.Scheme == "" || u.Host == "" {
+		allErrs = append(allErrs, field.Invalid(schedulerPath.Child("url"), c.Scheduler.URL, "must be an absolute URL"))
+	}
+	if c.Scheduler.MaxRetries < 0 {
+		allErrs = append(allErrs, field.Invalid(schedulerPath.Child("maxRetries"), c.Scheduler.MaxRetries, "must not be negative"))
+	}
+	allErrs = append(allErrs, validateDuration(schedulerPath.Child("initialBackoff"), c.Scheduler.InitialBackoff, false)...)
+	allErrs = append(allErrs, validateDuration(schedulerPath.Child("maxBackoff"), c.Scheduler.MaxBackoff, false)...)
+
+	forecastPath := field.NewPath("forecast")
+	providers := []string{
+		forecast.ProviderScheduler, forecast.ProviderElectricityMaps, forecast.ProviderWattTime,
+		forecast.ProviderCarbonAwareSDK, forecast.ProviderNationalGrid, forecast.ProviderStatic,
+	}
+	switch c.Forecast.Provider {
+	case forecast.ProviderStatic:
+		if c.Forecast.StaticPath == "" {
+			allErrs = append(allErrs, field.Required(forecastPath.Child("staticPath"), "required by the static provider"))
+		}
+	case forecast.ProviderCarbonAwareSDK:
+		if c.Forecast.URL == "" {
+			allErrs = append(allErrs, field.Required(forecastPath.Child("url"), "required by the carbon-aware-sdk provider"))
+		}
+	case forecast.ProviderScheduler, forecast.ProviderElectricityMaps, forecast.ProviderWattTime, forecast.ProviderNationalGrid:
+	default:
+		allErrs = append(allErrs, field.NotSupported(forecastPath.Child("provider"), c.Forecast.Provider, providers))
+	}
+	for zone, location := range c.Forecast.Locations {
+		if zone == "" || location == "" {
+			allErrs = append(allErrs, field.Invalid(forecastPath.Child("locations").Key(zone), location,
+				"keys must be provider:region or a grid zone and values must not be empty"))
+		}
+	}
+	for region, gridZone := range c.Forecast.GridZones {
+		if !strings.Contains(region, ":") || gridZone == "" {
+			allErrs = append(allErrs, field.Invalid(forecastPath.Child("gridZones").Key(region), gridZone,
+				"keys must be provider:region and values must not be empty"))
+		}
+	}
+	allErrs = append(allErrs, validateDuration(forecastPath.Child("reevaluationInterval"), c.Forecast.ReevaluationInterval, false)...)
+	allErrs = append(allErrs, validateDuration(forecastPath.Child("retryInterval"), c.Forecast.RetryInterval, true)...)
+	allErrs = append(allErrs, validateDuration(forecastPath.Child("retryCutoff"), c.Forecast.RetryCutoff, false)...)
+	if c.Forecast.NumOptions < 1 {
+		allErrs = append(allErrs, field.Invalid(forecastPath.Child("numOptions"), c.Forecast.NumOptions, "must be positive"))
+	}
+
+	jobsPath := field.NewPath("jobs")
+	allErrs = append(allErrs, validateDuration(jobsPath.Child("defaultMaxDuration"), c.Jobs.DefaultMaxDuration, true)...)
+	allErrs = append(allErrs, validateDuration(jobsPath.Child("maxDelayLimit"), c.Jobs.MaxDelayLimit, false)...)
+	allErrs = append(allErrs, validateDuration(jobsPath.Child("statusCheckInterval"), c.Jobs.StatusCheckInterval, true)...)
+	allErrs = append(allErrs, validateDuration(jobsPath.Child("startTimeout"), c.Jobs.StartTimeout, false)...)
+
+	cloudPath := field.NewPath("cloudEnvironment")
+	if c.CloudEnvironment.Provider == "" {
+		allErrs = append(allErrs, field.Required(cloudPath.Child("provider"), ""))
+	}
+	if c.CloudEnvironment.Region == "" {
+		allErrs = append(allErrs, field.Required(cloudPath.Child("region"), ""))
+	}
+
+	emissionsPath := field.NewPath("emissions")
+	for _, coefficient := range []struct {
+		name  string
+		watts float64
+	}{
+		{"wattsPerCPU", c.Emissions.WattsPerCPU},
+		{"wattsPerGiBMemory", c.Emissions.WattsPerGiBMemory},
+		{"wattsPerGPU", c.Emissions.WattsPerGPU},
+	} {
+		if coefficient.watts < 0 {
+			allErrs = append(allErrs, field.Invalid(emissionsPath.Child(coefficient.name), coefficient.watts, "must not be negative"))
+		}
+	}
+	if c.Emissions.PowerUsageEffectiveness < 1 {
+		allErrs = append(allErrs, field.Invalid(emissionsPath.Child("powerUsageEffectiveness"),
+			c.Emissions.PowerUsageEffectiveness, "must be at least 1"))
+	}
+
+	return allErrs.ToAggregate()
+}
+
+// validateDuration rejects negative durations, and zero durations if positive is set
+func validateDuration(path *field.Path, d metav1.Duration, positive bool) field.ErrorList {
+	switch {
+	case d.Duration < 0:
+		return field.ErrorList{field.Invalid(path, d.Duration.String(), "must not be negative")}
+	case positive && d.Duration == 0:
+		return field.ErrorList{field.Invalid(path, d.Duration.String(), "must be positive")}
+	}
+	return nil
+}
+
+// RestartRequired reports whether changing from old to c affects settings that are only read
+// at startup. Pod scheduling gates decide which controllers and webhooks are registered with
+// the manager, so they cannot change while it runs.
+func (c *OperatorConfig) RestartRequired(old *OperatorConfig) bool {
+	return old.PodSchedulingGates != c.PodSchedulingGates
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestConfig(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Config Suite")
+}
+
+var _ = Describe("Config file", func() {
+	It("should keep the defaults of omitted fields", func() {
+		cfg, err := Parse([]byte(`
+apiVersion: config.carbonaware.dev/v1alpha1
+kind: OperatorConfig
+forecast:
+  reevaluationInterval: 0s
+  locations:
+    aws:us-east-1: US-MIDA-PJM
+jobs:
+  maxDelayLimit: 72h
+`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Forecast.ReevaluationInterval.Duration).To(BeZero())
+		Expect(cfg.Forecast.Locations).To(HaveKeyWithValue("aws:us-east-1", "US-MIDA-PJM"))
+		Expect(cfg.Jobs.MaxDelayLimit.Duration).To(Equal(72 * time.Hour))
+
+		defaults := Defaults()
+		Expect(cfg.Scheduler).To(Equal(defaults.Scheduler))
+		Expect(cfg.Forecast.RetryCutoff).To(Equal(defaults.Forecast.RetryCutoff))
+		Expect(cfg.Jobs.StatusCheckInterval.Duration).To(Equal(30 * time.Second))
+		Expect(cfg.CloudEnvironment.Region).To(Equal(DefaultCloudRegion))
+	})
+
+	It("should use the defaults without a file", func() {
+		cfg, err := Load("")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg).To(Equal(Defaults()))
+		Expect(cfg.Validate()).To(Succeed())
+	})
+
+	It("should require a supported version", func() {
+		_, err := Parse([]byte("kind: OperatorConfig\n"))
+		Expect(err).To(MatchError(ContainSubstring("apiVersion")))
+
+		_, err = Parse([]byte("apiVersion: config.carbonaware.dev/v2\nkind: OperatorConfig\n"))
+		Expect(err).To(MatchError(ContainSubstring("apiVersion")))
+	})
+
+	It("should reject unknown fields and invalid values", func() {
+		_, err := Parse([]byte(`
+apiVersion: config.carbonaware.dev/v1alpha1
+kind: OperatorConfig
+jobs:
+  defaultMaxDurration: 2h
+`))
+		Expect(err).To(MatchError(ContainSubstring("defaultMaxDurration")))
+
+		_, err = Parse([]byte(`
+apiVersion: config.carbonaware.dev/v1alpha1
+kind: OperatorConfig
+scheduler:
+  url: carbon-aware-scheduler
+forecast:
+  provider: static
+  retryCutoff: -1m
+  numOptions: 0
+  gridZones:
+    us-east-1: US-MIDA-PJM
+jobs:
+  defaultMaxDuration: 0s
+emissions:
+  wattsPerGPU: -1
+  powerUsageEffectiveness: 0.9
+`))
+		Expect(err).To(MatchError(ContainSubstring("scheduler.url")))
+		Expect(err).To(MatchError(ContainSubstring("forecast.staticPath")))
+		Expect(err).To(MatchError(ContainSubstring("forecast.retryCutoff")))
+		Expect(err).To(MatchError(ContainSubstring("forecast.numOptions")))
+		Expect(err).To(MatchError(ContainSubstring("forecast.gridZones[us-east-1]")))
+		Expect(err).To(MatchError(ContainSubstring("jobs.defaultMaxDuration")))
+		Expect(err).To(MatchError(ContainSubstring("emissions.wattsPerGPU")))
+		Expect(err).To(MatchError(ContainSubstring("emissions.powerUsageEffectiveness")))
+	})
+
+	It("should tell which changes require a restart", func() {
+		old := Defaults()
+		cfg := Defaults()
+		cfg.Forecast.ReevaluationInterval.Duration = time.Minute
+		cfg.Jobs.MaxDelayLimit.Duration = time.Hour
+		cfg.Forecast.GridZones = map[string]string{"aws:us-east-1": "US-MIDA-DOM"}
+		cfg.Emissions.PowerUsageEffectiveness = 1.2
+		cfg.Jobs.StartTimeout.Duration = 10 * time.Minute
+		Expect(cfg.RestartRequired(old)).To(BeFalse())
+
+		// The forecast client and the cloud environment are rebuilt on reload
+		cfg.Forecast.NumOptions = 5
+		cfg.Forecast.Provider = "nationalgrid"
+		cfg.Scheduler.URL = "http://scheduler.example"
+		cfg.CloudEnvironment.Override = true
+		Expect(cfg.RestartRequired(old)).To(BeFalse())
+
+		cfg.PodSchedulingGates.Enabled = !old.PodSchedulingGates.Enabled
+		Expect(cfg.RestartRequired(old)).To(BeTrue())
+	})
+})
+
+var _ = Describe("Watcher", func() {
+	It("should reload valid changes and keep the last valid configuration", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "config.yaml")
+		writeConfig := func(content string, modTime time.Time) {
+			Expect(os.WriteFile(path, []byte(content), 0o600)).To(Succeed())
+			Expect(os.Chtimes(path, modTime, modTime)).To(Succeed())
+		}
+		header := "apiVersion: config.carbonaware.dev/v1alpha1\nkind: OperatorConfig\n"
+		start := time.Now().Add(-time.Hour)
+		writeConfig(header+"jobs:\n  maxDelayLimit: 1h\n", start)
+
+		watcher, err := NewWatcher(path)
+		Expect(err).NotTo(HaveOccurred())
+		watcher.interval = 10 * time.Millisecond
+		Expect(watcher.Current().Jobs.MaxDelayLimit.Duration).To(Equal(time.Hour))
+
+		var reloads atomic.Int32
+		watcher.Subscribe(func(*OperatorConfig) { reloads.Add(1) })
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			defer GinkgoRecover()
+			Expect(watcher.Start(ctx)).To(Succeed())
+		}()
+
+		writeConfig(header+"jobs:\n  maxDelayLimit: 2h\n", start.Add(time.Minute))
+		Eventually(func() time.Duration { return watcher.Current().Jobs.MaxDelayLimit.Duration }).Should(Equal(2 * time.Hour))
+		Expect(reloads.Load()).To(BeEquivalentTo(1))
+
+		writeConfig(header+"jobs:\n  maxDelayLimit: -2h\n", start.Add(2*time.Minute))
+		Consistently(func() time.Duration { return watcher.Current().Jobs.MaxDelayLimit.Duration }, 100*time.Millisecond).Should(Equal(2 * time.Hour))
+		Expect(reloads.Load()).To(BeEquivalentTo(1))
+	})
+
+	It("should reject changes to settings that are only read at startup", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "config.yaml")
+		header := "apiVersion: config.carbonaware.dev/v1alpha1\nkind: OperatorConfig\n"
+		start := time.Now().Add(-time.Hour)
+		Expect(os.WriteFile(path, []byte(header), 0o600)).To(Succeed())
+		Expect(os.Chtimes(path, start, start)).To(Succeed())
+
+		watcher, err := NewWatcher(path)
+		Expect(err).NotTo(HaveOccurred())
+		var rejections []error
+		watcher.OnRejected(func(err error) { rejections = append(rejections, err) })
+
+		Expect(os.WriteFile(path, []byte(header+"podSchedulingGates:\n  enabled: true\njobs:\n  maxDelayLimit: 2h\n"), 0o600)).To(Succeed())
+		Expect(os.Chtimes(path, start.Add(time.Minute), start.Add(time.Minute))).To(Succeed())
+		watcher.reload()
+		Expect(watcher.Current().Jobs.MaxDelayLimit.Duration).To(Equal(2 * time.Hour))
+		Expect(watcher.Current().PodSchedulingGates.Enabled).To(BeFalse())
+		Expect(rejections).To(HaveLen(1))
+		Expect(rejections[0]).To(MatchError(ContainSubstring("podSchedulingGates")))
+
+		Expect(os.WriteFile(path, []byte(header+"jobs:\n  maxDelayLimit: -2h\n"), 0o600)).To(Succeed())
+		Expect(os.Chtimes(path, start.Add(2*time.Minute), start.Add(2*time.Minute))).To(Succeed())
+		watcher.reload()
+		Expect(rejections).To(HaveLen(2))
+		Expect(rejections[1]).To(MatchError(ContainSubstring("invalid config file")))
+	})
+})
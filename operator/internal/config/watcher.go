@@ -0,0 +1,169 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// defaultWatchInterval is how often the configuration file is checked for changes
+const defaultWatchInterval = 10 * time.Second
+
+var watcherlog = logf.Log.WithName("config")
+
+// Watcher holds the current configuration and reloads it when the file changes. Invalid
+// changes are rejected, so the last valid configuration stays in effect. Changes to settings
+// that are only read at startup are rejected too, while the rest of the file is applied.
+// Rejections are reported to the functions registered with OnRejected.
+//
+// Watcher implements manager.Runnable, so it is started with the manager.
+type Watcher struct {
+	path     string
+	interval time.Duration
+
+	mu          sync.RWMutex
+	current     *OperatorConfig
+	fingerprint string
+	subscribers []func(*OperatorConfig)
+	rejected    []func(error)
+}
+
+// NewWatcher loads the configuration file at path. An empty path yields a Watcher that always
+// returns the default configuration.
+func NewWatcher(path string) (*Watcher, error) {
+	cfg, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	fingerprint, _ := fileFingerprint(path)
+	return &Watcher{path: path, interval: defaultWatchInterval, current: cfg, fingerprint: fingerprint}, nil
+}
+
+// Current returns the configuration in effect. It must not be modified.
+func (w *Watcher) Current() *OperatorConfig {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.current
+}
+
+// Subscribe calls fn with every configuration that is reloaded from the file
+func (w *Watcher) Subscribe(fn func(*OperatorConfig)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.subscribers = append(w.subscribers, fn)
+}
+
+// OnRejected calls fn with the reason whenever a change of the file is rejected in whole or in part
+func (w *Watcher) OnRejected(fn func(error)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.rejected = append(w.rejected, fn)
+}
+
+// Start polls the configuration file for changes until ctx is done
+func (w *Watcher) Start(ctx context.Context) error {
+	if w.path == "" {
+		return nil
+	}
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			w.reload()
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. Every replica serves webhooks,
+// so every replica reloads its configuration.
+func (w *Watcher) NeedLeaderElection() bool {
+	return false
+}
+
+// reload re-reads the configuration file if it changed and notifies the subscribers
+func (w *Watcher) reload() {
+	fingerprint, err := fileFingerprint(w.path)
+	if err != nil {
+		watcherlog.Error(err, "Failed to check config file", "path", w.path)
+		return
+	}
+
+	w.mu.Lock()
+	if fingerprint == w.fingerprint {
+		w.mu.Unlock()
+		return
+	}
+	w.fingerprint = fingerprint
+	rejected := append([]func(error){}, w.rejected...)
+	cfg, err := Load(w.path)
+	if err != nil {
+		w.mu.Unlock()
+		watcherlog.Error(err, "Ignoring invalid config file, keeping the previous configuration", "path", w.path)
+		notifyRejected(rejected, fmt.Errorf("ignoring invalid config file %s: %w", w.path, err))
+		return
+	}
+	// Keep the settings that are only read at startup, so that Current describes the running
+	// operator rather than the file
+	old := w.current
+	restartRequired := cfg.RestartRequired(old)
+	if restartRequired {
+		cfg.PodSchedulingGates = old.PodSchedulingGates
+	}
+	w.current = cfg
+	subscribers := append([]func(*OperatorConfig){}, w.subscribers...)
+	w.mu.Unlock()
+
+	watcherlog.Info("Reloaded config file", "path", w.path)
+	if restartRequired {
+		err := fmt.Errorf("config file %s changed podSchedulingGates, which only takes effect after a restart; keeping podSchedulingGates.enabled=%t", w.path, old.PodSchedulingGates.Enabled)
+		watcherlog.Error(err, "Rejected part of the config file")
+		notifyRejected(rejected, err)
+	}
+	for _, fn := range subscribers {
+		fn(cfg)
+	}
+}
+
+// notifyRejected reports a rejected change to every function registered with OnRejected
+func notifyRejected(fns []func(error), err error) {
+	for _, fn := range fns {
+		fn(err)
+	}
+}
+
+// fileFingerprint identifies the content of a file by its size and modification time. The
+// symlinks of mounted ConfigMaps are followed, so that their atomic updates are noticed.
+func fileFingerprint(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d:%d", info.Size(), info.ModTime().UnixNano()), nil
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	batchv1alpha1 "github.com/carbon-aware-kube/operator/api/v1alpha1"
+	schedulingclient "github.com/carbon-aware-kube/operator/internal/client"
+	"github.com/carbon-aware-kube/operator/internal/policy"
+)
+
+const (
+	// maxScheduleAlternatives is the number of alternative start times kept in status
+	maxScheduleAlternatives = 3
+
+	// minAlternativeSpacing is the least time between alternative start times in the same region,
+	// so that a job that cannot start does not try again moments later under the same conditions
+	minAlternativeSpacing = 30 * time.Minute
+)
+
+// scheduleAlternatives returns the greenest start times of a schedule response that a job can
+// move to if its Job cannot be started at the chosen option: times after the chosen one, or at the
+// same time in another region, that are allowed by the policy and the concurrency limit. Start
+// times in the same region are at least the job's duration, and minAlternativeSpacing, apart from
+// the chosen one and from each other.
+func scheduleAlternatives(limit *concurrencyLimit, jobPolicy *policy.Policy, scheduleResp *schedulingclient.ScheduleResponse, chosen schedulingclient.ScheduleOption, jobDuration time.Duration, windowEnd time.Time) []batchv1alpha1.ScheduleAlternative {
+	spacing := max(jobDuration, minAlternativeSpacing)
+	taken := []schedulingclient.ScheduleOption{chosen}
+	tooClose := func(option schedulingclient.ScheduleOption) bool {
+		return slices.ContainsFunc(taken, func(other schedulingclient.ScheduleOption) bool {
+			return other.Zone.Region == option.Zone.Region && option.Time.Sub(other.Time).Abs() < spacing
+		})
+	}
+
+	ranked := slices.Clone(timeline(scheduleResp))
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].CO2Intensity < ranked[j].CO2Intensity
+	})
+
+	var alternatives []batchv1alpha1.ScheduleAlternative
+	for _, option := range ranked {
+		if len(alternatives) == maxScheduleAlternatives {
+			break
+		}
+		if option.Time.Before(chosen.Time) || tooClose(option) {
+			continue
+		}
+		if !optionAllowed(jobPolicy, scheduleResp.NaiveCase, option, windowEnd) || limit.fullAt(option.Time) {
+			continue
+		}
+		taken = append(taken, option)
+		alternatives = append(alternatives, batchv1alpha1.ScheduleAlternative{
+			Time:                       metav1.NewTime(option.Time),
+			Region:                     option.Zone.Region,
+			GridZone:                   option.Zone.GridZone,
+			CarbonIntensityGramsPerKWh: decimalQuantity(option.CO2Intensity),
+		})
+	}
+	return alternatives
+}
+
+// nextAlternative returns the index of the first alternative start time of a CarbonAwareJob
+// after now, or -1 if none is left
+func nextAlternative(carbonAwareJob *batchv1alpha1.CarbonAwareJob, now time.Time) int {
+	for i, alternative := range carbonAwareJob.Status.Alternatives {
+		if alternative.Time.After(now) {
+			return i
+		}
+	}
+	return -1
+}
+
+// moveToAlternative moves ScheduledTime to the alternative at index i, which it removes together
+// with the ones before it, and records the change as a schedule revision. The intensity and the
+// savings follow the alternative, and the region of the decision changes with it unless the
+// decision was made by the fallback policy.
+func moveToAlternative(carbonAwareJob *batchv1alpha1.CarbonAwareJob, i int, cause string, now time.Time) {
+	alternative := carbonAwareJob.Status.Alternatives[i]
+	carbonAwareJob.Status.Alternatives = carbonAwareJob.Status.Alternatives[i+1:]
+
+	decision := carbonAwareJob.Status.SchedulingDecision
+	intensity := "unknown"
+	intensityQuantity := func() *resource.Quantity { return nil }
+	var savings *batchv1alpha1.CarbonSavings
+	if grams := alternative.CarbonIntensityGramsPerKWh; grams != nil {
+		value := grams.AsApproximateFloat64()
+		intensity = formatIntensity(value)
+		intensityQuantity = func() *resource.Quantity { return decimalQuantity(value) }
+		savings = decisionSavings(decision, value)
+	}
+	newTime := alternative.Time
+	reason := fmt.Sprintf("%s. Moved to the next best start time at %s (%s)", cause, newTime.UTC().Format(time.RFC3339), intensity)
+
+	regionChanged := decision != nil && decision.Region != "" && alternative.Region != "" && decision.Region != alternative.Region
+	if regionChanged {
+		reason = fmt.Sprintf("%s. Moved to the next best start time at %s in %s (%s)", cause, newTime.UTC().Format(time.RFC3339), alternative.Region, intensity)
+	}
+
+	addScheduleRevision(carbonAwareJob, now, newTime.Time, reason)
+	carbonAwareJob.Status.ScheduledTime = &newTime
+	carbonAwareJob.Status.CarbonIntensity = intensity
+	carbonAwareJob.Status.CarbonIntensityGramsPerKWh = intensityQuantity()
+	carbonAwareJob.Status.CarbonSavings = savings
+	if decision != nil {
+		decision.OptimalTime = &newTime
+		decision.OptimalIntensity = intensity
+		decision.OptimalIntensityGramsPerKWh = intensityQuantity()
+		decision.DecisionReason = reason
+		if regionChanged {
+			decision.Region = alternative.Region
+		}
+		if decision.Region == alternative.Region {
+			decision.GridZone = alternative.GridZone
+		}
+	}
+}
+
+// startFailed moves a CarbonAwareJob whose Job could not be created or resumed to its next
+// alternative start time instead of retrying the same moment. The error is returned to retry if
+// no alternative is left or the failure is a conflict that resolves on the next attempt.
+func (r *CarbonAwareJobReconciler) startFailed(ctx context.Context, carbonAwareJob *batchv1alpha1.CarbonAwareJob, cause string, err error) (ctrl.Result, error) {
+	now := time.Now()
+	i := nextAlternative(carbonAwareJob, now)
+	if i < 0 || errors.IsAlreadyExists(err) || errors.IsConflict(err) {
+		return ctrl.Result{}, err
+	}
+	return r.fallThrough(ctx, carbonAwareJob, i, cause, now)
+}
+
+// fallThrough moves a CarbonAwareJob to the alternative start time at index i, records the change
+// and requeues it for the new start time
+func (r *CarbonAwareJobReconciler) fallThrough(ctx context.Context, carbonAwareJob *batchv1alpha1.CarbonAwareJob, i int, cause string, now time.Time) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	logger.Info("Moving to the next alternative start time", "name", carbonAwareJob.Name, "cause", cause)
+
+	moveToAlternative(carbonAwareJob, i, cause, now)
+	revision := carbonAwareJob.Status.ScheduleRevisions[len(carbonAwareJob.Status.ScheduleRevisions)-1]
+	r.Recorder.Event(carbonAwareJob, corev1.EventTypeWarning, ReasonAlternativeScheduled, revision.Reason)
+	setCondition(carbonAwareJob, ConditionWaiting, metav1.ConditionTrue, ReasonAlternativeScheduled,
+		fmt.Sprintf("Waiting until %s", revision.NewTime.UTC().Format(time.RFC3339)))
+	if err := r.Status().Update(ctx, carbonAwareJob); err != nil {
+		logger.Error(err, "Failed to update CarbonAwareJob status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: time.Until(carbonAwareJob.Status.ScheduledTime.Time)}, nil
+}
+
+// startTimedOut reports whether a Job started more than StartTimeout ago without any pod that
+// became ready or finished, e.g. because its pods cannot be created or scheduled. A Job that was
+// ready once, as recorded in ReadyTime, is running and never times out, even if its pods are
+// restarting or failing their readiness probe.
+func (r *CarbonAwareJobReconciler) startTimedOut(carbonAwareJob *batchv1alpha1.CarbonAwareJob, job *batchv1.Job, now time.Time) bool {
+	if r.StartTimeout <= 0 || carbonAwareJob.Status.ReadyTime != nil || job.Status.StartTime == nil || job.Status.Ready == nil {
+		return false
+	}
+	return *job.Status.Ready == 0 && job.Status.Succeeded == 0 && job.Status.Failed == 0 &&
+		now.Sub(job.Status.StartTime.Time) > r.StartTimeout
+}
+
+// observeReady records in ReadyTime when a pod of the Job is first seen ready
+func observeReady(carbonAwareJob *batchv1alpha1.CarbonAwareJob, job *batchv1.Job, now time.Time) {
+	if carbonAwareJob.Status.ReadyTime == nil && job.Status.Ready != nil && *job.Status.Ready > 0 {
+		readyTime := metav1.NewTime(now)
+		carbonAwareJob.Status.ReadyTime = &readyTime
+	}
+}
+
+// restartAtAlternative deletes a Job that did not start in time and moves its CarbonAwareJob
+// back to Pending at the alternative start time at index i, where a new Job is created
+func (r *CarbonAwareJobReconciler) restartAtAlternative(ctx context.Context, carbonAwareJob *batchv1alpha1.CarbonAwareJob, job *batchv1.Job, i int, now time.Time) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	if err := r.Delete(ctx, job, ctrlclient.PropagationPolicy(metav1.DeletePropagationBackground)); ctrlclient.IgnoreNotFound(err) != nil {
+		logger.Error(err, "Failed to delete Job that did not start")
+		return ctrl.Result{}, err
+	}
+	cause := fmt.Sprintf("Job %s had no ready pod %s after it started and was deleted", job.Name, r.StartTimeout)
+	setCondition(carbonAwareJob, ConditionJobCreated, metav1.ConditionFalse, ReasonAlternativeScheduled, cause)
+	carbonAwareJob.Status.JobName = ""
+	carbonAwareJob.Status.JobStatus = nil
+	carbonAwareJob.Status.SchedulingState = string(SchedulingStatePending)
+	return r.fallThrough(ctx, carbonAwareJob, i, cause, now)
+}
@@ -0,0 +1,348 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ref "k8s.io/client-go/tools/reference"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	batchv1alpha1 "github.com/carbon-aware-kube/operator/api/v1alpha1"
+)
+
+const (
+	// CronJobNameLabel is set on every CarbonAwareJob created by a CarbonAwareCronJob
+	CronJobNameLabel = "carbonaware.dev/cronjob-name"
+
+	// CronScheduledTimeAnnotation records the cron tick a CarbonAwareJob was created for
+	CronScheduledTimeAnnotation = "carbonaware.dev/cron-scheduled-time"
+
+	// maxMissedSchedules bounds how many missed ticks are walked before giving up
+	maxMissedSchedules = 100
+)
+
+// CarbonAwareCronJobReconciler reconciles a CarbonAwareCronJob object
+type CarbonAwareCronJobReconciler struct {
+	ctrlclient.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=batch.carbonaware.dev,resources=carbonawarecronjobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=batch.carbonaware.dev,resources=carbonawarecronjobs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=batch.carbonaware.dev,resources=carbonawarecronjobs/finalizers,verbs=update
+// +kubebuilder:rbac:groups=batch.carbonaware.dev,resources=carbonawarejobs,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile creates a CarbonAwareJob for every tick of the cron schedule, enforces
+// the concurrency policy and prunes finished runs beyond the history limits.
+func (r *CarbonAwareCronJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	// Fetch the CarbonAwareCronJob instance
+	var cronJob batchv1alpha1.CarbonAwareCronJob
+	if err := r.Get(ctx, req.NamespacedName, &cronJob); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Failed to get CarbonAwareCronJob")
+		return ctrl.Result{}, err
+	}
+
+	// List all CarbonAwareJobs created for this CarbonAwareCronJob
+	var childJobs batchv1alpha1.CarbonAwareJobList
+	if err := r.List(ctx, &childJobs,
+		ctrlclient.InNamespace(cronJob.Namespace),
+		ctrlclient.MatchingLabels{CronJobNameLabel: cronJob.Name},
+	); err != nil {
+		logger.Error(err, "Failed to list child CarbonAwareJobs")
+		return ctrl.Result{}, err
+	}
+
+	var activeJobs, successfulJobs, failedJobs []*batchv1alpha1.CarbonAwareJob
+	for i := range childJobs.Items {
+		child := &childJobs.Items[i]
+		if !metav1.IsControlledBy(child, &cronJob) {
+			continue
+		}
+
+		switch child.Status.SchedulingState {
+		case string(SchedulingStateCompleted):
+			successfulJobs = append(successfulJobs, child)
+			if child.Status.JobStatus != nil && child.Status.JobStatus.CompletionTime != nil {
+				completionTime := child.Status.JobStatus.CompletionTime
+				if cronJob.Status.LastSuccessfulTime == nil || cronJob.Status.LastSuccessfulTime.Before(completionTime) {
+					cronJob.Status.LastSuccessfulTime = completionTime.DeepCopy()
+				}
+			}
+		case string(SchedulingStateFailed):
+			failedJobs = append(failedJobs, child)
+		default:
+			activeJobs = append(activeJobs, child)
+		}
+
+		// Keep the last schedule time in sync with the most recent run we know about
+		if scheduledTime := getCronScheduledTime(child); scheduledTime != nil {
+			if cronJob.Status.LastScheduleTime == nil || cronJob.Status.LastScheduleTime.Time.Before(*scheduledTime) {
+				cronJob.Status.LastScheduleTime = &metav1.Time{Time: *scheduledTime}
+			}
+		}
+	}
+
+	cronJob.Status.Active = nil
+	for _, activeJob := range activeJobs {
+		jobRef, err := ref.GetReference(r.Scheme, activeJob)
+		if err != nil {
+			logger.Error(err, "Unable to make reference to active CarbonAwareJob", "job", activeJob.Name)
+			continue
+		}
+		cronJob.Status.Active = append(cronJob.Status.Active, *jobRef)
+	}
+
+	if err := r.Status().Update(ctx, &cronJob); err != nil {
+		logger.Error(err, "Failed to update CarbonAwareCronJob status")
+		return ctrl.Result{}, err
+	}
+
+	// Prune finished runs beyond the configured history limits
+	if cronJob.Spec.FailedJobsHistoryLimit != nil {
+		r.deleteOldJobs(ctx, failedJobs, *cronJob.Spec.FailedJobsHistoryLimit)
+	}
+	if cronJob.Spec.SuccessfulJobsHistoryLimit != nil {
+		r.deleteOldJobs(ctx, successfulJobs, *cronJob.Spec.SuccessfulJobsHistoryLimit)
+	}
+
+	if cronJob.Spec.Suspend != nil && *cronJob.Spec.Suspend {
+		logger.Info("CarbonAwareCronJob suspended, skipping")
+		return ctrl.Result{}, nil
+	}
+
+	sched, err := parseCronSchedule(&cronJob)
+	if err != nil {
+		// An invalid schedule won't fix itself until the spec changes, so don't requeue
+		logger.Error(err, "Unparseable schedule", "schedule", cronJob.Spec.Schedule)
+		return ctrl.Result{}, nil
+	}
+
+	now := time.Now()
+	missedRun, nextRun, err := getNextSchedule(&cronJob, now, sched)
+	if err != nil {
+		logger.Error(err, "Unable to figure out CarbonAwareCronJob schedule")
+		return ctrl.Result{}, nil
+	}
+
+	scheduledResult := ctrl.Result{RequeueAfter: nextRun.Sub(now)}
+	if missedRun.IsZero() {
+		return scheduledResult, nil
+	}
+
+	logger = logger.WithValues("currentRun", missedRun, "nextRun", nextRun)
+	if cronJob.Spec.StartingDeadlineSeconds != nil {
+		deadline := missedRun.Add(time.Duration(*cronJob.Spec.StartingDeadlineSeconds) * time.Second)
+		if deadline.Before(now) {
+			logger.Info("Missed starting deadline for last run, sleeping till next")
+			return scheduledResult, nil
+		}
+	}
+
+	// Apply the concurrency policy to runs that are still waiting or running
+	switch cronJob.Spec.ConcurrencyPolicy {
+	case batchv1alpha1.ForbidConcurrent:
+		if len(activeJobs) > 0 {
+			logger.Info("Concurrency policy blocks concurrent runs, skipping", "active", len(activeJobs))
+			return scheduledResult, nil
+		}
+	case batchv1alpha1.ReplaceConcurrent:
+		for _, activeJob := range activeJobs {
+			// Deleting the CarbonAwareJob lets its finalizer clean up the underlying Job
+			if err := r.Delete(ctx, activeJob, ctrlclient.PropagationPolicy(metav1.DeletePropagationBackground)); ctrlclient.IgnoreNotFound(err) != nil {
+				logger.Error(err, "Failed to delete active CarbonAwareJob", "job", activeJob.Name)
+				return ctrl.Result{}, err
+			}
+		}
+	}
+
+	child, err := r.constructCarbonAwareJobForCronJob(&cronJob, missedRun)
+	if err != nil {
+		logger.Error(err, "Failed to construct CarbonAwareJob from template")
+		return scheduledResult, nil
+	}
+
+	if err := r.Create(ctx, child); err != nil && !errors.IsAlreadyExists(err) {
+		logger.Error(err, "Failed to create CarbonAwareJob for CarbonAwareCronJob", "job", child.Name)
+		return ctrl.Result{}, err
+	}
+	logger.Info("Created CarbonAwareJob for CarbonAwareCronJob run", "job", child.Name)
+
+	// Record the run so the next reconcile doesn't pick the same tick again
+	cronJob.Status.LastScheduleTime = &metav1.Time{Time: missedRun}
+	if err := r.Status().Update(ctx, &cronJob); err != nil {
+		logger.Error(err, "Failed to update CarbonAwareCronJob status")
+		return ctrl.Result{}, err
+	}
+
+	return scheduledResult, nil
+}
+
+// deleteOldJobs deletes the oldest finished CarbonAwareJobs beyond the given history limit
+func (r *CarbonAwareCronJobReconciler) deleteOldJobs(ctx context.Context, jobs []*batchv1alpha1.CarbonAwareJob, limit int32) {
+	logger := log.FromContext(ctx)
+
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].CreationTimestamp.Before(&jobs[j].CreationTimestamp)
+	})
+
+	for i := 0; i < len(jobs)-int(limit); i++ {
+		job := jobs[i]
+		if err := r.Delete(ctx, job, ctrlclient.PropagationPolicy(metav1.DeletePropagationBackground)); ctrlclient.IgnoreNotFound(err) != nil {
+			logger.Error(err, "Failed to delete old CarbonAwareJob", "job", job.Name)
+		} else {
+			logger.Info("Deleted old CarbonAwareJob", "job", job.Name)
+		}
+	}
+}
+
+// constructCarbonAwareJobForCronJob creates a CarbonAwareJob from the CarbonAwareCronJob template for the given tick
+func (r *CarbonAwareCronJobReconciler) constructCarbonAwareJobForCronJob(cronJob *batchv1alpha1.CarbonAwareCronJob, scheduledTime time.Time) (*batchv1alpha1.CarbonAwareJob, error) {
+	// Use a deterministic name so the same tick never produces two runs
+	name := fmt.Sprintf("%s-%d", cronJob.Name, scheduledTime.Unix())
+
+	child := &batchv1alpha1.CarbonAwareJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   cronJob.Namespace,
+			Labels:      make(map[string]string),
+			Annotations: make(map[string]string),
+		},
+		Spec: *cronJob.Spec.JobTemplate.Spec.DeepCopy(),
+	}
+
+	for k, v := range cronJob.Spec.JobTemplate.Metadata.Labels {
+		child.Labels[k] = v
+	}
+	for k, v := range cronJob.Spec.JobTemplate.Metadata.Annotations {
+		child.Annotations[k] = v
+	}
+	child.Labels[CronJobNameLabel] = cronJob.Name
+	child.Annotations[CronScheduledTimeAnnotation] = scheduledTime.Format(time.RFC3339)
+
+	if err := controllerutil.SetControllerReference(cronJob, child, r.Scheme); err != nil {
+		return nil, err
+	}
+
+	return child, nil
+}
+
+// getCronScheduledTime returns the cron tick a CarbonAwareJob was created for, if known
+func getCronScheduledTime(job *batchv1alpha1.CarbonAwareJob) *time.Time {
+	timeRaw := job.Annotations[CronScheduledTimeAnnotation]
+	if timeRaw == "" {
+		return nil
+	}
+
+	timeParsed, err := time.Parse(time.RFC3339, timeRaw)
+	if err != nil {
+		return nil
+	}
+	return &timeParsed
+}
+
+// submissionTimeFor returns the time a CarbonAwareJob's window starts from. Jobs created by a
+// CarbonAwareCronJob start from their cron tick, so that a late first reconcile does not shift
+// the window. The tick annotation is ignored on other jobs, whose creators could otherwise
+// backdate their window.
+func submissionTimeFor(job *batchv1alpha1.CarbonAwareJob, now time.Time) time.Time {
+	if !ownedByCronJob(job) {
+		return now
+	}
+	if tick := getCronScheduledTime(job); tick != nil && tick.Before(now) {
+		return *tick
+	}
+	return now
+}
+
+// ownedByCronJob reports whether a CarbonAwareJob is controlled by a CarbonAwareCronJob
+func ownedByCronJob(job *batchv1alpha1.CarbonAwareJob) bool {
+	owner := metav1.GetControllerOf(job)
+	if owner == nil || owner.Kind != "CarbonAwareCronJob" {
+		return false
+	}
+	gv, err := schema.ParseGroupVersion(owner.APIVersion)
+	return err == nil && gv.Group == batchv1alpha1.GroupVersion.Group
+}
+
+// parseCronSchedule parses the schedule of a CarbonAwareCronJob, honoring its time zone
+func parseCronSchedule(cronJob *batchv1alpha1.CarbonAwareCronJob) (cron.Schedule, error) {
+	spec := cronJob.Spec.Schedule
+	if cronJob.Spec.TimeZone != nil && *cronJob.Spec.TimeZone != "" {
+		if _, err := time.LoadLocation(*cronJob.Spec.TimeZone); err != nil {
+			return nil, fmt.Errorf("unknown time zone %q: %w", *cronJob.Spec.TimeZone, err)
+		}
+		spec = fmt.Sprintf("CRON_TZ=%s %s", *cronJob.Spec.TimeZone, spec)
+	}
+	return cron.ParseStandard(spec)
+}
+
+// getNextSchedule returns the most recent tick that was missed (zero if none) and the next tick after now
+func getNextSchedule(cronJob *batchv1alpha1.CarbonAwareCronJob, now time.Time, sched cron.Schedule) (lastMissed time.Time, next time.Time, err error) {
+	// Start from the last run we know about, or from creation if we've never run
+	earliestTime := cronJob.CreationTimestamp.Time
+	if cronJob.Status.LastScheduleTime != nil {
+		earliestTime = cronJob.Status.LastScheduleTime.Time
+	}
+
+	// Ticks older than the starting deadline can never be started, so skip them
+	if cronJob.Spec.StartingDeadlineSeconds != nil {
+		schedulingDeadline := now.Add(-time.Duration(*cronJob.Spec.StartingDeadlineSeconds) * time.Second)
+		if schedulingDeadline.After(earliestTime) {
+			earliestTime = schedulingDeadline
+		}
+	}
+
+	if earliestTime.After(now) {
+		return time.Time{}, sched.Next(now), nil
+	}
+
+	starts := 0
+	for t := sched.Next(earliestTime); !t.After(now); t = sched.Next(t) {
+		lastMissed = t
+		starts++
+		if starts > maxMissedSchedules {
+			return time.Time{}, time.Time{}, fmt.Errorf("too many missed start times (> %d), set or decrease .spec.startingDeadlineSeconds or check clock skew", maxMissedSchedules)
+		}
+	}
+
+	return lastMissed, sched.Next(now), nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *CarbonAwareCronJobReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&batchv1alpha1.CarbonAwareCronJob{}).
+		Owns(&batchv1alpha1.CarbonAwareJob{}).
+		Complete(r)
+}
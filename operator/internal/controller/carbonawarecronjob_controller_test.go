@@ -0,0 +1,262 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	batchv1alpha1 "github.com/carbon-aware-kube/operator/api/v1alpha1"
+)
+
+var _ = Describe("CarbonAwareCronJob Controller", func() {
+	const cronJobName = "test-carbonawarecronjob"
+
+	var (
+		namespacedName types.NamespacedName
+		testNS         *corev1.Namespace
+		reconciler     *CarbonAwareCronJobReconciler
+	)
+
+	newCronJob := func(policy batchv1alpha1.ConcurrencyPolicy) *batchv1alpha1.CarbonAwareCronJob {
+		return &batchv1alpha1.CarbonAwareCronJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      cronJobName,
+				Namespace: testNS.Name,
+			},
+			Spec: batchv1alpha1.CarbonAwareCronJobSpec{
+				Schedule:          "*/5 * * * *",
+				ConcurrencyPolicy: policy,
+				JobTemplate: batchv1alpha1.CarbonAwareJobTemplateSpec{
+					Spec: batchv1alpha1.CarbonAwareJobSpec{
+						MaxDelay: metav1.Duration{Duration: 2 * time.Hour},
+						Template: batchv1alpha1.JobTemplateSpec{
+							Spec: batchv1.JobSpec{
+								Template: corev1.PodTemplateSpec{
+									Spec: corev1.PodSpec{
+										Containers: []corev1.Container{
+											{
+												Name:  "test",
+												Image: "test:latest",
+											},
+										},
+										RestartPolicy: corev1.RestartPolicyNever,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	// createWithMissedRun creates the CarbonAwareCronJob and backdates its last run so a tick is due
+	createWithMissedRun := func(cronJob *batchv1alpha1.CarbonAwareCronJob) {
+		Expect(k8sClient.Create(ctx, cronJob)).To(Succeed())
+		cronJob.Status.LastScheduleTime = &metav1.Time{Time: time.Now().Add(-6 * time.Minute)}
+		Expect(k8sClient.Status().Update(ctx, cronJob)).To(Succeed())
+	}
+
+	listChildren := func() []batchv1alpha1.CarbonAwareJob {
+		var children batchv1alpha1.CarbonAwareJobList
+		Expect(k8sClient.List(ctx, &children,
+			ctrlclient.InNamespace(testNS.Name),
+			ctrlclient.MatchingLabels{CronJobNameLabel: cronJobName},
+		)).To(Succeed())
+		return children.Items
+	}
+
+	BeforeEach(func() {
+		testNS = &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-ns-",
+			},
+		}
+		Expect(k8sClient.Create(ctx, testNS)).To(Succeed())
+
+		namespacedName = types.NamespacedName{
+			Name:      cronJobName,
+			Namespace: testNS.Name,
+		}
+
+		reconciler = &CarbonAwareCronJobReconciler{
+			Client: k8sClient,
+			Scheme: k8sClient.Scheme(),
+		}
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(ctx, testNS)).To(Succeed())
+	})
+
+	Context("When a tick is due", func() {
+		It("Should create a CarbonAwareJob from the template", func() {
+			createWithMissedRun(newCronJob(batchv1alpha1.AllowConcurrent))
+
+			result, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+
+			children := listChildren()
+			Expect(children).To(HaveLen(1))
+			Expect(children[0].Spec.MaxDelay.Duration).To(Equal(2 * time.Hour))
+			Expect(children[0].Annotations).To(HaveKey(CronScheduledTimeAnnotation))
+
+			cronJob := &batchv1alpha1.CarbonAwareCronJob{}
+			Expect(k8sClient.Get(ctx, namespacedName, cronJob)).To(Succeed())
+			Expect(cronJob.Status.LastScheduleTime).NotTo(BeNil())
+			Expect(cronJob.Status.LastScheduleTime.Time).To(BeTemporally(">", time.Now().Add(-5*time.Minute)))
+		})
+	})
+
+	Context("When the concurrency policy is Forbid", func() {
+		It("Should skip the run while a previous CarbonAwareJob is still pending", func() {
+			createWithMissedRun(newCronJob(batchv1alpha1.ForbidConcurrent))
+
+			_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(listChildren()).To(HaveLen(1))
+
+			By("Backdating the last run again while the first run is still active")
+			cronJob := &batchv1alpha1.CarbonAwareCronJob{}
+			Expect(k8sClient.Get(ctx, namespacedName, cronJob)).To(Succeed())
+			cronJob.Status.LastScheduleTime = &metav1.Time{Time: time.Now().Add(-6 * time.Minute)}
+			Expect(k8sClient.Status().Update(ctx, cronJob)).To(Succeed())
+
+			_, err = reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(listChildren()).To(HaveLen(1))
+		})
+	})
+})
+
+var _ = Describe("getNextSchedule", func() {
+	var cronJob *batchv1alpha1.CarbonAwareCronJob
+
+	BeforeEach(func() {
+		cronJob = &batchv1alpha1.CarbonAwareCronJob{
+			Spec: batchv1alpha1.CarbonAwareCronJobSpec{
+				Schedule: "0 * * * *",
+			},
+		}
+	})
+
+	It("Should return the most recent missed tick and the next one", func() {
+		now := time.Date(2025, 1, 1, 10, 30, 0, 0, time.UTC)
+		cronJob.Status.LastScheduleTime = &metav1.Time{Time: now.Add(-3 * time.Hour)}
+		sched, err := parseCronSchedule(cronJob)
+		Expect(err).NotTo(HaveOccurred())
+
+		missed, next, err := getNextSchedule(cronJob, now, sched)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(missed).To(Equal(time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)))
+		Expect(next).To(Equal(time.Date(2025, 1, 1, 11, 0, 0, 0, time.UTC)))
+	})
+
+	It("Should not report a missed tick when the last run is current", func() {
+		now := time.Date(2025, 1, 1, 10, 30, 0, 0, time.UTC)
+		cronJob.Status.LastScheduleTime = &metav1.Time{Time: time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)}
+		sched, err := parseCronSchedule(cronJob)
+		Expect(err).NotTo(HaveOccurred())
+
+		missed, _, err := getNextSchedule(cronJob, now, sched)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(missed.IsZero()).To(BeTrue())
+	})
+
+	It("Should ignore ticks older than the starting deadline", func() {
+		now := time.Date(2025, 1, 1, 10, 30, 0, 0, time.UTC)
+		cronJob.Status.LastScheduleTime = &metav1.Time{Time: now.Add(-500 * time.Hour)}
+		deadline := int64(3600)
+		cronJob.Spec.StartingDeadlineSeconds = &deadline
+		sched, err := parseCronSchedule(cronJob)
+		Expect(err).NotTo(HaveOccurred())
+
+		missed, _, err := getNextSchedule(cronJob, now, sched)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(missed).To(Equal(time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)))
+	})
+
+	It("Should fail when too many ticks were missed", func() {
+		now := time.Date(2025, 1, 1, 10, 30, 0, 0, time.UTC)
+		cronJob.Status.LastScheduleTime = &metav1.Time{Time: now.Add(-500 * time.Hour)}
+		sched, err := parseCronSchedule(cronJob)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, _, err = getNextSchedule(cronJob, now, sched)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("Should reject unknown time zones", func() {
+		tz := "Mars/Olympus_Mons"
+		cronJob.Spec.TimeZone = &tz
+		_, err := parseCronSchedule(cronJob)
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("submissionTimeFor", func() {
+	It("Should start the window of a cron run at its tick", func() {
+		now := time.Date(2025, 1, 1, 10, 30, 0, 0, time.UTC)
+		tick := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+		cronJob := &batchv1alpha1.CarbonAwareCronJob{ObjectMeta: metav1.ObjectMeta{Name: "nightly", UID: "cron-uid"}}
+		cronJob.SetGroupVersionKind(batchv1alpha1.GroupVersion.WithKind("CarbonAwareCronJob"))
+		child := &batchv1alpha1.CarbonAwareJob{ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{CronScheduledTimeAnnotation: tick.Format(time.RFC3339)},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(cronJob, cronJob.GroupVersionKind()),
+			},
+		}}
+		Expect(submissionTimeFor(child, now)).To(Equal(tick))
+
+		By("Ignoring ticks in the future")
+		Expect(submissionTimeFor(child, tick.Add(-time.Minute))).To(Equal(tick.Add(-time.Minute)))
+
+		By("Starting other jobs now")
+		Expect(submissionTimeFor(&batchv1alpha1.CarbonAwareJob{}, now)).To(Equal(now))
+	})
+
+	It("Should ignore the tick annotation on jobs not controlled by a CarbonAwareCronJob", func() {
+		now := time.Date(2025, 1, 1, 10, 30, 0, 0, time.UTC)
+		tick := time.Date(2024, 12, 1, 10, 0, 0, 0, time.UTC)
+		job := &batchv1alpha1.CarbonAwareJob{ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{CronScheduledTimeAnnotation: tick.Format(time.RFC3339)},
+		}}
+		Expect(submissionTimeFor(job, now)).To(Equal(now))
+
+		By("Ignoring owners that are not CarbonAwareCronJobs")
+		job.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: "batch/v1", Kind: "CronJob", Name: "nightly", UID: "cron-uid", Controller: boolPtr(true),
+		}}
+		Expect(submissionTimeFor(job, now)).To(Equal(now))
+
+		By("Ignoring CarbonAwareCronJobs that do not control the job")
+		job.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: batchv1alpha1.GroupVersion.String(), Kind: "CarbonAwareCronJob", Name: "nightly", UID: "cron-uid",
+		}}
+		Expect(submissionTimeFor(job, now)).To(Equal(now))
+	})
+})
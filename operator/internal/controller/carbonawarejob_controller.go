@@ -20,21 +20,30 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	batchv1alpha1 "github.com/carbon-aware-kube/operator/api/v1alpha1"
-	cloudinfo "github.com/carbon-aware-kube/operator/internal/introspection"
 	schedulingclient "github.com/carbon-aware-kube/operator/internal/client"
+	"github.com/carbon-aware-kube/operator/internal/config"
+	"github.com/carbon-aware-kube/operator/internal/forecast"
+	"github.com/carbon-aware-kube/operator/internal/gridzone"
+	cloudinfo "github.com/carbon-aware-kube/operator/internal/introspection"
+	"github.com/carbon-aware-kube/operator/internal/policy"
 )
 
 // SchedulingState represents the current state of the carbon-aware scheduling process
@@ -47,6 +56,10 @@ const (
 	// SchedulingStatePending indicates a CarbonAwareJob that is waiting for its optimal start time
 	SchedulingStatePending SchedulingState = "Pending"
 
+	// SchedulingStateForecastUnavailable indicates a CarbonAwareJob whose forecast could not be
+	// obtained yet and that is retrying until the fallback cutoff
+	SchedulingStateForecastUnavailable SchedulingState = "ForecastUnavailable"
+
 	// SchedulingStateScheduled indicates a CarbonAwareJob that has been scheduled and the Job has been created
 	SchedulingStateScheduled SchedulingState = "Scheduled"
 
@@ -61,29 +74,135 @@ const (
 
 	// CarbonAwareJobFinalizer is the finalizer name for CarbonAwareJob resources
 	CarbonAwareJobFinalizer = "batch.carbonaware.dev/finalizer"
+
+	// maxScheduleRevisions is the number of schedule revisions kept in status
+	maxScheduleRevisions = 10
+
+	// scheduleRevisionTolerance is the minimum shift of ScheduledTime that counts as a revision
+	scheduleRevisionTolerance = time.Minute
+
+	// defaultForecastRetryInterval is used when ForecastRetryInterval is not set
+	defaultForecastRetryInterval = time.Minute
+
+	// defaultStatusCheckInterval is used when StatusCheckInterval is not set
+	defaultStatusCheckInterval = 30 * time.Second
+
+	// annotationScheduledTime records the chosen start time on Jobs and gated Pods
+	annotationScheduledTime = "carbonaware.dev/scheduled-time"
+
+	// annotationCarbonIntensity records the forecast intensity at the chosen start time
+	annotationCarbonIntensity = "carbonaware.dev/carbon-intensity"
+
+	// annotationGridZone records the grid zone whose forecast the start time was chosen from
+	annotationGridZone = "carbonaware.dev/grid-zone"
 )
 
 // CarbonAwareJobReconciler reconciles a CarbonAwareJob object
 type CarbonAwareJobReconciler struct {
 	ctrlclient.Client
 	Scheme *runtime.Scheme
+	// Recorder emits events for scheduling transitions
+	Recorder record.EventRecorder
 	// SchedulingClient is a client for fetching carbon intensity forecasts
 	SchedulingClient schedulingclient.SchedulingClientInterface
-	// CloudEnvironment is the cloud environment detected by introspection
+	// CloudEnvironment is the cloud environment of Jobs whose nodes do not report one, or of all
+	// Jobs if OverrideCloudEnvironment is set
 	CloudEnvironment *cloudinfo.CloudEnvironment
+	// OverrideCloudEnvironment requests forecasts for CloudEnvironment instead of the regions of
+	// the nodes a Job can run on
+	OverrideCloudEnvironment bool
+	// Nodes groups the cluster's nodes by location. If nil, nodes are listed when a placement
+	// refers to them
+	Nodes *cloudinfo.NodeInventory
+	// GridZones maps cloud regions to the grid zones sent with forecast requests
+	GridZones *gridzone.Table
+	// ForecastSource names the forecast provider in scheduling decisions
+	ForecastSource string
+	// DefaultMaxDuration is the expected run time of jobs that do not set MaxDuration
+	DefaultMaxDuration time.Duration
+	// ReforecastInterval is how often pending jobs re-query the forecast. Zero disables re-evaluation
+	ReforecastInterval time.Duration
+	// ForecastRetryInterval is how often a job in ForecastUnavailable retries the forecast
+	ForecastRetryInterval time.Duration
+	// ForecastRetryCutoff is how long before the end of the window the controller stops
+	// retrying the forecast and applies the job's fallback policy
+	ForecastRetryCutoff time.Duration
+	// StatusCheckInterval is how often the status of a running Job is checked
+	StatusCheckInterval time.Duration
+	// StartTimeout is how long a started Job may go without a ready or finished pod before the
+	// job moves to its next alternative start time. Zero disables the check
+	StartTimeout time.Duration
+	// PowerModel estimates the energy of finished Jobs. The defaults are used if zero
+	PowerModel config.EmissionsConfig
+	// Config provides the operator configuration and its reloads. The defaults are used if nil
+	Config *config.Watcher
+
+	// settings guards the fields updated by ApplyConfig. Reconcile works on a snapshot, which
+	// must copy every field above.
+	settings sync.RWMutex
+	// origin is the reconciler a snapshot was taken from, whose trackers it shares
+	origin *CarbonAwareJobReconciler
+	// lowHours learns the hour of day with the lowest forecast intensity per zone
+	lowHours lowHourTracker
+	// states tracks the scheduling state of every CarbonAwareJob for metrics
+	states stateTracker
 }
 
-// +kubebuilder:rbac:groups=batch.carbon-aware.dev,resources=carbonawarejobs,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=batch.carbon-aware.dev,resources=carbonawarejobs/status,verbs=get;update;patch
-// +kubebuilder:rbac:groups=batch.carbon-aware.dev,resources=carbonawarejobs/finalizers,verbs=update
+// +kubebuilder:rbac:groups=batch.carbonaware.dev,resources=carbonawarejobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=batch.carbonaware.dev,resources=carbonawarejobs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=batch.carbonaware.dev,resources=carbonawarejobs/finalizers,verbs=update
+// +kubebuilder:rbac:groups=batch.carbonaware.dev,resources=carbonpolicies;clustercarbonpolicies,verbs=get;list;watch
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=,resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.19.0/pkg/reconcile
 func (r *CarbonAwareJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	// The settings are copied rather than locked for the whole reconciliation, so that a reload
+	// does not wait for forecast requests
+	return r.snapshot().reconcile(ctx, req)
+}
+
+// snapshot returns a copy of the reconciler with the settings in effect, sharing its trackers
+func (r *CarbonAwareJobReconciler) snapshot() *CarbonAwareJobReconciler {
+	r.settings.RLock()
+	defer r.settings.RUnlock()
+	return &CarbonAwareJobReconciler{
+		Client:                   r.Client,
+		Scheme:                   r.Scheme,
+		Recorder:                 r.Recorder,
+		SchedulingClient:         r.SchedulingClient,
+		CloudEnvironment:         r.CloudEnvironment,
+		OverrideCloudEnvironment: r.OverrideCloudEnvironment,
+		Nodes:                    r.Nodes,
+		GridZones:                r.GridZones,
+		ForecastSource:           r.ForecastSource,
+		DefaultMaxDuration:       r.DefaultMaxDuration,
+		ReforecastInterval:       r.ReforecastInterval,
+		ForecastRetryInterval:    r.ForecastRetryInterval,
+		ForecastRetryCutoff:      r.ForecastRetryCutoff,
+		StatusCheckInterval:      r.StatusCheckInterval,
+		StartTimeout:             r.StartTimeout,
+		PowerModel:               r.PowerModel,
+		Config:                   r.Config,
+		origin:                   r.shared(),
+	}
+}
+
+// shared returns the reconciler that holds the trackers, which is the origin of a snapshot
+func (r *CarbonAwareJobReconciler) shared() *CarbonAwareJobReconciler {
+	if r.origin != nil {
+		return r.origin
+	}
+	return r
+}
+
+// reconcile reconciles a CarbonAwareJob with the settings of a snapshot
+func (r *CarbonAwareJobReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
 	// Fetch the CarbonAwareJob instance
@@ -92,18 +211,22 @@ func (r *CarbonAwareJobReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		if errors.IsNotFound(err) {
 			// Request object not found, could have been deleted after reconcile request.
 			// Return and don't requeue
+			r.shared().states.forget(req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
 		logger.Error(err, "Failed to get CarbonAwareJob")
 		return ctrl.Result{}, err
 	}
+	defer func() {
+		r.shared().states.set(req.NamespacedName, carbonAwareJob.Status.SchedulingState)
+	}()
 
 	// Initialize status if it's a new CarbonAwareJob
 	if carbonAwareJob.Status.SubmissionTime == nil {
 		logger.Info("Initializing CarbonAwareJob status", "name", carbonAwareJob.Name)
-		now := metav1.Now()
-		carbonAwareJob.Status.SubmissionTime = &now
+		submitted := metav1.NewTime(submissionTimeFor(&carbonAwareJob, time.Now()))
+		carbonAwareJob.Status.SubmissionTime = &submitted
 		carbonAwareJob.Status.SchedulingState = string(SchedulingStateNew)
 		if err := r.Status().Update(ctx, &carbonAwareJob); err != nil {
 			logger.Error(err, "Failed to initialize CarbonAwareJob status")
@@ -132,10 +255,18 @@ func (r *CarbonAwareJobReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 
 	// Handle the CarbonAwareJob based on its current state
 	switch carbonAwareJob.Status.SchedulingState {
-	case string(SchedulingStateNew):
-		return r.handleNewJob(ctx, &carbonAwareJob)
+	case string(SchedulingStateNew), string(SchedulingStateForecastUnavailable):
+		jobPolicy, err := r.applyPolicy(ctx, &carbonAwareJob)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		return r.handleNewJob(ctx, &carbonAwareJob, jobPolicy)
 	case string(SchedulingStatePending):
-		return r.handlePendingJob(ctx, &carbonAwareJob)
+		jobPolicy, err := r.applyPolicy(ctx, &carbonAwareJob)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		return r.handlePendingJob(ctx, &carbonAwareJob, jobPolicy)
 	case string(SchedulingStateScheduled), string(SchedulingStateRunning):
 		return r.handleScheduledJob(ctx, &carbonAwareJob)
 	case string(SchedulingStateCompleted), string(SchedulingStateFailed):
@@ -152,9 +283,9 @@ func (r *CarbonAwareJobReconciler) initializeStatus(ctx context.Context, carbonA
 	logger := log.FromContext(ctx)
 	logger.Info("Initializing CarbonAwareJob status", "name", carbonAwareJob.Name)
 
-	// Set submission time to now
-	now := metav1.NewTime(time.Now())
-	carbonAwareJob.Status.SubmissionTime = &now
+	// Set submission time to now, or to the cron tick the job was created for
+	submitted := metav1.NewTime(submissionTimeFor(carbonAwareJob, time.Now()))
+	carbonAwareJob.Status.SubmissionTime = &submitted
 	carbonAwareJob.Status.SchedulingState = string(SchedulingStateNew)
 
 	// Update the status
@@ -201,49 +332,91 @@ func (r *CarbonAwareJobReconciler) handleDeletion(ctx context.Context, carbonAwa
 	return ctrl.Result{}, nil
 }
 
-// handleNewJob processes a newly created CarbonAwareJob
-func (r *CarbonAwareJobReconciler) handleNewJob(ctx context.Context, carbonAwareJob *batchv1alpha1.CarbonAwareJob) (ctrl.Result, error) {
+// handleNewJob processes a newly created CarbonAwareJob under its policy, if any
+func (r *CarbonAwareJobReconciler) handleNewJob(ctx context.Context, carbonAwareJob *batchv1alpha1.CarbonAwareJob, jobPolicy *policy.Policy) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 	logger.Info("Handling new CarbonAwareJob", "name", carbonAwareJob.Name)
 
-	// Get submission time and max delay
+	// Get submission time and expected job duration
 	submissionTime := carbonAwareJob.Status.SubmissionTime.Time
-	maxDelay := carbonAwareJob.Spec.MaxDelay.Duration
+	jobDuration := r.jobDuration(carbonAwareJob)
 
-	// Default job duration to 1 hour if not specified
-	jobDuration := 1 * time.Hour
-	if carbonAwareJob.Spec.MaxDuration != nil && carbonAwareJob.Spec.MaxDuration.Duration > 0 {
-		jobDuration = carbonAwareJob.Spec.MaxDuration.Duration
+	// Derive the window of allowed start times from MaxDelay and Deadline
+	windowStart, windowEnd, err := schedulingWindow(carbonAwareJob, submissionTime, jobDuration)
+	if err != nil {
+		logger.Error(err, "CarbonAwareJob cannot be scheduled")
+		r.Recorder.Event(carbonAwareJob, corev1.EventTypeWarning, ReasonDeadlineUnmet, err.Error())
+		setCondition(carbonAwareJob, ConditionWaiting, metav1.ConditionFalse, ReasonDeadlineUnmet, err.Error())
+		setCondition(carbonAwareJob, ConditionFailed, metav1.ConditionTrue, ReasonDeadlineUnmet, err.Error())
+		carbonAwareJob.Status.SchedulingState = string(SchedulingStateFailed)
+		carbonAwareJob.Status.SchedulingDecision = &batchv1alpha1.SchedulingDecision{
+			DecisionReason: err.Error(),
+		}
+		if err := r.Status().Update(ctx, carbonAwareJob); err != nil {
+			logger.Error(err, "Failed to update CarbonAwareJob status")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+	carbonAwareJob.Status.SchedulingWindow = &batchv1alpha1.SchedulingWindow{
+		Start: metav1.NewTime(windowStart),
+		End:   metav1.NewTime(windowEnd),
+	}
+
+	// Retries after a forecast outage only search the remainder of the window
+	now := time.Now()
+	searchStart := windowStart
+	if now.After(searchStart) {
+		searchStart = now
+	}
+	if searchStart.After(windowEnd) {
+		searchStart = windowEnd
 	}
+	maxDelay := windowEnd.Sub(searchStart)
 
-	// Default cloud environment to AWS:us-east-1 if not specified
-	cloudZone := schedulingclient.CloudZone{
-		Provider: "aws",
-		Region:   "us-east-1",
+	// Request forecasts for every region the job and its policy allow
+	jobPlacement, err := r.restrictPlacement(ctx, carbonAwareJob, jobPolicy)
+	if err != nil {
+		logger.Error(err, "Failed to resolve CarbonAwareJob placement")
+		return ctrl.Result{}, err
 	}
-	if r.CloudEnvironment != nil {
-		cloudZone = schedulingclient.CloudZone{
-			Provider: r.CloudEnvironment.Provider,
-			Region:   r.CloudEnvironment.Region,
+
+	// In Suspended mode the Job is created before the forecast, so that template and quota
+	// errors surface at submission rather than at the scheduled time
+	if carbonAwareJob.Spec.JobCreation == batchv1alpha1.JobCreationSuspended && carbonAwareJob.Status.JobName == "" {
+		if err := r.createSuspendedJob(ctx, carbonAwareJob, jobPlacement); err != nil {
+			return ctrl.Result{}, err
 		}
-	} else {
-		logger.Error(nil, "CloudEnvironment not initialized. Defaulting to AWS:us-east-1")
 	}
 
 	// Get the optimal schedule from the scheduling API
-	scheduleResp, err := r.SchedulingClient.GetOptimalSchedule(
+	scheduleResp, err := getOptimalSchedule(
 		ctx,
-		submissionTime,
+		r.SchedulingClient,
+		r.GridZones,
+		searchStart,
 		maxDelay,
 		jobDuration,
-		cloudZone,
+		jobPlacement.zones,
 	)
 
 	if err != nil {
 		logger.Error(err, "Failed to get optimal schedule from API")
 
-		// Fallback to immediate scheduling if API fails
-		optimalTime := metav1.NewTime(submissionTime)
+		// Keep retrying until the cutoff rather than giving up on carbon savings after one outage
+		retryCutoff := windowEnd.Add(-r.ForecastRetryCutoff)
+		if now.Before(retryCutoff) {
+			return r.handleForecastUnavailable(ctx, carbonAwareJob, err, retryCutoff)
+		}
+
+		// Fallback according to the job's policy once the cutoff has passed
+		fallbackTime, fallbackReason := r.fallbackSchedule(carbonAwareJob, jobPlacement.zones, now, windowEnd)
+		if allowedTime := allowedStartTime(jobPolicy, fallbackTime, windowEnd); !allowedTime.Equal(fallbackTime) {
+			fallbackTime = allowedTime
+			fallbackReason = fmt.Sprintf("%s Moved to %s, which %s allows.", fallbackReason,
+				fallbackTime.UTC().Format(time.RFC3339), jobPolicy)
+		}
+		optimalTime := metav1.NewTime(fallbackTime)
 		carbonAwareJob.Status.SchedulingDecision = &batchv1alpha1.SchedulingDecision{
 			OptimalTime:        &optimalTime,
 			OptimalIntensity:   "unknown",
@@ -251,52 +424,90 @@ func (r *CarbonAwareJobReconciler) handleNewJob(ctx context.Context, carbonAware
 			WorstCaseIntensity: "unknown",
 			ImmediateIntensity: "unknown",
 			ForecastSource:     "fallback",
-			DecisionReason:     fmt.Sprintf("Failed to get forecast: %v. Scheduling immediately.", err),
+			Strategy:           strategyFallback,
+			DecisionReason:     fmt.Sprintf("Failed to get forecast: %v. %s", err, fallbackReason),
 		}
-
-		// Set the scheduled time to now
+		decisionReason := carbonAwareJob.Status.SchedulingDecision.DecisionReason
+		r.Recorder.Event(carbonAwareJob, corev1.EventTypeWarning, ReasonFallbackApplied, decisionReason)
+		setCondition(carbonAwareJob, ConditionForecastObtained, metav1.ConditionFalse, ReasonFallbackApplied, decisionReason)
+		setCondition(carbonAwareJob, ConditionDegraded, metav1.ConditionTrue, ReasonFallbackApplied, decisionReason)
+		setCondition(carbonAwareJob, ConditionWaiting, metav1.ConditionTrue, ReasonFallbackApplied,
+			fmt.Sprintf("Waiting until %s", fallbackTime.UTC().Format(time.RFC3339)))
+
+		// Set the scheduled time to the fallback time
 		carbonAwareJob.Status.ScheduledTime = &optimalTime
 		carbonAwareJob.Status.CarbonIntensity = "unknown"
+		carbonAwareJob.Status.CarbonIntensityGramsPerKWh = nil
 		carbonAwareJob.Status.CarbonSavings = &batchv1alpha1.CarbonSavings{
 			VsWorstCase:  "0.00%",
 			VsNaiveCase:  "0.00%",
 			VsMedianCase: "0.00%",
 		}
 	} else {
-		// Use the optimal schedule from the API response
-		optimalTime := metav1.NewTime(scheduleResp.Ideal.Time)
+		// Pick the start time from the forecast according to the job's strategy
+		chosen, decisionReason := selectOption(carbonAwareJob, scheduleResp)
+		chosen, decisionReason = applyPolicyConstraints(jobPolicy, scheduleResp, chosen, decisionReason, windowEnd)
+		limit, err := r.concurrencyLimit(ctx, carbonAwareJob, jobPolicy)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		chosen, decisionReason = spreadOption(limit, jobPolicy, scheduleResp, chosen, decisionReason, windowEnd)
+		carbonAwareJob.Status.Alternatives = scheduleAlternatives(limit, jobPolicy, scheduleResp, chosen, jobDuration, windowEnd)
+		optimalTime := metav1.NewTime(chosen.Time)
 		worstCaseTime := metav1.NewTime(scheduleResp.WorstCase.Time)
 
 		// Format zone information
-		optimalZone := fmt.Sprintf("%s:%s", scheduleResp.Ideal.Zone.Provider, scheduleResp.Ideal.Zone.Region)
-		
+		optimalZone := zoneName(chosen.Zone)
+
 		// Update the scheduling decision
 		carbonAwareJob.Status.SchedulingDecision = &batchv1alpha1.SchedulingDecision{
-			OptimalTime:        &optimalTime,
-			OptimalIntensity:   fmt.Sprintf("%.2f gCO2eq/kWh", scheduleResp.Ideal.CO2Intensity),
-			WorstCaseTime:      &worstCaseTime,
-			WorstCaseIntensity: fmt.Sprintf("%.2f gCO2eq/kWh", scheduleResp.WorstCase.CO2Intensity),
-			ImmediateIntensity: fmt.Sprintf("%.2f gCO2eq/kWh", scheduleResp.NaiveCase.CO2Intensity),
-			ForecastSource:     "carbon-aware-scheduler-api",
-			DecisionReason:     fmt.Sprintf("Optimal time determined for %s based on carbon intensity forecast", optimalZone),
+			OptimalTime:                   &optimalTime,
+			OptimalIntensity:              formatIntensity(chosen.CO2Intensity),
+			WorstCaseTime:                 &worstCaseTime,
+			WorstCaseIntensity:            formatIntensity(scheduleResp.WorstCase.CO2Intensity),
+			ImmediateIntensity:            formatIntensity(scheduleResp.NaiveCase.CO2Intensity),
+			OptimalIntensityGramsPerKWh:   decimalQuantity(chosen.CO2Intensity),
+			WorstCaseIntensityGramsPerKWh: decimalQuantity(scheduleResp.WorstCase.CO2Intensity),
+			ImmediateIntensityGramsPerKWh: decimalQuantity(scheduleResp.NaiveCase.CO2Intensity),
+			MedianIntensityGramsPerKWh:    decimalQuantity(scheduleResp.MedianCase.CO2Intensity),
+			ForecastSource:                r.forecastSource(),
+			DecisionReason:                decisionReason,
+			Strategy:                      string(schedulingStrategy(carbonAwareJob)),
+			Region:                        chosen.Zone.Region,
+			GridZone:                      chosen.Zone.GridZone,
 		}
 
 		// Set the scheduled time
 		carbonAwareJob.Status.ScheduledTime = &optimalTime
 
-		// Set carbon savings
-		carbonAwareJob.Status.CarbonSavings = &batchv1alpha1.CarbonSavings{
-			VsWorstCase:  fmt.Sprintf("-%.2f%%", scheduleResp.CarbonSavings.VsWorstCase),
-			VsNaiveCase:  fmt.Sprintf("-%.2f%%", scheduleResp.CarbonSavings.VsNaiveCase),
-			VsMedianCase: fmt.Sprintf("-%.2f%%", scheduleResp.CarbonSavings.VsMedianCase),
+		// Set carbon savings, as reported by the scheduler for the ideal time
+		if chosen == scheduleResp.Ideal {
+			carbonAwareJob.Status.CarbonSavings = newCarbonSavings(scheduleResp.CarbonSavings.VsWorstCase,
+				scheduleResp.CarbonSavings.VsNaiveCase, scheduleResp.CarbonSavings.VsMedianCase)
+		} else {
+			carbonAwareJob.Status.CarbonSavings = carbonSavingsFor(scheduleResp, chosen.CO2Intensity)
 		}
 
 		// Set carbon intensity
 		carbonAwareJob.Status.CarbonIntensity = carbonAwareJob.Status.SchedulingDecision.OptimalIntensity
+		carbonAwareJob.Status.CarbonIntensityGramsPerKWh = decimalQuantity(chosen.CO2Intensity)
+
+		r.shared().lowHours.record(scheduleResp.Ideal.Zone, scheduleResp.Ideal.Time)
+		message := fmt.Sprintf("Forecast from %s: starting at %s in %s at %s, %s vs running immediately (%s strategy)",
+			r.forecastSource(), optimalTime.UTC().Format(time.RFC3339), optimalZone,
+			carbonAwareJob.Status.CarbonIntensity, carbonAwareJob.Status.CarbonSavings.VsNaiveCase,
+			carbonAwareJob.Status.SchedulingDecision.Strategy)
+		r.Recorder.Event(carbonAwareJob, corev1.EventTypeNormal, ReasonForecastReceived, message)
+		setCondition(carbonAwareJob, ConditionForecastObtained, metav1.ConditionTrue, ReasonForecastReceived, message)
+		setCondition(carbonAwareJob, ConditionDegraded, metav1.ConditionFalse, ReasonForecastReceived, "")
+		setCondition(carbonAwareJob, ConditionWaiting, metav1.ConditionTrue, ReasonForecastReceived,
+			fmt.Sprintf("Waiting until %s", optimalTime.UTC().Format(time.RFC3339)))
 	}
 
 	// Update state to pending
 	carbonAwareJob.Status.SchedulingState = string(SchedulingStatePending)
+	forecastTime := metav1.Now()
+	carbonAwareJob.Status.LastForecastTime = &forecastTime
 
 	// Update the status
 	if err := r.Status().Update(ctx, carbonAwareJob); err != nil {
@@ -304,12 +515,200 @@ func (r *CarbonAwareJobReconciler) handleNewJob(ctx context.Context, carbonAware
 		return ctrl.Result{}, err
 	}
 
+	// The decision is only counted once it is stored, as a failed update schedules the job again
+	if carbonAwareJob.Status.SchedulingDecision.Strategy == strategyFallback {
+		fallbacks.WithLabelValues(string(fallbackStrategy(carbonAwareJob))).Inc()
+	}
+	observeScheduleDecision(carbonAwareJob, scheduleResp)
+
 	// Requeue to check if it's time to create the job
 	return ctrl.Result{RequeueAfter: time.Until(carbonAwareJob.Status.ScheduledTime.Time)}, nil
 }
 
+// handleForecastUnavailable parks a CarbonAwareJob in the ForecastUnavailable state and
+// requeues it to retry the forecast, at the latest at the retry cutoff
+func (r *CarbonAwareJobReconciler) handleForecastUnavailable(ctx context.Context, carbonAwareJob *batchv1alpha1.CarbonAwareJob, forecastErr error, retryCutoff time.Time) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	logger.Info("Forecast unavailable, retrying later", "name", carbonAwareJob.Name, "cutoff", retryCutoff)
+
+	carbonAwareJob.Status.SchedulingState = string(SchedulingStateForecastUnavailable)
+	carbonAwareJob.Status.SchedulingDecision = &batchv1alpha1.SchedulingDecision{
+		ForecastSource: "unavailable",
+		DecisionReason: fmt.Sprintf("Failed to get forecast: %v. Retrying until %s.", forecastErr, retryCutoff.UTC().Format(time.RFC3339)),
+	}
+	decisionReason := carbonAwareJob.Status.SchedulingDecision.DecisionReason
+	r.Recorder.Event(carbonAwareJob, corev1.EventTypeWarning, ReasonForecastFailed, decisionReason)
+	setCondition(carbonAwareJob, ConditionForecastObtained, metav1.ConditionFalse, ReasonForecastFailed, decisionReason)
+	setCondition(carbonAwareJob, ConditionWaiting, metav1.ConditionTrue, ReasonForecastFailed, "Waiting for a forecast")
+
+	if err := r.Status().Update(ctx, carbonAwareJob); err != nil {
+		logger.Error(err, "Failed to update CarbonAwareJob status")
+		return ctrl.Result{}, err
+	}
+
+	retryInterval := r.ForecastRetryInterval
+	if retryInterval <= 0 {
+		retryInterval = defaultForecastRetryInterval
+	}
+	if untilCutoff := time.Until(retryCutoff); untilCutoff < retryInterval {
+		retryInterval = untilCutoff
+	}
+	return ctrl.Result{RequeueAfter: retryInterval}, nil
+}
+
+// fallbackSchedule returns the start time and a human readable reason for a job whose
+// forecast could not be obtained, according to its fallback policy. The historical low hour
+// is taken from the first of zones that forecasts have been observed for.
+func (r *CarbonAwareJobReconciler) fallbackSchedule(carbonAwareJob *batchv1alpha1.CarbonAwareJob, zones []schedulingclient.CloudZone, now, windowEnd time.Time) (time.Time, string) {
+	switch fallbackStrategy(carbonAwareJob) {
+	case batchv1alpha1.FallbackWindowEnd:
+		if windowEnd.After(now) {
+			return windowEnd, "Scheduling at the end of the window."
+		}
+	case batchv1alpha1.FallbackHistoricalLow:
+		var hour int
+		var ok bool
+		for _, zone := range zones {
+			if hour, ok = r.shared().lowHours.lowHour(zone); ok {
+				break
+			}
+		}
+		if !ok && carbonAwareJob.Spec.Fallback.LowHourUTC != nil {
+			hour, ok = int(*carbonAwareJob.Spec.Fallback.LowHourUTC), true
+		}
+		if ok {
+			if start, found := nextHourOccurrence(hour, now, windowEnd); found {
+				return start, fmt.Sprintf("Scheduling at the historical low hour %02d:00 UTC.", hour)
+			}
+		}
+	}
+
+	return now, "Scheduling immediately."
+}
+
+// fallbackStrategy returns the fallback strategy of a CarbonAwareJob, defaulting to Immediate
+func fallbackStrategy(carbonAwareJob *batchv1alpha1.CarbonAwareJob) batchv1alpha1.FallbackStrategy {
+	if carbonAwareJob.Spec.Fallback != nil && carbonAwareJob.Spec.Fallback.Strategy != "" {
+		return carbonAwareJob.Spec.Fallback.Strategy
+	}
+	return batchv1alpha1.FallbackImmediate
+}
+
+// getOptimalSchedule queries the scheduling client for zones annotated with their grid zones and
+// records the request in the metrics. Zones of the response are annotated too, in case the
+// client does not return them.
+func getOptimalSchedule(ctx context.Context, client schedulingclient.SchedulingClientInterface, gridZones *gridzone.Table, startTime time.Time, maxDelay, jobDuration time.Duration, zones []schedulingclient.CloudZone) (*schedulingclient.ScheduleResponse, error) {
+	zones = slices.Clone(zones)
+	for i := range zones {
+		setGridZone(gridZones, &zones[i])
+	}
+
+	requestStart := time.Now()
+	scheduleResp, err := client.GetOptimalSchedule(ctx, startTime, maxDelay, jobDuration, zones)
+	if c, ok := client.(zoneObservingClient); !ok || !c.ObservesZones() {
+		observeForecastRequest(zones, time.Since(requestStart), err)
+	}
+	if err != nil {
+		return scheduleResp, err
+	}
+
+	for _, option := range []*schedulingclient.ScheduleOption{
+		&scheduleResp.Ideal, &scheduleResp.NaiveCase, &scheduleResp.MedianCase, &scheduleResp.WorstCase,
+	} {
+		setGridZone(gridZones, &option.Zone)
+	}
+	for i := range scheduleResp.Options {
+		setGridZone(gridZones, &scheduleResp.Options[i].Zone)
+	}
+	return scheduleResp, nil
+}
+
+// zoneObservingClient is implemented by scheduling clients that fetch the forecast of each zone
+// separately and report each fetch to the metrics themselves
+type zoneObservingClient interface {
+	ObservesZones() bool
+}
+
+// setGridZone sets the grid zone of a cloud zone from the table, unless it is already known
+func setGridZone(gridZones *gridzone.Table, zone *schedulingclient.CloudZone) {
+	if zone.GridZone != "" || zone.Region == "" {
+		return
+	}
+	if gridZone, ok := gridZones.Lookup(zone.Provider, zone.Region); ok {
+		zone.GridZone = gridZone
+	}
+}
+
+// cloudZone returns the zone of the detected cloud environment to request forecasts for,
+// defaulting to the configuration's default provider and region if not detected
+func cloudZone(ctx context.Context, env *cloudinfo.CloudEnvironment) schedulingclient.CloudZone {
+	if env == nil {
+		log.FromContext(ctx).Error(nil, "CloudEnvironment not initialized. Using the default cloud environment",
+			"provider", config.DefaultCloudProvider, "region", config.DefaultCloudRegion)
+		return schedulingclient.CloudZone{
+			Provider: config.DefaultCloudProvider,
+			Region:   config.DefaultCloudRegion,
+		}
+	}
+	return schedulingclient.CloudZone{
+		Provider: env.Provider,
+		Region:   env.Region,
+	}
+}
+
+// forecastSource returns the name recorded as the source of forecasts, defaulting to the scheduler API
+func (r *CarbonAwareJobReconciler) forecastSource() string {
+	if r.ForecastSource == "" {
+		return "carbon-aware-scheduler-api"
+	}
+	return r.ForecastSource
+}
+
+// statusCheckInterval returns how often the status of a running Job is checked
+func (r *CarbonAwareJobReconciler) statusCheckInterval() time.Duration {
+	if r.StatusCheckInterval > 0 {
+		return r.StatusCheckInterval
+	}
+	return defaultStatusCheckInterval
+}
+
+// jobDuration returns the expected run time of a CarbonAwareJob. Jobs admitted by the defaulting
+// webhook always set MaxDuration, others use the cluster default.
+func (r *CarbonAwareJobReconciler) jobDuration(carbonAwareJob *batchv1alpha1.CarbonAwareJob) time.Duration {
+	if carbonAwareJob.Spec.MaxDuration != nil && carbonAwareJob.Spec.MaxDuration.Duration > 0 {
+		return carbonAwareJob.Spec.MaxDuration.Duration
+	}
+	if r.DefaultMaxDuration > 0 {
+		return r.DefaultMaxDuration
+	}
+	return batchv1alpha1.DefaultMaxDuration
+}
+
+// schedulingWindow returns the range of allowed start times for a CarbonAwareJob.
+// The window opens at submission and closes after MaxDelay or at Deadline minus the
+// job duration, whichever comes first. An error is returned if the deadline cannot be met.
+func schedulingWindow(carbonAwareJob *batchv1alpha1.CarbonAwareJob, submissionTime time.Time, jobDuration time.Duration) (time.Time, time.Time, error) {
+	maxDelay := carbonAwareJob.Spec.MaxDelay.Duration
+	windowEnd := submissionTime.Add(maxDelay)
+
+	if deadline := carbonAwareJob.Spec.Deadline; deadline != nil {
+		latestStart := deadline.Add(-jobDuration)
+		if latestStart.Before(submissionTime) {
+			return time.Time{}, time.Time{}, fmt.Errorf(
+				"deadline %s cannot be met: a job of up to %s submitted at %s would have to start by %s",
+				deadline.UTC().Format(time.RFC3339), jobDuration, submissionTime.UTC().Format(time.RFC3339),
+				latestStart.UTC().Format(time.RFC3339))
+		}
+		if maxDelay <= 0 || latestStart.Before(windowEnd) {
+			windowEnd = latestStart
+		}
+	}
+
+	return submissionTime, windowEnd, nil
+}
+
 // handlePendingJob checks if it's time to create the underlying Job
-func (r *CarbonAwareJobReconciler) handlePendingJob(ctx context.Context, carbonAwareJob *batchv1alpha1.CarbonAwareJob) (ctrl.Result, error) {
+func (r *CarbonAwareJobReconciler) handlePendingJob(ctx context.Context, carbonAwareJob *batchv1alpha1.CarbonAwareJob, jobPolicy *policy.Policy) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 	logger.Info("Handling pending CarbonAwareJob", "name", carbonAwareJob.Name)
 
@@ -318,40 +717,345 @@ func (r *CarbonAwareJobReconciler) handlePendingJob(ctx context.Context, carbonA
 	scheduledTime := carbonAwareJob.Status.ScheduledTime.Time
 
 	if now.Before(scheduledTime) {
-		// Not time yet, requeue
+		if r.ReforecastInterval <= 0 {
+			// Not time yet, requeue
+			return ctrl.Result{RequeueAfter: time.Until(scheduledTime)}, nil
+		}
+
+		nextForecast := now
+		if carbonAwareJob.Status.LastForecastTime != nil {
+			nextForecast = carbonAwareJob.Status.LastForecastTime.Add(r.ReforecastInterval)
+		}
+		if !now.Before(nextForecast) {
+			return r.reevaluateSchedule(ctx, carbonAwareJob, jobPolicy)
+		}
+
+		// Not time yet, requeue at the start time or the next re-evaluation, whichever comes first
+		if nextForecast.Before(scheduledTime) {
+			return ctrl.Result{RequeueAfter: time.Until(nextForecast)}, nil
+		}
 		return ctrl.Result{RequeueAfter: time.Until(scheduledTime)}, nil
 	}
 
+	// Past the end of the window the job starts even if the concurrency limit is reached
+	if windowEnd := r.windowEnd(carbonAwareJob); now.Before(windowEnd) {
+		limit, err := r.concurrencyLimit(ctx, carbonAwareJob, jobPolicy)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if limit.saturated() {
+			return r.waitForSlot(ctx, carbonAwareJob, limit, windowEnd)
+		}
+	}
+
 	// Time to create the job
+	jobPlacement, err := r.restrictPlacement(ctx, carbonAwareJob, jobPolicy)
+	if err != nil {
+		logger.Error(err, "Failed to resolve CarbonAwareJob placement")
+		return ctrl.Result{}, err
+	}
+
+	// A Job created suspended up front only needs to be resumed
+	if carbonAwareJob.Status.JobName != "" {
+		return r.resumeSuspendedJob(ctx, carbonAwareJob, jobPlacement)
+	}
+
+	job, err := r.createJob(ctx, carbonAwareJob, jobPlacement, false)
+	if err != nil {
+		return r.startFailed(ctx, carbonAwareJob, fmt.Sprintf("Failed to create Job: %v", err), err)
+	}
+	message := fmt.Sprintf("Created Job %s", job.Name)
+	r.Recorder.Event(carbonAwareJob, corev1.EventTypeNormal, ReasonJobCreated, message)
+	setCondition(carbonAwareJob, ConditionJobCreated, metav1.ConditionTrue, ReasonJobCreated, message)
+	setCondition(carbonAwareJob, ConditionWaiting, metav1.ConditionFalse, ReasonJobCreated, message)
+
+	// Update status
+	carbonAwareJob.Status.JobName = job.Name
+	carbonAwareJob.Status.SchedulingState = string(SchedulingStateScheduled)
+
+	// Update the status
+	if err := r.Status().Update(ctx, carbonAwareJob); err != nil {
+		logger.Error(err, "Failed to update CarbonAwareJob status")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: r.statusCheckInterval()}, nil // Check job status periodically
+}
+
+// createJob creates the Job of a CarbonAwareJob, pinned to the region the scheduler chose or to
+// any allowed region if there is no decision yet or it was made by the fallback policy
+func (r *CarbonAwareJobReconciler) createJob(ctx context.Context, carbonAwareJob *batchv1alpha1.CarbonAwareJob, jobPlacement *placement, suspend bool) (*batchv1.Job, error) {
+	logger := log.FromContext(ctx)
+
 	job, err := r.constructJobFromTemplate(carbonAwareJob)
 	if err != nil {
 		logger.Error(err, "Failed to construct Job from template")
-		return ctrl.Result{}, err
+		return nil, err
+	}
+	if suspend {
+		job.Spec.Suspend = &suspend
 	}
+	addRequiredNodeAffinity(&job.Spec.Template.Spec, jobPlacement.nodeSelectorTerms(decisionRegion(carbonAwareJob)))
 
 	// Set the owner reference
 	if err := controllerutil.SetControllerReference(carbonAwareJob, job, r.Scheme); err != nil {
 		logger.Error(err, "Failed to set controller reference")
-		return ctrl.Result{}, err
+		return nil, err
 	}
 
 	// Create the job
 	if err := r.Create(ctx, job); err != nil {
 		logger.Error(err, "Failed to create Job")
-		return ctrl.Result{}, err
+		r.Recorder.Event(carbonAwareJob, corev1.EventTypeWarning, ReasonJobCreateFailed,
+			fmt.Sprintf("Failed to create Job: %v", err))
+		return nil, err
 	}
+	return job, nil
+}
 
-	// Update status
+// createSuspendedJob creates the Job of a CarbonAwareJob in Suspended mode with spec.suspend set
+// and records it in status. The CarbonAwareJob stays in its scheduling state until the Job is resumed.
+func (r *CarbonAwareJobReconciler) createSuspendedJob(ctx context.Context, carbonAwareJob *batchv1alpha1.CarbonAwareJob, jobPlacement *placement) error {
+	logger := log.FromContext(ctx)
+
+	job, err := r.createJob(ctx, carbonAwareJob, jobPlacement, true)
+	if err != nil {
+		return err
+	}
+	message := fmt.Sprintf("Created suspended Job %s", job.Name)
+	r.Recorder.Event(carbonAwareJob, corev1.EventTypeNormal, ReasonJobCreated, message)
+	setCondition(carbonAwareJob, ConditionJobCreated, metav1.ConditionTrue, ReasonJobCreated, message)
+
+	// Record the Job right away so that a failed forecast does not create it twice. The update
+	// returns the stored spec, so the policy defaults merged into it are restored afterwards
 	carbonAwareJob.Status.JobName = job.Name
+	spec := carbonAwareJob.Spec
+	if err := r.Status().Update(ctx, carbonAwareJob); err != nil {
+		logger.Error(err, "Failed to update CarbonAwareJob status")
+		return err
+	}
+	carbonAwareJob.Spec = spec
+	return nil
+}
+
+// resumeSuspendedJob releases the suspended Job of a CarbonAwareJob at its scheduled time. The
+// Job is pinned to the region chosen since it was created, which Kubernetes allows as long as
+// the Job has never run.
+func (r *CarbonAwareJobReconciler) resumeSuspendedJob(ctx context.Context, carbonAwareJob *batchv1alpha1.CarbonAwareJob, jobPlacement *placement) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	job := &batchv1.Job{}
+	jobNamespacedName := types.NamespacedName{
+		Namespace: carbonAwareJob.Namespace,
+		Name:      carbonAwareJob.Status.JobName,
+	}
+	if err := r.Get(ctx, jobNamespacedName, job); err != nil {
+		if errors.IsNotFound(err) {
+			// Let handleScheduledJob report the missing Job
+			carbonAwareJob.Status.SchedulingState = string(SchedulingStateScheduled)
+			if err := r.Status().Update(ctx, carbonAwareJob); err != nil {
+				logger.Error(err, "Failed to update CarbonAwareJob status")
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{Requeue: true}, nil
+		}
+		logger.Error(err, "Failed to get Job")
+		return ctrl.Result{}, err
+	}
+
+	if job.Status.StartTime == nil {
+		podSpec := carbonAwareJob.Spec.Template.Spec.Template.Spec.DeepCopy()
+		addRequiredNodeAffinity(podSpec, jobPlacement.nodeSelectorTerms(decisionRegion(carbonAwareJob)))
+		job.Spec.Template.Spec.Affinity = podSpec.Affinity
+	}
+	setScheduleAnnotations(job, carbonAwareJob)
+	suspend := false
+	job.Spec.Suspend = &suspend
+	if err := r.Update(ctx, job); err != nil {
+		logger.Error(err, "Failed to resume Job")
+		return r.startFailed(ctx, carbonAwareJob, fmt.Sprintf("Failed to resume Job %s: %v", job.Name, err), err)
+	}
+	message := fmt.Sprintf("Resumed Job %s", job.Name)
+	r.Recorder.Event(carbonAwareJob, corev1.EventTypeNormal, ReasonJobResumed, message)
+	setCondition(carbonAwareJob, ConditionWaiting, metav1.ConditionFalse, ReasonJobResumed, message)
+
 	carbonAwareJob.Status.SchedulingState = string(SchedulingStateScheduled)
+	if err := r.Status().Update(ctx, carbonAwareJob); err != nil {
+		logger.Error(err, "Failed to update CarbonAwareJob status")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: r.statusCheckInterval()}, nil // Check job status periodically
+}
+
+// decisionRegion returns the region of the scheduling decision of a CarbonAwareJob, or an empty
+// string if none was chosen
+func decisionRegion(carbonAwareJob *batchv1alpha1.CarbonAwareJob) string {
+	if carbonAwareJob.Status.SchedulingDecision == nil {
+		return ""
+	}
+	return carbonAwareJob.Status.SchedulingDecision.Region
+}
+
+// reevaluateSchedule re-queries the forecast for the remainder of the scheduling window
+// and moves ScheduledTime if a better slot has appeared. The original window end is never exceeded.
+func (r *CarbonAwareJobReconciler) reevaluateSchedule(ctx context.Context, carbonAwareJob *batchv1alpha1.CarbonAwareJob, jobPolicy *policy.Policy) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	logger.Info("Re-evaluating forecast for pending CarbonAwareJob", "name", carbonAwareJob.Name)
+
+	now := time.Now()
+	jobDuration := r.jobDuration(carbonAwareJob)
+	windowEnd := r.windowEnd(carbonAwareJob)
+
+	forecastTime := metav1.NewTime(now)
+	carbonAwareJob.Status.LastForecastTime = &forecastTime
+
+	if windowEnd.After(now) {
+		jobPlacement, err := r.restrictPlacement(ctx, carbonAwareJob, jobPolicy)
+		if err != nil {
+			logger.Error(err, "Failed to resolve CarbonAwareJob placement")
+			return ctrl.Result{}, err
+		}
+		limit, err := r.concurrencyLimit(ctx, carbonAwareJob, jobPolicy)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		scheduleResp, err := getOptimalSchedule(ctx, r.SchedulingClient, r.GridZones, now, windowEnd.Sub(now), jobDuration, jobPlacement.zones)
+		if err != nil {
+			// Keep the current schedule, it is still the best we know of
+			logger.Error(err, "Failed to re-evaluate forecast, keeping current schedule")
+		} else {
+			if applyRevisedSchedule(carbonAwareJob, scheduleResp, jobPolicy, limit, jobDuration, now, windowEnd) {
+				revision := carbonAwareJob.Status.ScheduleRevisions[len(carbonAwareJob.Status.ScheduleRevisions)-1]
+				r.Recorder.Event(carbonAwareJob, corev1.EventTypeNormal, ReasonScheduleRevised, revision.Reason)
+				setCondition(carbonAwareJob, ConditionWaiting, metav1.ConditionTrue, ReasonScheduleRevised,
+					fmt.Sprintf("Waiting until %s", revision.NewTime.UTC().Format(time.RFC3339)))
+			}
+			r.shared().lowHours.record(scheduleResp.Ideal.Zone, scheduleResp.Ideal.Time)
+		}
+	}
 
-	// Update the status
 	if err := r.Status().Update(ctx, carbonAwareJob); err != nil {
 		logger.Error(err, "Failed to update CarbonAwareJob status")
 		return ctrl.Result{}, err
 	}
 
-	return ctrl.Result{RequeueAfter: 30 * time.Second}, nil // Check job status periodically
+	scheduledTime := carbonAwareJob.Status.ScheduledTime.Time
+	if nextForecast := now.Add(r.ReforecastInterval); nextForecast.Before(scheduledTime) {
+		return ctrl.Result{RequeueAfter: time.Until(nextForecast)}, nil
+	}
+	return ctrl.Result{RequeueAfter: time.Until(scheduledTime)}, nil
+}
+
+// windowEnd returns the latest start time of a scheduled CarbonAwareJob. Jobs created before
+// the window was recorded in status fall back to the spec.
+func (r *CarbonAwareJobReconciler) windowEnd(carbonAwareJob *batchv1alpha1.CarbonAwareJob) time.Time {
+	if carbonAwareJob.Status.SchedulingWindow != nil {
+		return carbonAwareJob.Status.SchedulingWindow.End.Time
+	}
+	_, windowEnd, err := schedulingWindow(carbonAwareJob, carbonAwareJob.Status.SubmissionTime.Time, r.jobDuration(carbonAwareJob))
+	if err != nil {
+		return carbonAwareJob.Status.ScheduledTime.Time
+	}
+	return windowEnd
+}
+
+// applyRevisedSchedule moves ScheduledTime and the chosen region to the option a fresh forecast
+// yields under the job's strategy, policy and concurrency limit, clamped to [now, windowEnd], and
+// records the change in status. It reports whether the schedule changed.
+func applyRevisedSchedule(carbonAwareJob *batchv1alpha1.CarbonAwareJob, scheduleResp *schedulingclient.ScheduleResponse, jobPolicy *policy.Policy, limit *concurrencyLimit, jobDuration time.Duration, now, windowEnd time.Time) bool {
+	chosen, selectReason := selectOption(carbonAwareJob, scheduleResp)
+	chosen, constrainedReason := applyPolicyConstraints(jobPolicy, scheduleResp, chosen, selectReason, windowEnd)
+	chosen, constrainedReason = spreadOption(limit, jobPolicy, scheduleResp, chosen, constrainedReason, windowEnd)
+	carbonAwareJob.Status.Alternatives = scheduleAlternatives(limit, jobPolicy, scheduleResp, chosen, jobDuration, windowEnd)
+	newTime := chosen.Time
+	if newTime.After(windowEnd) {
+		newTime = windowEnd
+	}
+	if newTime.Before(now) {
+		newTime = now
+	}
+
+	// A fallback decision has no region, so it keeps allowing every region
+	newRegion := chosen.Zone.Region
+	decision := carbonAwareJob.Status.SchedulingDecision
+	regionChanged := decision != nil && decision.Region != "" && decision.Region != newRegion
+
+	previousTime := carbonAwareJob.Status.ScheduledTime.Time
+	shift := newTime.Sub(previousTime)
+	if !regionChanged && shift < scheduleRevisionTolerance && shift > -scheduleRevisionTolerance {
+		return false
+	}
+
+	optimalIntensity := formatIntensity(chosen.CO2Intensity)
+	previousIntensity, known := statusIntensity(carbonAwareJob.Status.CarbonIntensityGramsPerKWh, carbonAwareJob.Status.CarbonIntensity)
+	reason := revisionReason(newTime, newRegion, regionChanged, chosen.CO2Intensity, previousIntensity, known)
+	// Name the policy or concurrency limit that moved the job away from the strategy's choice
+	if cause := strings.TrimPrefix(strings.TrimPrefix(constrainedReason, selectReason), ". "); cause != "" {
+		reason = fmt.Sprintf("%s. %s", reason, cause)
+	}
+
+	addScheduleRevision(carbonAwareJob, now, newTime, reason)
+
+	scheduledTime := metav1.NewTime(newTime)
+	carbonAwareJob.Status.ScheduledTime = &scheduledTime
+	carbonAwareJob.Status.CarbonIntensity = optimalIntensity
+	carbonAwareJob.Status.CarbonIntensityGramsPerKWh = decimalQuantity(chosen.CO2Intensity)
+	// Savings stay relative to the cases the job was first scheduled against
+	carbonAwareJob.Status.CarbonSavings = decisionSavings(decision, chosen.CO2Intensity)
+	if carbonAwareJob.Status.CarbonSavings == nil {
+		carbonAwareJob.Status.CarbonSavings = carbonSavingsFor(scheduleResp, chosen.CO2Intensity)
+	}
+	if decision != nil {
+		decision.OptimalTime = &scheduledTime
+		decision.OptimalIntensity = optimalIntensity
+		decision.OptimalIntensityGramsPerKWh = decimalQuantity(chosen.CO2Intensity)
+		decision.DecisionReason = reason
+		decision.Strategy = string(schedulingStrategy(carbonAwareJob))
+		if regionChanged {
+			decision.Region = newRegion
+		}
+		if decision.Region == newRegion {
+			decision.GridZone = chosen.Zone.GridZone
+		}
+	}
+	return true
+}
+
+// revisionReason describes a move of the scheduled time to newTime by how the forecast intensity
+// changed compared to the previous scheduled time, if that is known
+func revisionReason(newTime time.Time, region string, regionChanged bool, intensity, previousIntensity float64, previousKnown bool) string {
+	slot := newTime.UTC().Format(time.RFC3339)
+	if regionChanged {
+		slot = fmt.Sprintf("%s in %s", slot, region)
+	}
+	switch {
+	case !previousKnown:
+		return fmt.Sprintf("Updated forecast moved the start to %s (%s)", slot, formatIntensity(intensity))
+	case intensity < previousIntensity:
+		return fmt.Sprintf("Updated forecast found a greener slot at %s (%s, down from %s)",
+			slot, formatIntensity(intensity), formatIntensity(previousIntensity))
+	case intensity > previousIntensity:
+		return fmt.Sprintf("Updated forecast moved the start to %s (%s, up from %s)",
+			slot, formatIntensity(intensity), formatIntensity(previousIntensity))
+	default:
+		return fmt.Sprintf("Updated forecast moved the start to %s at the same intensity (%s)", slot, formatIntensity(intensity))
+	}
+}
+
+// addScheduleRevision records a change of ScheduledTime to newTime in status, keeping the most
+// recent maxScheduleRevisions
+func addScheduleRevision(carbonAwareJob *batchv1alpha1.CarbonAwareJob, now, newTime time.Time, reason string) {
+	revision := batchv1alpha1.ScheduleRevision{
+		RevisionTime: metav1.NewTime(now),
+		PreviousTime: *carbonAwareJob.Status.ScheduledTime.DeepCopy(),
+		NewTime:      metav1.NewTime(newTime),
+		Reason:       reason,
+	}
+	carbonAwareJob.Status.ScheduleRevisions = append(carbonAwareJob.Status.ScheduleRevisions, revision)
+	if len(carbonAwareJob.Status.ScheduleRevisions) > maxScheduleRevisions {
+		carbonAwareJob.Status.ScheduleRevisions = carbonAwareJob.Status.ScheduleRevisions[len(carbonAwareJob.Status.ScheduleRevisions)-maxScheduleRevisions:]
+	}
 }
 
 // handleScheduledJob checks the status of the underlying Job
@@ -369,6 +1073,9 @@ func (r *CarbonAwareJobReconciler) handleScheduledJob(ctx context.Context, carbo
 	if err := r.Get(ctx, jobNamespacedName, job); err != nil {
 		if errors.IsNotFound(err) {
 			// Job was deleted, update status
+			message := fmt.Sprintf("Job %s no longer exists", carbonAwareJob.Status.JobName)
+			r.Recorder.Event(carbonAwareJob, corev1.EventTypeWarning, ReasonJobMissing, message)
+			setCondition(carbonAwareJob, ConditionFailed, metav1.ConditionTrue, ReasonJobMissing, message)
 			carbonAwareJob.Status.SchedulingState = string(SchedulingStateFailed)
 			carbonAwareJob.Status.JobStatus = nil
 			if err := r.Status().Update(ctx, carbonAwareJob); err != nil {
@@ -381,10 +1088,20 @@ func (r *CarbonAwareJobReconciler) handleScheduledJob(ctx context.Context, carbo
 		return ctrl.Result{}, err
 	}
 
+	// A Job whose pods cannot be created or scheduled is started again at the next alternative
+	now := time.Now()
+	observeReady(carbonAwareJob, job, now)
+	if r.startTimedOut(carbonAwareJob, job, now) {
+		if i := nextAlternative(carbonAwareJob, now); i >= 0 {
+			return r.restartAtAlternative(ctx, carbonAwareJob, job, i, now)
+		}
+	}
+
 	// Update job status
 	carbonAwareJob.Status.JobStatus = &job.Status
 
 	// Check job status
+	previousState := carbonAwareJob.Status.SchedulingState
 	if job.Status.Active > 0 {
 		carbonAwareJob.Status.SchedulingState = string(SchedulingStateRunning)
 	} else if job.Status.Succeeded > 0 {
@@ -393,15 +1110,50 @@ func (r *CarbonAwareJobReconciler) handleScheduledJob(ctx context.Context, carbo
 		carbonAwareJob.Status.SchedulingState = string(SchedulingStateFailed)
 	}
 
+	var eventType, reason, message string
+	if carbonAwareJob.Status.SchedulingState != previousState {
+		switch carbonAwareJob.Status.SchedulingState {
+		case string(SchedulingStateCompleted):
+			eventType, reason, message = corev1.EventTypeNormal, ReasonJobCompleted, fmt.Sprintf("Job %s completed", job.Name)
+			setCondition(carbonAwareJob, ConditionComplete, metav1.ConditionTrue, reason, message)
+		case string(SchedulingStateFailed):
+			eventType, reason, message = corev1.EventTypeWarning, ReasonJobFailed, fmt.Sprintf("Job %s failed", job.Name)
+			setCondition(carbonAwareJob, ConditionFailed, metav1.ConditionTrue, reason, message)
+		}
+	}
+
+	// Account the emissions of the Job once it has finished
+	finished := carbonAwareJob.Status.SchedulingState == string(SchedulingStateCompleted) ||
+		carbonAwareJob.Status.SchedulingState == string(SchedulingStateFailed)
+	accounted := false
+	if finished && carbonAwareJob.Status.Emissions == nil {
+		carbonAwareJob.Status.Emissions = r.estimateEmissions(ctx, carbonAwareJob, job)
+		accounted = carbonAwareJob.Status.Emissions != nil
+	}
+
 	// Update the status
 	if err := r.Status().Update(ctx, carbonAwareJob); err != nil {
 		logger.Error(err, "Failed to update CarbonAwareJob status")
 		return ctrl.Result{}, err
 	}
 
+	if reason != "" {
+		r.Recorder.Event(carbonAwareJob, eventType, reason, message)
+	}
+	// Emissions are counted once per CarbonAwareJob, however often its Job was restarted
+	if accounted {
+		observeEmissions(carbonAwareJob)
+	}
+	if emissions := carbonAwareJob.Status.Emissions; accounted && emissions.EmittedGrams != nil {
+		r.Recorder.Event(carbonAwareJob, corev1.EventTypeNormal, ReasonEmissionsEstimated, fmt.Sprintf(
+			"Job used an estimated %.4f kWh and emitted %.1f gCO2eq at %.2f gCO2eq/kWh (%s)",
+			emissions.EnergyKWh.AsApproximateFloat64(), emissions.EmittedGrams.AsApproximateFloat64(),
+			emissions.IntensityGramsPerKWh.AsApproximateFloat64(), emissions.IntensitySource))
+	}
+
 	// If job is still running, requeue to check again later
 	if carbonAwareJob.Status.SchedulingState == string(SchedulingStateRunning) {
-		return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+		return ctrl.Result{RequeueAfter: r.statusCheckInterval()}, nil
 	}
 
 	// Job is in a terminal state, no need to requeue
@@ -424,15 +1176,14 @@ func (r *CarbonAwareJobReconciler) constructJobFromTemplate(carbonAwareJob *batc
 				"app.kubernetes.io/managed-by": "carbon-aware-operator",
 			},
 			Annotations: map[string]string{
-				"carbonaware.dev/carbon-intensity":     carbonAwareJob.Status.CarbonIntensity,
-				"carbonaware.dev/scheduled-time":       carbonAwareJob.Status.ScheduledTime.Format(time.RFC3339),
-				"carbonaware.dev/carbon-savings-pct":   carbonAwareJob.Status.CarbonSavings.VsNaiveCase,
 				"carbonaware.dev/parent-resource-name": carbonAwareJob.Name,
 				"carbonaware.dev/parent-resource-uid":  string(carbonAwareJob.UID),
 			},
 		},
-		Spec: carbonAwareJob.Spec.Template.Spec,
+		// Deep copy so that placement affinity is not written back into the template
+		Spec: *carbonAwareJob.Spec.Template.Spec.DeepCopy(),
 	}
+	setScheduleAnnotations(job, carbonAwareJob)
 
 	// Copy any labels and annotations from the template metadata
 	if carbonAwareJob.Spec.Template.Metadata.Labels != nil {
@@ -450,44 +1201,138 @@ func (r *CarbonAwareJobReconciler) constructJobFromTemplate(carbonAwareJob *batc
 	return job, nil
 }
 
-// SetupWithManager sets up the controller with the Manager.
-func (r *CarbonAwareJobReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	ctx := context.Background()
-	// Initialize the scheduling client
-	schedulerURL := os.Getenv("CARBON_AWARE_SCHEDULER_URL")
-	if schedulerURL == "" {
-		schedulerURL = "http://carbon-aware-scheduler:8080" // Default URL if not specified
-	}
-	r.SchedulingClient = schedulingclient.NewSchedulingClient(schedulerURL)
-
-	// Check if cloud environment override is enabled
-	if os.Getenv("CLOUD_ENVIRONMENT_OVERRIDE") == "true" {
-		provider := os.Getenv("CLOUD_PROVIDER")
-		region := os.Getenv("CLOUD_REGION")
-		
-		ctrl.Log.Info("Using override cloud environment", "provider", provider, "region", region)
-		r.CloudEnvironment = &cloudinfo.CloudEnvironment{
-			Provider: provider,
-			Region:   region,
-		}
-	} else {
-		// Run introspection to auto-detect cloud environment
-		ctrl.Log.Info("Attempting to auto-detect cloud environment")
-		env, err := cloudinfo.DetectCloudEnvironment(ctx, r.Client)
+// setScheduleAnnotations records the scheduling decision of a CarbonAwareJob on its Job or a gated
+// Pod. A Job created suspended before the forecast is annotated when it is resumed.
+func setScheduleAnnotations(obj metav1.Object, carbonAwareJob *batchv1alpha1.CarbonAwareJob) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	if carbonAwareJob.Status.ScheduledTime != nil {
+		annotations[annotationCarbonIntensity] = carbonAwareJob.Status.CarbonIntensity
+		annotations[annotationScheduledTime] = carbonAwareJob.Status.ScheduledTime.Format(time.RFC3339)
+	}
+	if carbonAwareJob.Status.CarbonSavings != nil {
+		annotations["carbonaware.dev/carbon-savings-pct"] = carbonAwareJob.Status.CarbonSavings.VsNaiveCase
+	}
+	if region := decisionRegion(carbonAwareJob); region != "" {
+		annotations["carbonaware.dev/region"] = region
+	}
+	if decision := carbonAwareJob.Status.SchedulingDecision; decision != nil && decision.GridZone != "" {
+		annotations[annotationGridZone] = decision.GridZone
+	}
+	obj.SetAnnotations(annotations)
+}
+
+// ApplyConfig updates the settings that can change while the operator is running, and rebuilds
+// the scheduling client for the configured forecast provider. It waits for the reconciliation in
+// progress to finish.
+func (r *CarbonAwareJobReconciler) ApplyConfig(cfg *config.OperatorConfig) {
+	if err := r.applyConfig(cfg); err != nil {
+		ctrl.Log.Error(err, "Failed to create the forecast client, keeping the previous one")
+	}
+}
+
+// applyConfig applies a configuration under the settings lock. The other settings are applied
+// even if the scheduling client cannot be created.
+func (r *CarbonAwareJobReconciler) applyConfig(cfg *config.OperatorConfig) error {
+	r.settings.Lock()
+	defer r.settings.Unlock()
+	r.DefaultMaxDuration = cfg.Jobs.DefaultMaxDuration.Duration
+	r.ReforecastInterval = cfg.Forecast.ReevaluationInterval.Duration
+	r.ForecastRetryInterval = cfg.Forecast.RetryInterval.Duration
+	r.ForecastRetryCutoff = cfg.Forecast.RetryCutoff.Duration
+	r.StatusCheckInterval = cfg.Jobs.StatusCheckInterval.Duration
+	r.StartTimeout = cfg.Jobs.StartTimeout.Duration
+	r.PowerModel = cfg.Emissions
+	if r.GridZones != nil {
+		r.GridZones.SetOverrides(cfg.Forecast.GridZones)
+	}
+	r.CloudEnvironment, r.OverrideCloudEnvironment = configuredCloudEnvironment(cfg)
+
+	schedulingClient, source, err := newSchedulingClient(cfg)
+	if err != nil {
+		return err
+	}
+	r.SchedulingClient = schedulingClient
+	r.ForecastSource = source
+	return nil
+}
+
+// newSchedulingClient creates the scheduling client of the configured forecast provider and
+// returns it with the provider's name, which is empty for the scheduler API
+func newSchedulingClient(cfg *config.OperatorConfig) (schedulingclient.SchedulingClientInterface, string, error) {
+	// Use the carbon-aware scheduler API unless another forecast provider is configured
+	switch providerName := cfg.Forecast.Provider; providerName {
+	case "", forecast.ProviderScheduler:
+		schedulerClient := schedulingclient.NewSchedulingClientWithRetry(cfg.Scheduler.URL, schedulingclient.RetryConfig{
+			MaxRetries:     cfg.Scheduler.MaxRetries,
+			InitialBackoff: cfg.Scheduler.InitialBackoff.Duration,
+			MaxBackoff:     cfg.Scheduler.MaxBackoff.Duration,
+		})
+		schedulerClient.NumOptions = cfg.Forecast.NumOptions
+		ctrl.Log.Info("Using the scheduler API", "url", cfg.Scheduler.URL)
+		return schedulerClient, "", nil
+	default:
+		// Credentials come from the environment so that they stay out of the config file
+		provider, err := forecast.NewProvider(forecast.Config{
+			Name:     providerName,
+			BaseURL:  cfg.Forecast.URL,
+			APIToken: os.Getenv("FORECAST_API_TOKEN"),
+			Username: os.Getenv("FORECAST_API_USERNAME"),
+			Password: os.Getenv("FORECAST_API_PASSWORD"),
+			Path:     cfg.Forecast.StaticPath,
+		})
 		if err != nil {
-			ctrl.Log.Error(err, "failed to detect cloud environment")
-			// Set default values if detection fails
-			r.CloudEnvironment = &cloudinfo.CloudEnvironment{
-				Provider: "aws",
-				Region:   "us-east-1",
-			}
-			ctrl.Log.Info("Using default cloud environment", "provider", r.CloudEnvironment.Provider, "region", r.CloudEnvironment.Region)
-		} else {
-			ctrl.Log.Info("Detected cloud environment", "provider", env.Provider, "region", env.Region)
-			r.CloudEnvironment = env
+			return nil, "", err
 		}
+		ctrl.Log.Info("Using forecast provider", "provider", providerName)
+		forecastClient := forecast.NewClient(provider, cfg.Forecast.Locations)
+		forecastClient.NumOptions = cfg.Forecast.NumOptions
+		forecastClient.ObserveZone = observeForecastZone
+		return forecastClient, providerName, nil
+	}
+}
+
+// configuredCloudEnvironment returns the cloud environment of a configuration and whether it
+// overrides the environment of the cluster's nodes. Otherwise forecasts are requested for the
+// regions of the nodes each Job can run on, and the configured environment is only used when
+// no such node reports a region.
+func configuredCloudEnvironment(cfg *config.OperatorConfig) (*cloudinfo.CloudEnvironment, bool) {
+	env := &cloudinfo.CloudEnvironment{
+		Provider: cfg.CloudEnvironment.Provider,
+		Region:   cfg.CloudEnvironment.Region,
+	}
+	if cfg.CloudEnvironment.Override {
+		ctrl.Log.Info("Using override cloud environment", "provider", env.Provider, "region", env.Region)
+	} else {
+		ctrl.Log.Info("Using the cloud environment of the cluster's nodes",
+			"defaultProvider", env.Provider, "defaultRegion", env.Region)
 	}
+	return env, cfg.CloudEnvironment.Override
+}
 
+// SetupWithManager sets up the controller with the Manager.
+func (r *CarbonAwareJobReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.GridZones == nil {
+		r.GridZones = gridzone.NewTable(nil)
+	}
+	cfg := config.Defaults()
+	if r.Config != nil {
+		cfg = r.Config.Current()
+		r.Config.Subscribe(r.ApplyConfig)
+	}
+	// A forecast client that cannot be created at startup fails the operator
+	if err := r.applyConfig(cfg); err != nil {
+		return err
+	}
+
+	if r.Nodes == nil {
+		r.Nodes = cloudinfo.NewNodeInventory()
+		if err := r.Nodes.SetupWithManager(mgr); err != nil {
+			return err
+		}
+	}
 
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&batchv1alpha1.CarbonAwareJob{}).
@@ -17,26 +17,41 @@ limitations under the License.
 package controller
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
-	"os"
+	"reflect"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	batchv1alpha1 "github.com/carbon-aware-kube/operator/api/v1alpha1"
 	schedulingclient "github.com/carbon-aware-kube/operator/internal/client"
+	"github.com/carbon-aware-kube/operator/internal/config"
+	"github.com/carbon-aware-kube/operator/internal/forecast"
+	"github.com/carbon-aware-kube/operator/internal/gridzone"
+	cloudinfo "github.com/carbon-aware-kube/operator/internal/introspection"
+	"github.com/carbon-aware-kube/operator/internal/policy"
 )
 
 var _ = Describe("CarbonAwareJob Controller", func() {
@@ -51,6 +66,7 @@ var _ = Describe("CarbonAwareJob Controller", func() {
 		namespacedName types.NamespacedName
 		testNS         *corev1.Namespace
 		reconciler     *CarbonAwareJobReconciler
+		recorder       *record.FakeRecorder
 		schedulingErr  error
 	)
 
@@ -137,17 +153,18 @@ var _ = Describe("CarbonAwareJob Controller", func() {
 			w.WriteHeader(http.StatusNotFound)
 		}))
 
-		// Create a test server with the handler
-		// Set the environment variable for the scheduler URL to point to our test server
-		os.Setenv("CARBON_AWARE_SCHEDULER_URL", mockServer.URL)
-
 		// Create a real scheduling client that will connect to our test server
-		schedulingClient := schedulingclient.NewSchedulingClient(mockServer.URL)
+		schedulingClient := schedulingclient.NewSchedulingClientWithRetry(mockServer.URL, schedulingclient.RetryConfig{
+			MaxRetries:     1,
+			InitialBackoff: 10 * time.Millisecond,
+		})
 
 		// Create a CarbonAwareJobReconciler with the real client connected to our mock server
+		recorder = record.NewFakeRecorder(100)
 		reconciler = &CarbonAwareJobReconciler{
 			Client:           k8sClient,
 			Scheme:           k8sClient.Scheme(),
+			Recorder:         recorder,
 			SchedulingClient: schedulingClient,
 		}
 
@@ -204,7 +221,6 @@ var _ = Describe("CarbonAwareJob Controller", func() {
 						},
 					},
 					MaxDelay: metav1.Duration{Duration: time.Hour},
-					Location: "gcp:us-west2",
 				},
 			}
 
@@ -293,6 +309,15 @@ var _ = Describe("CarbonAwareJob Controller", func() {
 				}
 				return createdJob.Status.SchedulingDecision != nil
 			}, time.Second*10, time.Millisecond*250).Should(BeTrue())
+
+			By("Checking that intensities and savings are reported as numbers")
+			decision := createdJob.Status.SchedulingDecision
+			Expect(decision.OptimalIntensityGramsPerKWh).NotTo(BeNil())
+			Expect(decision.ImmediateIntensityGramsPerKWh).NotTo(BeNil())
+			Expect(decision.WorstCaseIntensityGramsPerKWh).NotTo(BeNil())
+			Expect(createdJob.Status.CarbonIntensityGramsPerKWh).NotTo(BeNil())
+			Expect(createdJob.Status.CarbonIntensityGramsPerKWh.Cmp(*decision.OptimalIntensityGramsPerKWh)).To(BeZero())
+			Expect(createdJob.Status.CarbonSavings.VsNaiveCasePercent).NotTo(BeNil())
 		})
 	})
 
@@ -302,6 +327,9 @@ var _ = Describe("CarbonAwareJob Controller", func() {
 			By("Setting up the mock server to fail")
 			schedulingErr = errors.New("API unavailable")
 
+			By("Configuring a retry cutoff that has already passed")
+			reconciler.ForecastRetryCutoff = 2 * time.Hour
+
 			By("Creating a new CarbonAwareJob")
 			carbonAwareJob := &batchv1alpha1.CarbonAwareJob{
 				ObjectMeta: metav1.ObjectMeta{
@@ -310,7 +338,6 @@ var _ = Describe("CarbonAwareJob Controller", func() {
 				},
 				Spec: batchv1alpha1.CarbonAwareJobSpec{
 					MaxDelay: metav1.Duration{Duration: 1 * time.Hour},
-					Location: "gcp:us-west2",
 					Template: batchv1alpha1.JobTemplateSpec{
 						Spec: batchv1.JobSpec{
 							Template: corev1.PodTemplateSpec{
@@ -351,6 +378,16 @@ var _ = Describe("CarbonAwareJob Controller", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(createdJob.Status.SchedulingDecision).NotTo(BeNil())
 			Expect(createdJob.Status.SchedulingDecision.ForecastSource).To(Equal("fallback"))
+			Expect(recorder.Events).To(Receive(HavePrefix("Warning " + ReasonFallbackApplied)))
+
+			By("Checking that the conditions report the fallback")
+			degraded := meta.FindStatusCondition(createdJob.Status.Conditions, ConditionDegraded)
+			Expect(degraded).NotTo(BeNil())
+			Expect(degraded.Status).To(Equal(metav1.ConditionTrue))
+			Expect(degraded.Reason).To(Equal(ReasonFallbackApplied))
+			Expect(degraded.ObservedGeneration).To(Equal(createdJob.Generation))
+			Expect(meta.IsStatusConditionFalse(createdJob.Status.Conditions, ConditionForecastObtained)).To(BeTrue())
+			Expect(meta.IsStatusConditionTrue(createdJob.Status.Conditions, ConditionWaiting)).To(BeTrue())
 
 			By("Checking that the carbon intensity is marked as unknown")
 			Eventually(func() string {
@@ -363,6 +400,77 @@ var _ = Describe("CarbonAwareJob Controller", func() {
 		})
 	})
 
+	// Test case 2b: When the scheduling API fails before the retry cutoff
+	Context("When the scheduling API fails before the retry cutoff", func() {
+		It("Should wait in ForecastUnavailable and then apply the fallback policy", func() {
+			By("Setting up the mock server to fail")
+			schedulingErr = errors.New("API unavailable")
+			reconciler.ForecastRetryInterval = 5 * time.Minute
+			reconciler.ForecastRetryCutoff = 15 * time.Minute
+
+			By("Creating a CarbonAwareJob that falls back to the end of its window")
+			carbonAwareJob := &batchv1alpha1.CarbonAwareJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       jobName,
+					Namespace:  testNS.Name,
+					Finalizers: []string{CarbonAwareJobFinalizer},
+				},
+				Spec: batchv1alpha1.CarbonAwareJobSpec{
+					MaxDelay: metav1.Duration{Duration: 1 * time.Hour},
+					Fallback: &batchv1alpha1.FallbackPolicy{
+						Strategy: batchv1alpha1.FallbackWindowEnd,
+					},
+					Template: batchv1alpha1.JobTemplateSpec{
+						Spec: batchv1.JobSpec{
+							Template: corev1.PodTemplateSpec{
+								Spec: corev1.PodSpec{
+									Containers: []corev1.Container{
+										{
+											Name:  "test",
+											Image: containerImg,
+										},
+									},
+									RestartPolicy: corev1.RestartPolicyNever,
+								},
+							},
+						},
+					},
+				},
+			}
+			err := k8sClient.Create(ctx, carbonAwareJob)
+			Expect(err).NotTo(HaveOccurred())
+
+			submissionTime := time.Now()
+			carbonAwareJob.Status = batchv1alpha1.CarbonAwareJobStatus{
+				SubmissionTime:  &metav1.Time{Time: submissionTime},
+				SchedulingState: string(SchedulingStateNew),
+			}
+			err = k8sClient.Status().Update(ctx, carbonAwareJob)
+			Expect(err).NotTo(HaveOccurred())
+
+			result, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(5 * time.Minute))
+
+			createdJob := &batchv1alpha1.CarbonAwareJob{}
+			err = k8sClient.Get(ctx, namespacedName, createdJob)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(createdJob.Status.SchedulingState).To(Equal(string(SchedulingStateForecastUnavailable)))
+			Expect(createdJob.Status.ScheduledTime).To(BeNil())
+
+			By("Moving the cutoff past now so the next retry falls back")
+			reconciler.ForecastRetryCutoff = 2 * time.Hour
+			_, err = reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = k8sClient.Get(ctx, namespacedName, createdJob)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(createdJob.Status.SchedulingState).To(Equal(string(SchedulingStatePending)))
+			Expect(createdJob.Status.SchedulingDecision.ForecastSource).To(Equal("fallback"))
+			Expect(createdJob.Status.ScheduledTime.Time).To(BeTemporally("~", submissionTime.Add(time.Hour), time.Second))
+		})
+	})
+
 	// Test case 3: When it's time to create the underlying job
 	Context("When it's time to create the underlying job", func() {
 		It("Should create the job and update the status", func() {
@@ -376,7 +484,6 @@ var _ = Describe("CarbonAwareJob Controller", func() {
 				},
 				Spec: batchv1alpha1.CarbonAwareJobSpec{
 					MaxDelay: metav1.Duration{Duration: 1 * time.Hour},
-					Location: "gcp:us-west2",
 					Template: batchv1alpha1.JobTemplateSpec{
 						Spec: batchv1.JobSpec{
 							Template: corev1.PodTemplateSpec{
@@ -423,6 +530,7 @@ var _ = Describe("CarbonAwareJob Controller", func() {
 			// First reconciliation - process job
 			_, err = reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: namespacedName})
 			Expect(err).NotTo(HaveOccurred())
+			Expect(recorder.Events).To(Receive(HavePrefix("Normal " + ReasonJobCreated)))
 
 			By("Checking that the job state is updated to Scheduled")
 			// Define createdJob here so it can be used in the rest of the test
@@ -437,6 +545,10 @@ var _ = Describe("CarbonAwareJob Controller", func() {
 				return createdJob.Status.SchedulingState
 			}, time.Second*10, time.Millisecond*250).Should(Equal(string(SchedulingStateScheduled)))
 
+			By("Checking that the conditions report the created job")
+			Expect(meta.IsStatusConditionTrue(createdJob.Status.Conditions, ConditionJobCreated)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(createdJob.Status.Conditions, ConditionWaiting)).To(BeTrue())
+
 			By("Checking that the job name is set")
 			Eventually(func() string {
 				err := k8sClient.Get(ctx, namespacedName, createdJob)
@@ -496,7 +608,6 @@ var _ = Describe("CarbonAwareJob Controller", func() {
 				},
 				Spec: batchv1alpha1.CarbonAwareJobSpec{
 					MaxDelay: metav1.Duration{Duration: 1 * time.Hour},
-					Location: "gcp:us-west2",
 					Template: batchv1alpha1.JobTemplateSpec{
 						Spec: batchv1.JobSpec{
 							Template: corev1.PodTemplateSpec{
@@ -581,4 +692,1551 @@ var _ = Describe("CarbonAwareJob Controller", func() {
 			Expect(apierrors.IsNotFound(err)).To(BeTrue(), "Underlying Job should be deleted")
 		})
 	})
+	// Test case 5: When a deadline cannot be met
+	Context("When the deadline cannot be met", func() {
+		It("Should mark the job as failed without creating it", func() {
+			By("Creating a CarbonAwareJob whose deadline is shorter than its duration")
+			deadline := metav1.NewTime(time.Now().Add(10 * time.Minute))
+			carbonAwareJob := &batchv1alpha1.CarbonAwareJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       jobName,
+					Namespace:  testNS.Name,
+					Finalizers: []string{CarbonAwareJobFinalizer},
+				},
+				Spec: batchv1alpha1.CarbonAwareJobSpec{
+					Deadline:    &deadline,
+					MaxDuration: &metav1.Duration{Duration: 1 * time.Hour},
+					Template: batchv1alpha1.JobTemplateSpec{
+						Spec: batchv1.JobSpec{
+							Template: corev1.PodTemplateSpec{
+								Spec: corev1.PodSpec{
+									Containers: []corev1.Container{
+										{
+											Name:  "test",
+											Image: containerImg,
+										},
+									},
+									RestartPolicy: corev1.RestartPolicyNever,
+								},
+							},
+						},
+					},
+				},
+			}
+			err := k8sClient.Create(ctx, carbonAwareJob)
+			Expect(err).NotTo(HaveOccurred())
+
+			carbonAwareJob.Status = batchv1alpha1.CarbonAwareJobStatus{
+				SubmissionTime:  &metav1.Time{Time: time.Now()},
+				SchedulingState: string(SchedulingStateNew),
+			}
+			err = k8sClient.Status().Update(ctx, carbonAwareJob)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Checking that the job failed with the reason recorded")
+			createdJob := &batchv1alpha1.CarbonAwareJob{}
+			err = k8sClient.Get(ctx, namespacedName, createdJob)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(createdJob.Status.SchedulingState).To(Equal(string(SchedulingStateFailed)))
+			Expect(createdJob.Status.SchedulingDecision).NotTo(BeNil())
+			Expect(createdJob.Status.SchedulingDecision.DecisionReason).To(ContainSubstring("cannot be met"))
+			Expect(createdJob.Status.JobName).To(BeEmpty())
+		})
+	})
+
+	// Test case 6: When a pending job's forecast is re-evaluated
+	Context("When a better slot appears while the job is pending", func() {
+		It("Should move the scheduled time within the original window and record the revision", func() {
+			now := time.Now()
+			windowEnd := now.Add(2 * time.Hour)
+
+			By("Configuring a scheduler that now prefers a later time past the window end")
+			reconciler.ReforecastInterval = 15 * time.Minute
+			reconciler.SchedulingClient = &schedulingclient.MockSchedulingClient{
+				MockGetOptimalSchedule: func(_ context.Context, startTime time.Time, maxDelay time.Duration, _ time.Duration, locations []schedulingclient.CloudZone) (*schedulingclient.ScheduleResponse, error) {
+					Expect(startTime.Add(maxDelay)).To(BeTemporally("~", windowEnd, time.Second))
+					return &schedulingclient.ScheduleResponse{
+						Ideal: schedulingclient.ScheduleOption{
+							Time:         windowEnd.Add(time.Hour),
+							Zone:         locations[0],
+							CO2Intensity: 80.0,
+						},
+					}, nil
+				},
+			}
+
+			By("Creating a pending CarbonAwareJob whose forecast is stale")
+			carbonAwareJob := &batchv1alpha1.CarbonAwareJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       jobName,
+					Namespace:  testNS.Name,
+					Finalizers: []string{CarbonAwareJobFinalizer},
+				},
+				Spec: batchv1alpha1.CarbonAwareJobSpec{
+					MaxDelay: metav1.Duration{Duration: 3 * time.Hour},
+					Template: batchv1alpha1.JobTemplateSpec{
+						Spec: batchv1.JobSpec{
+							Template: corev1.PodTemplateSpec{
+								Spec: corev1.PodSpec{
+									Containers: []corev1.Container{
+										{
+											Name:  "test",
+											Image: containerImg,
+										},
+									},
+									RestartPolicy: corev1.RestartPolicyNever,
+								},
+							},
+						},
+					},
+				},
+			}
+			err := k8sClient.Create(ctx, carbonAwareJob)
+			Expect(err).NotTo(HaveOccurred())
+
+			scheduledTime := metav1.NewTime(now.Add(30 * time.Minute))
+			carbonAwareJob.Status = batchv1alpha1.CarbonAwareJobStatus{
+				SubmissionTime:   &metav1.Time{Time: now.Add(-1 * time.Hour)},
+				ScheduledTime:    &scheduledTime,
+				SchedulingState:  string(SchedulingStatePending),
+				LastForecastTime: &metav1.Time{Time: now.Add(-1 * time.Hour)},
+				SchedulingWindow: &batchv1alpha1.SchedulingWindow{
+					Start: metav1.NewTime(now.Add(-1 * time.Hour)),
+					End:   metav1.NewTime(windowEnd),
+				},
+			}
+			err = k8sClient.Status().Update(ctx, carbonAwareJob)
+			Expect(err).NotTo(HaveOccurred())
+
+			result, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically("<=", 15*time.Minute))
+
+			By("Checking that the new time is clamped to the window end and recorded")
+			updatedJob := &batchv1alpha1.CarbonAwareJob{}
+			err = k8sClient.Get(ctx, namespacedName, updatedJob)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updatedJob.Status.SchedulingState).To(Equal(string(SchedulingStatePending)))
+			Expect(updatedJob.Status.ScheduledTime.Time).To(BeTemporally("~", windowEnd, time.Second))
+			Expect(updatedJob.Status.ScheduleRevisions).To(HaveLen(1))
+			Expect(updatedJob.Status.ScheduleRevisions[0].PreviousTime.Time).To(BeTemporally("~", scheduledTime.Time, time.Second))
+			Expect(updatedJob.Status.ScheduleRevisions[0].NewTime.Time).To(BeTemporally("~", windowEnd, time.Second))
+			Expect(updatedJob.Status.ScheduleRevisions[0].Reason).NotTo(BeEmpty())
+		})
+	})
+
+	// Test case 7: When the job may run in several regions
+	Context("When the scheduler picked one of several allowed regions", func() {
+		It("Should pin the created Job to that region", func() {
+			By("Creating a CarbonAwareJob allowed in two regions whose start time has come")
+			carbonAwareJob := &batchv1alpha1.CarbonAwareJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       jobName,
+					Namespace:  testNS.Name,
+					Finalizers: []string{CarbonAwareJobFinalizer},
+				},
+				Spec: batchv1alpha1.CarbonAwareJobSpec{
+					MaxDelay: metav1.Duration{Duration: 3 * time.Hour},
+					Placement: &batchv1alpha1.Placement{
+						Regions: []string{"us-east-1", "eu-west-1"},
+					},
+					Template: batchv1alpha1.JobTemplateSpec{
+						Spec: batchv1.JobSpec{
+							Template: corev1.PodTemplateSpec{
+								Spec: corev1.PodSpec{
+									Containers: []corev1.Container{
+										{
+											Name:  "test",
+											Image: containerImg,
+										},
+									},
+									RestartPolicy: corev1.RestartPolicyNever,
+								},
+							},
+						},
+					},
+				},
+			}
+			err := k8sClient.Create(ctx, carbonAwareJob)
+			Expect(err).NotTo(HaveOccurred())
+
+			scheduledTime := metav1.NewTime(time.Now().Add(-time.Minute))
+			carbonAwareJob.Status = batchv1alpha1.CarbonAwareJobStatus{
+				SubmissionTime:  &metav1.Time{Time: time.Now().Add(-time.Hour)},
+				ScheduledTime:   &scheduledTime,
+				SchedulingState: string(SchedulingStatePending),
+				CarbonSavings:   &batchv1alpha1.CarbonSavings{},
+				SchedulingDecision: &batchv1alpha1.SchedulingDecision{
+					Region: "eu-west-1",
+				},
+			}
+			err = k8sClient.Status().Update(ctx, carbonAwareJob)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Checking that the Job requires nodes in the chosen region")
+			updatedJob := &batchv1alpha1.CarbonAwareJob{}
+			err = k8sClient.Get(ctx, namespacedName, updatedJob)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updatedJob.Status.JobName).NotTo(BeEmpty())
+
+			job := &batchv1.Job{}
+			err = k8sClient.Get(ctx, types.NamespacedName{Namespace: testNS.Name, Name: updatedJob.Status.JobName}, job)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(job.Annotations).To(HaveKeyWithValue("carbonaware.dev/region", "eu-west-1"))
+			affinity := job.Spec.Template.Spec.Affinity
+			Expect(affinity).NotTo(BeNil())
+			Expect(affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms).To(Equal([]corev1.NodeSelectorTerm{{
+				MatchExpressions: []corev1.NodeSelectorRequirement{{
+					Key:      regionLabel,
+					Operator: corev1.NodeSelectorOpIn,
+					Values:   []string{"eu-west-1"},
+				}},
+			}}))
+		})
+	})
+
+	Context("When the Job is created in Suspended mode", func() {
+		It("Should create the Job suspended and resume it at the scheduled time", func() {
+			By("Creating a new CarbonAwareJob with suspended job creation")
+			carbonAwareJob := &batchv1alpha1.CarbonAwareJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       jobName,
+					Namespace:  testNS.Name,
+					Finalizers: []string{CarbonAwareJobFinalizer},
+				},
+				Spec: batchv1alpha1.CarbonAwareJobSpec{
+					MaxDelay:    metav1.Duration{Duration: time.Hour},
+					JobCreation: batchv1alpha1.JobCreationSuspended,
+					Template: batchv1alpha1.JobTemplateSpec{
+						Spec: batchv1.JobSpec{
+							Template: corev1.PodTemplateSpec{
+								Spec: corev1.PodSpec{
+									Containers: []corev1.Container{
+										{
+											Name:  "test",
+											Image: containerImg,
+										},
+									},
+									RestartPolicy: corev1.RestartPolicyNever,
+								},
+							},
+						},
+					},
+				},
+			}
+			err := k8sClient.Create(ctx, carbonAwareJob)
+			Expect(err).NotTo(HaveOccurred())
+
+			carbonAwareJob.Status = batchv1alpha1.CarbonAwareJobStatus{
+				SubmissionTime:  &metav1.Time{Time: time.Now()},
+				SchedulingState: string(SchedulingStateNew),
+			}
+			err = k8sClient.Status().Update(ctx, carbonAwareJob)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Checking that the Job exists but is suspended until the scheduled time")
+			updatedJob := &batchv1alpha1.CarbonAwareJob{}
+			err = k8sClient.Get(ctx, namespacedName, updatedJob)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updatedJob.Status.SchedulingState).To(Equal(string(SchedulingStatePending)))
+			Expect(updatedJob.Status.JobName).NotTo(BeEmpty())
+			Expect(meta.IsStatusConditionTrue(updatedJob.Status.Conditions, ConditionJobCreated)).To(BeTrue())
+
+			job := &batchv1.Job{}
+			jobKey := types.NamespacedName{Namespace: testNS.Name, Name: updatedJob.Status.JobName}
+			err = k8sClient.Get(ctx, jobKey, job)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(job.Spec.Suspend).To(HaveValue(BeTrue()))
+			Expect(metav1.IsControlledBy(job, updatedJob)).To(BeTrue())
+
+			By("Resuming the Job once the scheduled time has come")
+			scheduledTime := metav1.NewTime(time.Now().Add(-time.Minute))
+			updatedJob.Status.ScheduledTime = &scheduledTime
+			err = k8sClient.Status().Update(ctx, updatedJob)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = k8sClient.Get(ctx, namespacedName, updatedJob)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updatedJob.Status.SchedulingState).To(Equal(string(SchedulingStateScheduled)))
+			Expect(meta.IsStatusConditionFalse(updatedJob.Status.Conditions, ConditionWaiting)).To(BeTrue())
+
+			err = k8sClient.Get(ctx, jobKey, job)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(job.Spec.Suspend).To(HaveValue(BeFalse()))
+			Expect(job.Annotations).To(HaveKeyWithValue("carbonaware.dev/scheduled-time", scheduledTime.Format(time.RFC3339)))
+		})
+	})
+})
+
+var _ = Describe("schedulingWindow", func() {
+	submissionTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	It("Should use MaxDelay when no deadline is set", func() {
+		carbonAwareJob := &batchv1alpha1.CarbonAwareJob{
+			Spec: batchv1alpha1.CarbonAwareJobSpec{
+				MaxDelay: metav1.Duration{Duration: 4 * time.Hour},
+			},
+		}
+		start, end, err := schedulingWindow(carbonAwareJob, submissionTime, time.Hour)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(start).To(Equal(submissionTime))
+		Expect(end).To(Equal(submissionTime.Add(4 * time.Hour)))
+	})
+
+	It("Should end the window at the deadline minus the job duration", func() {
+		deadline := metav1.NewTime(submissionTime.Add(6 * time.Hour))
+		carbonAwareJob := &batchv1alpha1.CarbonAwareJob{
+			Spec: batchv1alpha1.CarbonAwareJobSpec{
+				Deadline: &deadline,
+			},
+		}
+		_, end, err := schedulingWindow(carbonAwareJob, submissionTime, 2*time.Hour)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(end).To(Equal(submissionTime.Add(4 * time.Hour)))
+	})
+
+	It("Should use the earlier of MaxDelay and the deadline", func() {
+		deadline := metav1.NewTime(submissionTime.Add(6 * time.Hour))
+		carbonAwareJob := &batchv1alpha1.CarbonAwareJob{
+			Spec: batchv1alpha1.CarbonAwareJobSpec{
+				MaxDelay: metav1.Duration{Duration: 1 * time.Hour},
+				Deadline: &deadline,
+			},
+		}
+		_, end, err := schedulingWindow(carbonAwareJob, submissionTime, 2*time.Hour)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(end).To(Equal(submissionTime.Add(1 * time.Hour)))
+	})
+
+	It("Should reject a deadline that cannot be met", func() {
+		deadline := metav1.NewTime(submissionTime.Add(30 * time.Minute))
+		carbonAwareJob := &batchv1alpha1.CarbonAwareJob{
+			Spec: batchv1alpha1.CarbonAwareJobSpec{
+				Deadline: &deadline,
+			},
+		}
+		_, _, err := schedulingWindow(carbonAwareJob, submissionTime, time.Hour)
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("Historical low fallback", func() {
+	zone := schedulingclient.CloudZone{Provider: "aws", Region: "us-east-1"}
+
+	It("Should learn the most frequent ideal hour per zone", func() {
+		var tracker lowHourTracker
+		_, ok := tracker.lowHour(zone)
+		Expect(ok).To(BeFalse())
+
+		tracker.record(zone, time.Date(2025, 1, 1, 13, 15, 0, 0, time.UTC))
+		tracker.record(zone, time.Date(2025, 1, 2, 13, 45, 0, 0, time.UTC))
+		tracker.record(zone, time.Date(2025, 1, 3, 2, 0, 0, 0, time.UTC))
+
+		hour, ok := tracker.lowHour(zone)
+		Expect(ok).To(BeTrue())
+		Expect(hour).To(Equal(13))
+	})
+
+	It("Should find the next occurrence of an hour inside the window", func() {
+		now := time.Date(2025, 1, 1, 20, 0, 0, 0, time.UTC)
+
+		start, ok := nextHourOccurrence(3, now, now.Add(12*time.Hour))
+		Expect(ok).To(BeTrue())
+		Expect(start).To(Equal(time.Date(2025, 1, 2, 3, 0, 0, 0, time.UTC)))
+
+		_, ok = nextHourOccurrence(13, now, now.Add(12*time.Hour))
+		Expect(ok).To(BeFalse())
+
+		start, ok = nextHourOccurrence(20, now.Add(30*time.Minute), now.Add(time.Hour))
+		Expect(ok).To(BeTrue())
+		Expect(start).To(Equal(now.Add(30 * time.Minute)))
+	})
+})
+
+var _ = Describe("Placement", func() {
+	defaultZone := schedulingclient.CloudZone{Provider: "aws", Region: "us-east-1"}
+	nodeLocations := []cloudinfo.CloudEnvironment{
+		{Provider: "aws", Region: "us-east-1", Zone: "us-east-1a"},
+		{Provider: "aws", Region: "us-east-1", Zone: "us-east-1b"},
+		{Provider: "aws", Region: "eu-west-1", Zone: "eu-west-1a"},
+		{Provider: "unknown", Region: "eu-north-1", Zone: "eu-north-1a"},
+	}
+
+	It("Should request every listed region", func() {
+		p, err := buildPlacement(&batchv1alpha1.Placement{Regions: []string{"eu-west-1", "us-west-2"}}, defaultZone, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(p.zones).To(Equal([]schedulingclient.CloudZone{
+			{Provider: "aws", Region: "eu-west-1"},
+			{Provider: "aws", Region: "us-west-2"},
+		}))
+	})
+
+	It("Should allow every region the nodes are in", func() {
+		p, err := buildPlacement(&batchv1alpha1.Placement{AnyNodeRegion: true}, defaultZone, nodeLocations)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(p.zones).To(Equal([]schedulingclient.CloudZone{
+			{Provider: "aws", Region: "us-east-1"},
+			{Provider: "aws", Region: "eu-west-1"},
+			{Provider: "aws", Region: "eu-north-1"},
+		}))
+	})
+
+	It("Should derive regions from zones and restrict the Job to those zones", func() {
+		p, err := buildPlacement(&batchv1alpha1.Placement{
+			Regions: []string{"eu-west-1"},
+			Zones:   []string{"us-east-1b"},
+		}, defaultZone, nodeLocations)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(p.zones).To(Equal([]schedulingclient.CloudZone{
+			{Provider: "aws", Region: "eu-west-1"},
+			{Provider: "aws", Region: "us-east-1"},
+		}))
+
+		Expect(p.nodeSelectorTerms("us-east-1")).To(Equal([]corev1.NodeSelectorTerm{{
+			MatchExpressions: []corev1.NodeSelectorRequirement{
+				{Key: regionLabel, Operator: corev1.NodeSelectorOpIn, Values: []string{"us-east-1"}},
+				{Key: zoneLabel, Operator: corev1.NodeSelectorOpIn, Values: []string{"us-east-1b"}},
+			},
+		}}))
+		Expect(p.nodeSelectorTerms("")).To(HaveLen(2))
+	})
+
+	It("Should run Jobs without a placement in the regions of their nodes", func() {
+		p := nodePlacement(defaultZone, nodeLocations[:2])
+		Expect(p.zones).To(Equal([]schedulingclient.CloudZone{{Provider: "aws", Region: "us-east-1"}}))
+		Expect(p.nodeSelectorTerms("us-east-1")).To(BeNil())
+
+		p = nodePlacement(defaultZone, nodeLocations)
+		Expect(p.zones).To(HaveLen(3))
+		Expect(p.nodeSelectorTerms("eu-west-1")).To(Equal([]corev1.NodeSelectorTerm{{
+			MatchExpressions: []corev1.NodeSelectorRequirement{
+				{Key: regionLabel, Operator: corev1.NodeSelectorOpIn, Values: []string{"eu-west-1"}},
+			},
+		}}))
+
+		p = nodePlacement(defaultZone, nil)
+		Expect(p.zones).To(Equal([]schedulingclient.CloudZone{defaultZone}))
+		Expect(p.nodeSelectorTerms("")).To(BeNil())
+	})
+
+	It("Should restrict Jobs to grid zones by the grid region label", func() {
+		p := nodePlacement(defaultZone, []cloudinfo.CloudEnvironment{
+			{Provider: cloudinfo.ProviderGrid, Region: "DE"},
+			{Provider: "aws", Region: "eu-central-1"},
+		})
+		Expect(p.zones).To(Equal([]schedulingclient.CloudZone{
+			{Provider: cloudinfo.ProviderGrid, Region: "DE"},
+			{Provider: "aws", Region: "eu-central-1"},
+		}))
+		Expect(p.nodeSelectorTerms("DE")[0].MatchExpressions[0].Key).To(Equal(cloudinfo.GridRegionLabel))
+		Expect(p.nodeSelectorTerms("eu-central-1")[0].MatchExpressions[0].Key).To(Equal(regionLabel))
+	})
+
+	It("Should fail when nothing matches", func() {
+		_, err := buildPlacement(&batchv1alpha1.Placement{Zones: []string{"mars-1a"}}, defaultZone, nodeLocations)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("Should combine new terms with existing required node affinity", func() {
+		podSpec := &corev1.PodSpec{
+			Affinity: &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+							MatchExpressions: []corev1.NodeSelectorRequirement{
+								{Key: "gpu", Operator: corev1.NodeSelectorOpExists},
+							},
+						}},
+					},
+				},
+			},
+		}
+		p := &placement{
+			zones:        []schedulingclient.CloudZone{{Provider: "aws", Region: "us-east-1"}, {Provider: "aws", Region: "eu-west-1"}},
+			allowedZones: map[string][]string{"us-east-1": nil, "eu-west-1": nil},
+			restricted:   true,
+		}
+
+		addRequiredNodeAffinity(podSpec, p.nodeSelectorTerms(""))
+		terms := podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+		Expect(terms).To(HaveLen(2))
+		for _, term := range terms {
+			Expect(term.MatchExpressions).To(HaveLen(2))
+			Expect(term.MatchExpressions[0].Key).To(Equal("gpu"))
+			Expect(term.MatchExpressions[1].Key).To(Equal(regionLabel))
+		}
+	})
+})
+
+var _ = Describe("Grid zones", func() {
+	It("Should request and report the grid zones of cloud regions", func() {
+		var requested []schedulingclient.CloudZone
+		client := &schedulingclient.MockSchedulingClient{
+			MockGetOptimalSchedule: func(_ context.Context, startTime time.Time, _ time.Duration, _ time.Duration, locations []schedulingclient.CloudZone) (*schedulingclient.ScheduleResponse, error) {
+				requested = locations
+				// Like the scheduler, answer without grid zones
+				ideal := schedulingclient.ScheduleOption{Time: startTime, Zone: schedulingclient.CloudZone{Provider: "aws", Region: "eu-west-1"}}
+				return &schedulingclient.ScheduleResponse{Ideal: ideal, NaiveCase: ideal, MedianCase: ideal, WorstCase: ideal}, nil
+			},
+		}
+		zones := []schedulingclient.CloudZone{
+			{Provider: "aws", Region: "us-east-1"},
+			{Provider: "aws", Region: "eu-west-1"},
+			{Provider: "hetzner", Region: "fsn1"},
+		}
+
+		resp, err := getOptimalSchedule(ctx, client, gridzone.NewTable(map[string]string{"aws:eu-west-1": "IE-TEST"}),
+			time.Now(), time.Hour, time.Hour, zones)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(requested).To(Equal([]schedulingclient.CloudZone{
+			{Provider: "aws", Region: "us-east-1", GridZone: "US-MIDA-PJM"},
+			{Provider: "aws", Region: "eu-west-1", GridZone: "IE-TEST"},
+			{Provider: "hetzner", Region: "fsn1"},
+		}))
+		Expect(zones[0].GridZone).To(BeEmpty())
+		Expect(resp.Ideal.Zone.GridZone).To(Equal("IE-TEST"))
+	})
+})
+
+var _ = Describe("Configuration reloads", func() {
+	It("Should rebuild the forecast client and the cloud environment", func() {
+		reconciler := &CarbonAwareJobReconciler{}
+		cfg := config.Defaults()
+		reconciler.ApplyConfig(cfg)
+		Expect(reconciler.SchedulingClient).To(BeAssignableToTypeOf(&schedulingclient.SchedulingClient{}))
+		Expect(reconciler.ForecastSource).To(BeEmpty())
+		Expect(reconciler.OverrideCloudEnvironment).To(BeFalse())
+
+		reloaded := config.Defaults()
+		reloaded.Forecast.Provider = forecast.ProviderNationalGrid
+		reloaded.CloudEnvironment = config.CloudEnvironmentConfig{Override: true, Provider: "gcp", Region: "europe-west2"}
+		reconciler.ApplyConfig(reloaded)
+		Expect(reconciler.SchedulingClient).To(BeAssignableToTypeOf(&forecast.Client{}))
+		Expect(reconciler.ForecastSource).To(Equal(forecast.ProviderNationalGrid))
+		Expect(reconciler.OverrideCloudEnvironment).To(BeTrue())
+		Expect(reconciler.CloudEnvironment).To(Equal(&cloudinfo.CloudEnvironment{Provider: "gcp", Region: "europe-west2"}))
+	})
+
+	It("Should reconcile with a copy of every setting", func() {
+		watcher, err := config.NewWatcher("")
+		Expect(err).NotTo(HaveOccurred())
+		reconciler := &CarbonAwareJobReconciler{
+			Client:                   fake.NewClientBuilder().Build(),
+			Scheme:                   runtime.NewScheme(),
+			Recorder:                 record.NewFakeRecorder(1),
+			SchedulingClient:         &schedulingclient.MockSchedulingClient{},
+			CloudEnvironment:         &cloudinfo.CloudEnvironment{Provider: "aws", Region: "us-east-1"},
+			OverrideCloudEnvironment: true,
+			Nodes:                    cloudinfo.NewNodeInventory(),
+			GridZones:                gridzone.NewTable(nil),
+			ForecastSource:           "static",
+			DefaultMaxDuration:       time.Hour,
+			ReforecastInterval:       time.Minute,
+			ForecastRetryInterval:    time.Minute,
+			ForecastRetryCutoff:      time.Minute,
+			StatusCheckInterval:      time.Minute,
+			StartTimeout:             time.Minute,
+			PowerModel:               config.Defaults().Emissions,
+			Config:                   watcher,
+		}
+		snapshot := reconciler.snapshot()
+
+		original, copied := reflect.ValueOf(reconciler).Elem(), reflect.ValueOf(snapshot).Elem()
+		for i := 0; i < original.NumField(); i++ {
+			if !original.Type().Field(i).IsExported() {
+				continue
+			}
+			name := original.Type().Field(i).Name
+			Expect(original.Field(i).IsZero()).To(BeFalse(), "set %s above", name)
+			Expect(copied.Field(i).Interface()).To(BeIdenticalTo(original.Field(i).Interface()), name)
+		}
+		Expect(snapshot.shared()).To(BeIdenticalTo(reconciler))
+		Expect(snapshot.snapshot().shared()).To(BeIdenticalTo(reconciler))
+
+		gateReconciler := &PodSchedulingGateReconciler{
+			Client:                   reconciler.Client,
+			Recorder:                 reconciler.Recorder,
+			SchedulingClient:         reconciler.SchedulingClient,
+			CloudEnvironment:         reconciler.CloudEnvironment,
+			OverrideCloudEnvironment: true,
+			Nodes:                    reconciler.Nodes,
+			GridZones:                reconciler.GridZones,
+			DefaultMaxDuration:       time.Hour,
+			ForecastRetryInterval:    time.Minute,
+			ForecastRetryCutoff:      time.Minute,
+			Config:                   watcher,
+		}
+		original, copied = reflect.ValueOf(gateReconciler).Elem(), reflect.ValueOf(gateReconciler.snapshot()).Elem()
+		for i := 0; i < original.NumField(); i++ {
+			if !original.Type().Field(i).IsExported() {
+				continue
+			}
+			name := original.Type().Field(i).Name
+			Expect(original.Field(i).IsZero()).To(BeFalse(), "set %s above", name)
+			Expect(copied.Field(i).Interface()).To(BeIdenticalTo(original.Field(i).Interface()), name)
+		}
+	})
+})
+
+var _ = Describe("Emissions", func() {
+	var (
+		carbonAwareJob *batchv1alpha1.CarbonAwareJob
+		job            *batchv1.Job
+		start          time.Time
+	)
+
+	BeforeEach(func() {
+		start = time.Now().Add(-4 * time.Hour).Truncate(time.Second)
+		carbonAwareJob = &batchv1alpha1.CarbonAwareJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "emissions-test", Namespace: "emissions-test"},
+			Spec: batchv1alpha1.CarbonAwareJobSpec{
+				Template: batchv1alpha1.JobTemplateSpec{
+					Spec: batchv1.JobSpec{
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{{
+									Name: "test",
+									Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+										corev1.ResourceCPU:                    resource.MustParse("2"),
+										corev1.ResourceName("nvidia.com/gpu"): resource.MustParse("1"),
+									}},
+								}},
+							},
+						},
+					},
+				},
+			},
+			Status: batchv1alpha1.CarbonAwareJobStatus{
+				SubmissionTime:  &metav1.Time{Time: start.Add(-2 * time.Hour)},
+				CarbonIntensity: "100.00 gCO2eq/kWh",
+				SchedulingDecision: &batchv1alpha1.SchedulingDecision{
+					ImmediateIntensity: "300.00 gCO2eq/kWh",
+					Region:             "eu-west-1",
+					GridZone:           "IE",
+				},
+			},
+		}
+		job = &batchv1.Job{Status: batchv1.JobStatus{
+			StartTime:      &metav1.Time{Time: start},
+			CompletionTime: &metav1.Time{Time: start.Add(3 * time.Hour)},
+		}}
+	})
+
+	It("Should estimate energy from requests and run time and use the realized intensity", func() {
+		var requestedZones []schedulingclient.CloudZone
+		var requestedStarts, requestedEnds []time.Time
+		reconciler := &CarbonAwareJobReconciler{
+			CloudEnvironment: &cloudinfo.CloudEnvironment{Provider: "aws", Region: "us-east-1"},
+			GridZones:        gridzone.NewTable(nil),
+			PowerModel:       config.EmissionsConfig{WattsPerCPU: 2, WattsPerGPU: 100, PowerUsageEffectiveness: 1.5},
+			SchedulingClient: &schedulingclient.MockSchedulingClient{
+				MockGetOptimalSchedule: func(context.Context, time.Time, time.Duration, time.Duration, []schedulingclient.CloudZone) (*schedulingclient.ScheduleResponse, error) {
+					Fail("the forecast must not be used for the realized intensity")
+					return nil, nil
+				},
+				MockRealizedIntensity: func(_ context.Context, zone schedulingclient.CloudZone, from, to time.Time) (float64, error) {
+					requestedZones = append(requestedZones, zone)
+					requestedStarts = append(requestedStarts, from)
+					requestedEnds = append(requestedEnds, to)
+					if from.Equal(start) {
+						return 150, nil
+					}
+					return 250, nil
+				},
+			},
+		}
+
+		emissions := reconciler.estimateEmissions(ctx, carbonAwareJob, job)
+		// Both intervals are read for the zone of the decision rather than the cluster's
+		Expect(requestedZones).To(HaveLen(2))
+		for _, zone := range requestedZones {
+			Expect(zone).To(Equal(schedulingclient.CloudZone{Provider: "aws", Region: "eu-west-1", GridZone: "IE"}))
+		}
+		Expect(requestedStarts[0]).To(BeTemporally("==", start))
+		Expect(requestedEnds[0]).To(BeTemporally("==", start.Add(3*time.Hour)))
+		Expect(requestedStarts[1]).To(BeTemporally("==", start.Add(-2*time.Hour)))
+		Expect(requestedEnds[1]).To(BeTemporally("==", start.Add(time.Hour)))
+
+		// (2 * 2 W + 1 * 100 W) * 1.5 * 3 h
+		Expect(emissions.EnergyKWh.AsApproximateFloat64()).To(BeNumerically("~", 0.468, 1e-6))
+		Expect(emissions.IntensitySource).To(Equal(batchv1alpha1.IntensitySourceRealized))
+		Expect(emissions.IntensityGramsPerKWh.AsApproximateFloat64()).To(BeNumerically("~", 150, 1e-3))
+		Expect(emissions.EmittedGrams.AsApproximateFloat64()).To(BeNumerically("~", 70.2, 1e-3))
+		// Measured at submission, not forecast: 0.468 kWh * (250 - 150) gCO2eq/kWh
+		Expect(emissions.AvoidedGrams.AsApproximateFloat64()).To(BeNumerically("~", 46.8, 1e-3))
+		Expect(emissions.EndTime.Sub(emissions.StartTime.Time)).To(Equal(3 * time.Hour))
+	})
+
+	It("Should fall back to the forecast intensity", func() {
+		reconciler := &CarbonAwareJobReconciler{
+			CloudEnvironment: &cloudinfo.CloudEnvironment{Provider: "aws", Region: "us-east-1"},
+			SchedulingClient: &schedulingclient.MockSchedulingClient{
+				MockRealizedIntensity: func(context.Context, schedulingclient.CloudZone, time.Time, time.Time) (float64, error) {
+					return 0, errors.New("no data for the past")
+				},
+			},
+		}
+
+		emissions := reconciler.estimateEmissions(ctx, carbonAwareJob, job)
+		Expect(emissions.IntensitySource).To(Equal(batchv1alpha1.IntensitySourceForecast))
+		// Default power model: (2 * 2.12 W + 1 * 250 W) * 3 h
+		Expect(emissions.EnergyKWh.AsApproximateFloat64()).To(BeNumerically("~", 0.76272, 1e-6))
+		Expect(emissions.EmittedGrams.AsApproximateFloat64()).To(BeNumerically("~", 76.272, 1e-3))
+		Expect(emissions.AvoidedGrams.AsApproximateFloat64()).To(BeNumerically("~", 152.544, 1e-3))
+	})
+
+	It("Should compare forecasts when the decision has no zone", func() {
+		carbonAwareJob.Status.SchedulingDecision.Region = ""
+		reconciler := &CarbonAwareJobReconciler{
+			SchedulingClient: &schedulingclient.MockSchedulingClient{
+				MockRealizedIntensity: func(context.Context, schedulingclient.CloudZone, time.Time, time.Time) (float64, error) {
+					Fail("the realized intensity of an unknown zone must not be requested")
+					return 0, nil
+				},
+			},
+		}
+
+		emissions := reconciler.estimateEmissions(ctx, carbonAwareJob, job)
+		Expect(emissions.IntensitySource).To(Equal(batchv1alpha1.IntensitySourceForecast))
+		Expect(emissions.AvoidedGrams.AsApproximateFloat64()).To(BeNumerically("~", 152.544, 1e-3))
+	})
+
+	It("Should never label the forecast as realized for clients without history", func() {
+		reconciler := &CarbonAwareJobReconciler{
+			CloudEnvironment: &cloudinfo.CloudEnvironment{Provider: "aws", Region: "us-east-1"},
+			SchedulingClient: schedulingclient.NewSchedulingClient("http://scheduler.invalid"),
+		}
+
+		emissions := reconciler.estimateEmissions(ctx, carbonAwareJob, job)
+		Expect(emissions.IntensitySource).To(Equal(batchv1alpha1.IntensitySourceForecast))
+		Expect(emissions.IntensityGramsPerKWh.AsApproximateFloat64()).To(BeNumerically("~", 100, 1e-3))
+	})
+
+	It("Should leave the intensity source unset when no intensity is known", func() {
+		// Decisions of the fallback policy record no intensity
+		carbonAwareJob.Status.CarbonIntensity = "unknown"
+		reconciler := &CarbonAwareJobReconciler{
+			CloudEnvironment: &cloudinfo.CloudEnvironment{Provider: "aws", Region: "us-east-1"},
+			SchedulingClient: &schedulingclient.MockSchedulingClient{
+				MockRealizedIntensity: func(context.Context, schedulingclient.CloudZone, time.Time, time.Time) (float64, error) {
+					return 0, errors.New("no data for the past")
+				},
+			},
+		}
+
+		emissions := reconciler.estimateEmissions(ctx, carbonAwareJob, job)
+		Expect(emissions.EnergyKWh.AsApproximateFloat64()).To(BeNumerically("~", 0.76272, 1e-6))
+		Expect(emissions.IntensitySource).To(BeEmpty())
+		Expect(emissions.IntensityGramsPerKWh).To(BeNil())
+		Expect(emissions.EmittedGrams).To(BeNil())
+		Expect(emissions.AvoidedGrams).To(BeNil())
+	})
+
+	It("Should count avoided emissions once when the Job has finished", func() {
+		carbonAwareJob.Status.JobName = "emissions-test-1"
+		carbonAwareJob.Status.SchedulingState = string(SchedulingStateScheduled)
+		job.ObjectMeta = metav1.ObjectMeta{Name: "emissions-test-1", Namespace: "emissions-test"}
+		job.Status.Succeeded = 1
+
+		scheme := runtime.NewScheme()
+		Expect(batchv1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(batchv1.AddToScheme(scheme)).To(Succeed())
+		reconciler := &CarbonAwareJobReconciler{
+			Client: fake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(carbonAwareJob, job).WithStatusSubresource(carbonAwareJob).Build(),
+			Recorder:         record.NewFakeRecorder(10),
+			CloudEnvironment: &cloudinfo.CloudEnvironment{Provider: "aws", Region: "us-east-1"},
+			SchedulingClient: &schedulingclient.MockSchedulingClient{},
+		}
+		avoided := emissionsAvoided.WithLabelValues("emissions-test")
+		before := testutil.ToFloat64(avoided)
+
+		_, err := reconciler.handleScheduledJob(ctx, carbonAwareJob)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(carbonAwareJob.Status.Emissions).NotTo(BeNil())
+		counted := testutil.ToFloat64(avoided) - before
+		Expect(counted).To(BeNumerically(">", 0))
+
+		_, err = reconciler.handleScheduledJob(ctx, carbonAwareJob)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(testutil.ToFloat64(avoided) - before).To(Equal(counted))
+	})
+
+	It("Should not estimate Jobs that never started", func() {
+		job.Status.StartTime = nil
+		Expect((&CarbonAwareJobReconciler{}).estimateEmissions(ctx, carbonAwareJob, job)).To(BeNil())
+	})
+})
+
+// regionProvider is a forecast provider with a flat forecast for some regions
+type regionProvider map[string]float64
+
+func (p regionProvider) Name() string {
+	return "regions"
+}
+
+func (p regionProvider) Forecast(_ context.Context, location string, start, end time.Time) ([]forecast.Point, error) {
+	intensity, ok := p[location]
+	if !ok {
+		return nil, errors.New("no forecast")
+	}
+	return []forecast.Point{{Time: start, Intensity: intensity}, {Time: end, Intensity: intensity}}, nil
+}
+
+var _ = Describe("Metrics", func() {
+	It("Should move jobs between states and forget deleted ones", func() {
+		var tracker stateTracker
+		key := types.NamespacedName{Namespace: "default", Name: "metrics-test"}
+		pending := testutil.ToFloat64(jobsByState.WithLabelValues(string(SchedulingStatePending)))
+		completed := testutil.ToFloat64(jobsByState.WithLabelValues(string(SchedulingStateCompleted)))
+
+		tracker.set(key, string(SchedulingStatePending))
+		tracker.set(key, string(SchedulingStatePending))
+		Expect(testutil.ToFloat64(jobsByState.WithLabelValues(string(SchedulingStatePending)))).To(Equal(pending + 1))
+
+		tracker.set(key, string(SchedulingStateCompleted))
+		Expect(testutil.ToFloat64(jobsByState.WithLabelValues(string(SchedulingStatePending)))).To(Equal(pending))
+		Expect(testutil.ToFloat64(jobsByState.WithLabelValues(string(SchedulingStateCompleted)))).To(Equal(completed + 1))
+
+		tracker.forget(key)
+		tracker.forget(key)
+		Expect(testutil.ToFloat64(jobsByState.WithLabelValues(string(SchedulingStateCompleted)))).To(Equal(completed))
+	})
+
+	It("Should count a forecast request for several zones once per zone", func() {
+		zones := []schedulingclient.CloudZone{
+			{Provider: "aws", Region: "metrics-east", GridZone: "US-MIDA-PJM"},
+			{Provider: "aws", Region: "metrics-east", GridZone: "US-NY-NYIS"},
+			{Provider: "aws", Region: "metrics-west"},
+		}
+
+		observeForecastRequest(zones, time.Second, errors.New("unavailable"))
+		Expect(testutil.ToFloat64(forecastRequestErrors.WithLabelValues("aws:metrics-east"))).To(Equal(1.0))
+		Expect(testutil.ToFloat64(forecastRequestErrors.WithLabelValues("aws:metrics-west"))).To(Equal(1.0))
+
+		observeForecastRequest(zones[2:], time.Second, nil)
+		Expect(testutil.ToFloat64(forecastRequestErrors.WithLabelValues("aws:metrics-east"))).To(Equal(1.0))
+		Expect(testutil.ToFloat64(forecastRequestErrors.WithLabelValues("aws:metrics-west"))).To(Equal(1.0))
+	})
+
+	It("Should count the forecast of each zone separately for forecast providers", func() {
+		zones := []schedulingclient.CloudZone{
+			{Provider: "aws", Region: "metrics-zone-east"},
+			{Provider: "aws", Region: "metrics-zone-west"},
+		}
+		client := forecast.NewClient(regionProvider{"metrics-zone-west": 100}, nil)
+		client.ObserveZone = observeForecastZone
+
+		_, err := getOptimalSchedule(ctx, client, nil, time.Now(), time.Hour, time.Hour, zones)
+		Expect(err).NotTo(HaveOccurred())
+		// The provider observes each zone, so the request is not counted again
+		Expect(testutil.ToFloat64(forecastRequestErrors.WithLabelValues("aws:metrics-zone-east"))).To(Equal(1.0))
+		Expect(testutil.ToFloat64(forecastRequestErrors.WithLabelValues("aws:metrics-zone-west"))).To(BeZero())
+	})
+
+	It("Should count a scheduling decision only once it is stored", func() {
+		submitted := metav1.Now()
+		carbonAwareJob := &batchv1alpha1.CarbonAwareJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "metrics-decision", Namespace: "default"},
+			Spec:       batchv1alpha1.CarbonAwareJobSpec{MaxDelay: metav1.Duration{Duration: 4 * time.Hour}},
+			Status: batchv1alpha1.CarbonAwareJobStatus{
+				SubmissionTime:  &submitted,
+				SchedulingState: string(SchedulingStateNew),
+			},
+		}
+		scheme := runtime.NewScheme()
+		Expect(batchv1alpha1.AddToScheme(scheme)).To(Succeed())
+		conflict := true
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(carbonAwareJob).WithStatusSubresource(carbonAwareJob).
+			WithInterceptorFuncs(interceptor.Funcs{
+				SubResourceUpdate: func(ctx context.Context, c ctrlclient.Client, subResource string, obj ctrlclient.Object, opts ...ctrlclient.SubResourceUpdateOption) error {
+					if conflict {
+						return apierrors.NewConflict(batchv1alpha1.GroupVersion.WithResource("carbonawarejobs").GroupResource(), obj.GetName(), errors.New("modified"))
+					}
+					return c.SubResource(subResource).Update(ctx, obj, opts...)
+				},
+			}).Build()
+		reconciler := &CarbonAwareJobReconciler{
+			Client:                   fakeClient,
+			Recorder:                 record.NewFakeRecorder(10),
+			SchedulingClient:         &schedulingclient.MockSchedulingClient{},
+			CloudEnvironment:         &cloudinfo.CloudEnvironment{Provider: "aws", Region: "us-east-1"},
+			OverrideCloudEnvironment: true,
+			Nodes:                    cloudinfo.NewNodeInventory(),
+			GridZones:                gridzone.NewTable(nil),
+		}
+		decisions := func() uint64 {
+			metric := &dto.Metric{}
+			Expect(schedulingDelay.Write(metric)).To(Succeed())
+			return metric.GetHistogram().GetSampleCount()
+		}
+		before := decisions()
+
+		_, err := reconciler.handleNewJob(context.Background(), carbonAwareJob.DeepCopy(), nil)
+		Expect(apierrors.IsConflict(err)).To(BeTrue(), "unexpected error: %v", err)
+		Expect(decisions()).To(Equal(before))
+
+		conflict = false
+		_, err = reconciler.handleNewJob(context.Background(), carbonAwareJob.DeepCopy(), nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decisions()).To(Equal(before + 1))
+	})
+
+	It("Should count the emissions recorded in status", func() {
+		carbonAwareJob := &batchv1alpha1.CarbonAwareJob{ObjectMeta: metav1.ObjectMeta{Namespace: "metrics-emissions"}}
+		carbonAwareJob.Status.Emissions = &batchv1alpha1.EmissionsEstimate{
+			EmittedGrams: decimalQuantity(70.2),
+			AvoidedGrams: decimalQuantity(46.8),
+		}
+		observeEmissions(carbonAwareJob)
+		Expect(testutil.ToFloat64(emissionsEmitted.WithLabelValues("metrics-emissions"))).To(BeNumerically("~", 70.2, 1e-9))
+		Expect(testutil.ToFloat64(emissionsAvoided.WithLabelValues("metrics-emissions"))).To(BeNumerically("~", 46.8, 1e-9))
+
+		// A delay that made things worse is counted separately rather than dropped
+		carbonAwareJob.Status.Emissions.AvoidedGrams = decimalQuantity(-10)
+		observeEmissions(carbonAwareJob)
+		Expect(testutil.ToFloat64(emissionsAvoided.WithLabelValues("metrics-emissions"))).To(BeNumerically("~", 46.8, 1e-9))
+		Expect(testutil.ToFloat64(emissionsIncreased.WithLabelValues("metrics-emissions"))).To(BeNumerically("~", 10, 1e-9))
+	})
+
+	It("Should estimate the energy of a job and read intensities from status", func() {
+		parallelism := int32(2)
+		carbonAwareJob := &batchv1alpha1.CarbonAwareJob{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "metrics-test"},
+			Spec: batchv1alpha1.CarbonAwareJobSpec{
+				MaxDuration: &metav1.Duration{Duration: 2 * time.Hour},
+				Template: batchv1alpha1.JobTemplateSpec{
+					Spec: batchv1.JobSpec{
+						Parallelism: &parallelism,
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{{
+									Resources: corev1.ResourceRequirements{
+										Requests: corev1.ResourceList{
+											corev1.ResourceCPU:    resource.MustParse("4"),
+											corev1.ResourceMemory: resource.MustParse("8Gi"),
+										},
+									},
+								}},
+							},
+						},
+					},
+				},
+			},
+			Status: batchv1alpha1.CarbonAwareJobStatus{
+				CarbonIntensity:    "100.00 gCO2eq/kWh",
+				SchedulingDecision: &batchv1alpha1.SchedulingDecision{ImmediateIntensity: "300.00 gCO2eq/kWh"},
+			},
+		}
+
+		// (4 * 2.12 W + 8 * 0.392 W) * 2 pods * 2 h
+		model := config.Defaults().Emissions
+		Expect(estimatedEnergyKWh(model, carbonAwareJob, 2*time.Hour)).To(BeNumerically("~", 0.046464, 1e-9))
+
+		_, ok := parseIntensity("unknown")
+		Expect(ok).To(BeFalse())
+
+		// The numeric field takes precedence over the display string
+		intensity, ok := statusIntensity(decimalQuantity(123.456), "100.00 gCO2eq/kWh")
+		Expect(ok).To(BeTrue())
+		Expect(intensity).To(BeNumerically("~", 123.456, 1e-9))
+	})
+})
+
+var _ = Describe("Scheduling strategy", func() {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	zone := schedulingclient.CloudZone{Provider: "aws", Region: "us-east-1"}
+	option := func(offset time.Duration, intensity float64) schedulingclient.ScheduleOption {
+		return schedulingclient.ScheduleOption{Time: now.Add(offset), Zone: zone, CO2Intensity: intensity}
+	}
+	scheduleResp := &schedulingclient.ScheduleResponse{
+		Ideal:      option(6*time.Hour, 100),
+		NaiveCase:  option(0, 300),
+		MedianCase: option(4*time.Hour, 250),
+		WorstCase:  option(time.Hour, 400),
+		Options: []schedulingclient.ScheduleOption{
+			option(6*time.Hour, 100),
+			option(5*time.Hour, 150),
+			option(3*time.Hour, 180),
+		},
+		Candidates: []schedulingclient.ScheduleOption{
+			option(0, 300),
+			option(time.Hour, 400),
+			option(2*time.Hour, 190),
+			option(3*time.Hour, 180),
+			option(4*time.Hour, 250),
+			option(5*time.Hour, 150),
+			option(6*time.Hour, 100),
+			option(7*time.Hour, 320),
+		},
+	}
+	jobWithStrategy := func(strategy *batchv1alpha1.SchedulingStrategy) *batchv1alpha1.CarbonAwareJob {
+		return &batchv1alpha1.CarbonAwareJob{Spec: batchv1alpha1.CarbonAwareJobSpec{Strategy: strategy}}
+	}
+	int32Ptr := func(v int32) *int32 { return &v }
+
+	It("Should start at the ideal time by default", func() {
+		chosen, _ := selectOption(jobWithStrategy(nil), scheduleResp)
+		Expect(chosen).To(Equal(scheduleResp.Ideal))
+		Expect(schedulingStrategy(jobWithStrategy(nil))).To(Equal(batchv1alpha1.StrategyOptimal))
+	})
+
+	It("Should start at the first option below the threshold", func() {
+		// The slot at 2h is not among the ranked options but is the first one below the threshold
+		chosen, reason := selectOption(jobWithStrategy(&batchv1alpha1.SchedulingStrategy{
+			Type:               batchv1alpha1.StrategyThreshold,
+			ThresholdIntensity: int32Ptr(200),
+		}), scheduleResp)
+		Expect(chosen).To(Equal(option(2*time.Hour, 190)))
+		Expect(reason).To(ContainSubstring("200 gCO2eq/kWh"))
+
+		chosen, _ = selectOption(jobWithStrategy(&batchv1alpha1.SchedulingStrategy{
+			Type:               batchv1alpha1.StrategyThreshold,
+			ThresholdIntensity: int32Ptr(50),
+		}), scheduleResp)
+		Expect(chosen).To(Equal(scheduleResp.Ideal))
+	})
+
+	It("Should start at the first option below the percentile", func() {
+		// Percentiles interpolate between the closest ranks of all eight candidates
+		Expect(percentileIntensity(scheduleResp, 25)).To(BeNumerically("~", 172.5, 1e-9))
+		Expect(percentileIntensity(scheduleResp, 75)).To(BeNumerically("~", 305, 1e-9))
+		Expect(percentileIntensity(scheduleResp, 0)).To(Equal(100.0))
+		Expect(percentileIntensity(scheduleResp, 100)).To(Equal(400.0))
+
+		chosen, _ := selectOption(jobWithStrategy(&batchv1alpha1.SchedulingStrategy{
+			Type:       batchv1alpha1.StrategyFirstBelowPercentile,
+			Percentile: int32Ptr(25),
+		}), scheduleResp)
+		Expect(chosen).To(Equal(option(5*time.Hour, 150)))
+	})
+
+	It("Should fall back to the known options without candidates", func() {
+		known := *scheduleResp
+		known.Candidates = nil
+		Expect(timeline(&known)).To(Equal([]schedulingclient.ScheduleOption{
+			option(0, 300),
+			option(time.Hour, 400),
+			option(3*time.Hour, 180),
+			option(4*time.Hour, 250),
+			option(5*time.Hour, 150),
+			option(6*time.Hour, 100),
+		}))
+	})
+
+	It("Should start at the ideal time when the response has no candidates", func() {
+		// The scheduler only reports ranked options, which cannot be searched in time order
+		known := *scheduleResp
+		known.Candidates = nil
+		chosen, reason := selectOption(jobWithStrategy(&batchv1alpha1.SchedulingStrategy{
+			Type:               batchv1alpha1.StrategyThreshold,
+			ThresholdIntensity: int32Ptr(200),
+		}), &known)
+		Expect(chosen).To(Equal(scheduleResp.Ideal))
+		Expect(reason).To(HavePrefix("The Threshold strategy needs every start time of the forecast"))
+	})
+
+	It("Should report savings of a non-optimal option", func() {
+		savings := carbonSavingsFor(scheduleResp, 200)
+		Expect(savings.VsNaiveCase).To(Equal("-33.33%"))
+		Expect(savings.VsMedianCase).To(Equal("-20.00%"))
+		Expect(carbonSavingsFor(scheduleResp, 330).VsNaiveCase).To(Equal("+10.00%"))
+
+		// The numeric fields count savings as positive and increases as negative
+		Expect(savings.VsNaiveCasePercent.String()).To(Equal("33333m"))
+		Expect(savings.VsMedianCasePercent.AsApproximateFloat64()).To(BeNumerically("~", 20, 1e-9))
+		Expect(carbonSavingsFor(scheduleResp, 330).VsNaiveCasePercent.AsApproximateFloat64()).To(BeNumerically("~", -10, 1e-9))
+	})
+})
+
+var _ = Describe("Carbon policies", func() {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	windowEnd := now.Add(8 * time.Hour)
+	zone := schedulingclient.CloudZone{Provider: "aws", Region: "eu-west-1"}
+	option := func(offset time.Duration, intensity float64) schedulingclient.ScheduleOption {
+		return schedulingclient.ScheduleOption{Time: now.Add(offset), Zone: zone, CO2Intensity: intensity}
+	}
+	scheduleResp := &schedulingclient.ScheduleResponse{
+		Ideal:      option(6*time.Hour, 100),
+		NaiveCase:  option(0, 300),
+		MedianCase: option(4*time.Hour, 250),
+		WorstCase:  option(time.Hour, 400),
+		Options: []schedulingclient.ScheduleOption{
+			option(6*time.Hour, 100),
+			option(2*time.Hour, 190),
+		},
+	}
+	newPolicy := func(spec batchv1alpha1.CarbonPolicySpec) *policy.Policy {
+		p, err := policy.New(policy.KindCarbonPolicy, "default", "test-policy", spec)
+		Expect(err).NotTo(HaveOccurred())
+		return p
+	}
+
+	It("Should keep the chosen option without a policy", func() {
+		chosen, reason := applyPolicyConstraints(nil, scheduleResp, scheduleResp.Ideal, "optimal", windowEnd)
+		Expect(chosen).To(Equal(scheduleResp.Ideal))
+		Expect(reason).To(Equal("optimal"))
+	})
+
+	It("Should start at the best allowed option if the chosen time is forbidden", func() {
+		p := newPolicy(batchv1alpha1.CarbonPolicySpec{
+			ForbiddenTimeRanges: []batchv1alpha1.TimeRange{{Start: "17:00", End: "19:00"}},
+		})
+		chosen, reason := applyPolicyConstraints(p, scheduleResp, scheduleResp.Ideal, "optimal", windowEnd)
+		Expect(chosen).To(Equal(option(2*time.Hour, 190)))
+		Expect(reason).To(ContainSubstring("forbidden by CarbonPolicy default/test-policy"))
+	})
+
+	It("Should start at the next allowed time if every option is forbidden", func() {
+		p := newPolicy(batchv1alpha1.CarbonPolicySpec{
+			ForbiddenTimeRanges: []batchv1alpha1.TimeRange{{Start: "11:00", End: "19:00"}},
+		})
+		chosen, _ := applyPolicyConstraints(p, scheduleResp, scheduleResp.Ideal, "optimal", windowEnd)
+		Expect(chosen.Time).To(Equal(now.Add(7 * time.Hour)))
+		Expect(chosen.CO2Intensity).To(Equal(scheduleResp.MedianCase.CO2Intensity))
+
+		// The end of the window takes precedence over the policy
+		chosen, _ = applyPolicyConstraints(p, scheduleResp, scheduleResp.Ideal, "optimal", now.Add(5*time.Hour))
+		Expect(chosen.Time).To(Equal(now.Add(5 * time.Hour)))
+	})
+
+	It("Should start immediately if the delay saves less than the policy requires", func() {
+		minSavings := int32(70)
+		p := newPolicy(batchv1alpha1.CarbonPolicySpec{MinSavingsPercent: &minSavings})
+		chosen, reason := applyPolicyConstraints(p, scheduleResp, scheduleResp.Ideal, "optimal", windowEnd)
+		Expect(chosen).To(Equal(scheduleResp.NaiveCase))
+		Expect(reason).To(ContainSubstring("Delaying would save 66.67%, less than the 70% required"))
+
+		minSavings = 60
+		p = newPolicy(batchv1alpha1.CarbonPolicySpec{MinSavingsPercent: &minSavings})
+		chosen, _ = applyPolicyConstraints(p, scheduleResp, scheduleResp.Ideal, "optimal", windowEnd)
+		Expect(chosen).To(Equal(scheduleResp.Ideal))
+	})
+
+	It("Should restrict placement to the allowed regions", func() {
+		p := newPolicy(batchv1alpha1.CarbonPolicySpec{AllowedRegions: []string{"eu-west-1"}})
+		unrestricted := &placement{zones: []schedulingclient.CloudZone{
+			{Provider: "aws", Region: "us-east-1"},
+			{Provider: "aws", Region: "eu-west-1"},
+		}}
+		allowed, err := unrestricted.allowedBy(p)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(allowed.zones).To(Equal([]schedulingclient.CloudZone{{Provider: "aws", Region: "eu-west-1"}}))
+		Expect(allowed.nodeSelectorTerms("")).To(HaveLen(1))
+
+		// A placement within the allowed regions is left as it is
+		single := &placement{zones: []schedulingclient.CloudZone{{Provider: "aws", Region: "eu-west-1"}}}
+		Expect(single.allowedBy(p)).To(BeIdenticalTo(single))
+
+		_, err = (&placement{zones: []schedulingclient.CloudZone{{Provider: "aws", Region: "us-east-1"}}}).allowedBy(p)
+		Expect(err).To(MatchError(ContainSubstring("none of the regions [us-east-1] is allowed by CarbonPolicy default/test-policy")))
+	})
+
+	Context("With a concurrency limit", func() {
+		limit := func(runs ...jobRun) *concurrencyLimit {
+			return &concurrencyLimit{maxJobs: 1, scope: "CarbonPolicy default/test-policy", duration: time.Hour, runs: runs}
+		}
+		run := func(start, end time.Duration) jobRun {
+			return jobRun{start: now.Add(start), end: now.Add(end)}
+		}
+
+		It("Should detect options overlapping the maximum number of jobs", func() {
+			l := limit(run(6*time.Hour+30*time.Minute, 7*time.Hour+30*time.Minute))
+			Expect(l.fullAt(now.Add(6 * time.Hour))).To(BeTrue())
+			Expect(l.fullAt(now.Add(7 * time.Hour))).To(BeTrue())
+			Expect(l.fullAt(now.Add(5*time.Hour + 30*time.Minute))).To(BeFalse())
+			Expect(l.fullAt(now.Add(7*time.Hour + 30*time.Minute))).To(BeFalse())
+
+			l.maxJobs = 2
+			Expect(l.fullAt(now.Add(6 * time.Hour))).To(BeFalse())
+			Expect((*concurrencyLimit)(nil).fullAt(now)).To(BeFalse())
+		})
+
+		It("Should report saturation only for started jobs", func() {
+			l := limit(run(0, time.Hour))
+			Expect(l.saturated()).To(BeFalse())
+			l.runs[0].started = true
+			Expect(l.saturated()).To(BeTrue())
+			Expect((*concurrencyLimit)(nil).saturated()).To(BeFalse())
+		})
+
+		It("Should spread jobs over the next best options", func() {
+			l := limit(run(6*time.Hour, 7*time.Hour))
+			chosen, reason := spreadOption(l, nil, scheduleResp, scheduleResp.Ideal, "optimal", windowEnd)
+			Expect(chosen).To(Equal(option(2*time.Hour, 190)))
+			Expect(reason).To(ContainSubstring("1 jobs of CarbonPolicy default/test-policy are already scheduled at that time"))
+
+			// Options that save less than the policy requires are skipped, except starting immediately
+			minSavings := int32(50)
+			p := newPolicy(batchv1alpha1.CarbonPolicySpec{MinSavingsPercent: &minSavings})
+			chosen, _ = spreadOption(l, p, scheduleResp, scheduleResp.Ideal, "optimal", windowEnd)
+			Expect(chosen).To(Equal(scheduleResp.NaiveCase))
+
+			// Without a free option the chosen one is kept
+			l = limit(run(0, 8*time.Hour))
+			chosen, reason = spreadOption(l, nil, scheduleResp, scheduleResp.Ideal, "optimal", windowEnd)
+			Expect(chosen).To(Equal(scheduleResp.Ideal))
+			Expect(reason).To(Equal("optimal"))
+		})
+
+		It("Should count the pending and running jobs of the same policy", func() {
+			p := newPolicy(batchv1alpha1.CarbonPolicySpec{
+				Concurrency: &batchv1alpha1.ConcurrencyLimit{MaxJobs: 2},
+			})
+			carbonAwareJobWith := func(name, state string, policyName string, scheduled time.Time) *batchv1alpha1.CarbonAwareJob {
+				scheduledTime := metav1.NewTime(scheduled)
+				return &batchv1alpha1.CarbonAwareJob{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: types.UID(name)},
+					Spec:       batchv1alpha1.CarbonAwareJobSpec{MaxDuration: &metav1.Duration{Duration: 2 * time.Hour}},
+					Status: batchv1alpha1.CarbonAwareJobStatus{
+						SchedulingState: state,
+						ScheduledTime:   &scheduledTime,
+						Policy:          &batchv1alpha1.PolicyReference{Kind: policy.KindCarbonPolicy, Name: policyName},
+					},
+				}
+			}
+			scheduled := time.Now().Add(time.Hour)
+			running := carbonAwareJobWith("running", string(SchedulingStateRunning), "test-policy", scheduled)
+			running.Status.JobStatus = &batchv1.JobStatus{StartTime: &metav1.Time{Time: scheduled.Add(-3 * time.Hour)}}
+			self := carbonAwareJobWith("self", string(SchedulingStatePending), "test-policy", scheduled)
+
+			scheme := runtime.NewScheme()
+			Expect(batchv1alpha1.AddToScheme(scheme)).To(Succeed())
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+				self,
+				running,
+				carbonAwareJobWith("pending", string(SchedulingStatePending), "test-policy", scheduled),
+				carbonAwareJobWith("completed", string(SchedulingStateCompleted), "test-policy", scheduled),
+				carbonAwareJobWith("other-policy", string(SchedulingStatePending), "other-policy", scheduled),
+			).Build()
+			reconciler := &CarbonAwareJobReconciler{Client: fakeClient, StatusCheckInterval: time.Minute}
+
+			l, err := reconciler.concurrencyLimit(context.Background(), self, p)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(l.maxJobs).To(Equal(2))
+			Expect(l.duration).To(Equal(2 * time.Hour))
+			Expect(l.runs).To(HaveLen(2))
+			Expect(l.saturated()).To(BeFalse())
+			Expect(l.fullAt(scheduled)).To(BeFalse())
+
+			// A job running longer than expected keeps its slot until its status is checked again
+			Expect(l.fullAt(time.Now())).To(BeFalse())
+			l.maxJobs = 1
+			Expect(l.fullAt(time.Now())).To(BeTrue())
+			Expect(l.saturated()).To(BeTrue())
+
+			l, err = reconciler.concurrencyLimit(context.Background(), self, newPolicy(batchv1alpha1.CarbonPolicySpec{}))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(l).To(BeNil())
+		})
+	})
+})
+
+var _ = Describe("Schedule alternatives", func() {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	east := schedulingclient.CloudZone{Provider: "aws", Region: "us-east-1", GridZone: "US-MIDA-PJM"}
+	west := schedulingclient.CloudZone{Provider: "aws", Region: "eu-west-1", GridZone: "IE"}
+	option := func(offset time.Duration, zone schedulingclient.CloudZone, intensity float64) schedulingclient.ScheduleOption {
+		return schedulingclient.ScheduleOption{Time: now.Add(offset), Zone: zone, CO2Intensity: intensity}
+	}
+	scheduleResp := &schedulingclient.ScheduleResponse{
+		Ideal:     option(2*time.Hour, west, 100),
+		NaiveCase: option(0, east, 300),
+		Options: []schedulingclient.ScheduleOption{
+			option(2*time.Hour, west, 100),
+			option(time.Hour, west, 110),
+			option(2*time.Hour, east, 120),
+			option(3*time.Hour, west, 130),
+			option(9*time.Hour, west, 140),
+			option(4*time.Hour, east, 150),
+			option(5*time.Hour, east, 160),
+		},
+	}
+	windowEnd := now.Add(8 * time.Hour)
+
+	It("Should keep the best options after the chosen one that the job may start at", func() {
+		alternatives := scheduleAlternatives(nil, nil, scheduleResp, scheduleResp.Ideal, time.Hour, windowEnd)
+		Expect(alternatives).To(HaveLen(maxScheduleAlternatives))
+		Expect(alternatives[0].Time.Time).To(Equal(now.Add(2 * time.Hour)))
+		Expect(alternatives[0].Region).To(Equal("us-east-1"))
+		Expect(alternatives[0].GridZone).To(Equal("US-MIDA-PJM"))
+		Expect(alternatives[0].CarbonIntensityGramsPerKWh.AsApproximateFloat64()).To(Equal(120.0))
+		Expect(alternatives[1].Time.Time).To(Equal(now.Add(3 * time.Hour)))
+		Expect(alternatives[2].Time.Time).To(Equal(now.Add(4 * time.Hour)))
+
+		// Times the policy forbids and times at the concurrency limit are left out
+		p, err := policy.New(policy.KindCarbonPolicy, "default", "test-policy", batchv1alpha1.CarbonPolicySpec{
+			ForbiddenTimeRanges: []batchv1alpha1.TimeRange{{Start: "15:00", End: "16:00"}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		limit := &concurrencyLimit{maxJobs: 1, duration: 30 * time.Minute, runs: []jobRun{
+			{start: now.Add(4 * time.Hour), end: now.Add(5 * time.Hour)},
+		}}
+		alternatives = scheduleAlternatives(limit, p, scheduleResp, scheduleResp.Ideal, time.Hour, windowEnd)
+		Expect(alternatives).To(HaveLen(2))
+		Expect(alternatives[0].Time.Time).To(Equal(now.Add(2 * time.Hour)))
+		Expect(alternatives[1].Time.Time).To(Equal(now.Add(5 * time.Hour)))
+	})
+
+	It("Should space alternatives in the same region by the job duration", func() {
+		clustered := &schedulingclient.ScheduleResponse{
+			Ideal:     option(2*time.Hour, west, 100),
+			NaiveCase: option(0, east, 300),
+			Candidates: []schedulingclient.ScheduleOption{
+				option(2*time.Hour, west, 100),
+				option(2*time.Hour, east, 120),
+				option(2*time.Hour+5*time.Minute, west, 101),
+				option(2*time.Hour+10*time.Minute, west, 102),
+				option(2*time.Hour+30*time.Minute, east, 121),
+				option(5*time.Hour, west, 130),
+				option(5*time.Hour+5*time.Minute, west, 131),
+				option(6*time.Hour, west, 140),
+			},
+		}
+
+		alternatives := scheduleAlternatives(nil, nil, clustered, clustered.Ideal, 2*time.Hour, windowEnd)
+		Expect(alternatives).To(HaveLen(2))
+		Expect(alternatives[0].Time.Time).To(Equal(now.Add(2 * time.Hour)))
+		Expect(alternatives[0].Region).To(Equal("us-east-1"))
+		Expect(alternatives[1].Time.Time).To(Equal(now.Add(5 * time.Hour)))
+		Expect(alternatives[1].Region).To(Equal("eu-west-1"))
+
+		// Short jobs are still spaced by minAlternativeSpacing
+		alternatives = scheduleAlternatives(nil, nil, clustered, clustered.Ideal, time.Minute, windowEnd)
+		Expect(alternatives).To(HaveLen(3))
+		Expect(alternatives[1].Time.Time).To(Equal(now.Add(2*time.Hour + 30*time.Minute)))
+		Expect(alternatives[2].Time.Time).To(Equal(now.Add(5 * time.Hour)))
+	})
+
+	Context("When the Job cannot be started", func() {
+		var carbonAwareJob *batchv1alpha1.CarbonAwareJob
+
+		BeforeEach(func() {
+			scheduledTime := metav1.NewTime(now.Add(2 * time.Hour))
+			carbonAwareJob = &batchv1alpha1.CarbonAwareJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "alternatives", Namespace: "default"},
+				Status: batchv1alpha1.CarbonAwareJobStatus{
+					ScheduledTime:   &scheduledTime,
+					SchedulingState: string(SchedulingStatePending),
+					SchedulingDecision: &batchv1alpha1.SchedulingDecision{
+						OptimalTime:                   &scheduledTime,
+						Region:                        "eu-west-1",
+						GridZone:                      "IE",
+						WorstCaseIntensityGramsPerKWh: decimalQuantity(400),
+						ImmediateIntensityGramsPerKWh: decimalQuantity(300),
+						MedianIntensityGramsPerKWh:    decimalQuantity(200),
+					},
+					Alternatives: scheduleAlternatives(nil, nil, scheduleResp, scheduleResp.Ideal, time.Hour, windowEnd),
+				},
+			}
+		})
+
+		It("Should move to the next alternative that has not passed", func() {
+			Expect(nextAlternative(carbonAwareJob, now.Add(2*time.Hour))).To(Equal(1))
+			moveToAlternative(carbonAwareJob, 1, "Failed to create Job: quota exceeded", now.Add(2*time.Hour))
+
+			Expect(carbonAwareJob.Status.ScheduledTime.Time).To(Equal(now.Add(3 * time.Hour)))
+			Expect(carbonAwareJob.Status.CarbonIntensity).To(Equal("130.00 gCO2eq/kWh"))
+			Expect(carbonAwareJob.Status.CarbonSavings.VsNaiveCase).To(Equal("-56.67%"))
+			Expect(carbonAwareJob.Status.CarbonSavings.VsMedianCase).To(Equal("-35.00%"))
+			Expect(carbonAwareJob.Status.CarbonSavings.VsWorstCase).To(Equal("-67.50%"))
+			Expect(carbonAwareJob.Status.Alternatives).To(HaveLen(1))
+			Expect(carbonAwareJob.Status.SchedulingDecision.OptimalTime.Time).To(Equal(now.Add(3 * time.Hour)))
+			Expect(carbonAwareJob.Status.SchedulingDecision.Region).To(Equal("eu-west-1"))
+			Expect(carbonAwareJob.Status.ScheduleRevisions).To(HaveLen(1))
+			revision := carbonAwareJob.Status.ScheduleRevisions[0]
+			Expect(revision.PreviousTime.Time).To(Equal(now.Add(2 * time.Hour)))
+			Expect(revision.Reason).To(HavePrefix("Failed to create Job: quota exceeded. Moved to the next best start time at"))
+
+			// The region changes with the alternative
+			moveToAlternative(carbonAwareJob, 0, "Failed to create Job: quota exceeded", now.Add(3*time.Hour))
+			Expect(carbonAwareJob.Status.SchedulingDecision.Region).To(Equal("us-east-1"))
+			Expect(carbonAwareJob.Status.SchedulingDecision.GridZone).To(Equal("US-MIDA-PJM"))
+			Expect(carbonAwareJob.Status.Alternatives).To(BeEmpty())
+			Expect(nextAlternative(carbonAwareJob, now)).To(Equal(-1))
+		})
+
+		It("Should fall through to an alternative instead of retrying", func() {
+			scheme := runtime.NewScheme()
+			Expect(batchv1alpha1.AddToScheme(scheme)).To(Succeed())
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(carbonAwareJob).WithStatusSubresource(carbonAwareJob).Build()
+			reconciler := &CarbonAwareJobReconciler{Client: fakeClient, Recorder: record.NewFakeRecorder(10)}
+
+			// Alternatives in the past are skipped
+			createErr := errors.New("exceeded quota")
+			carbonAwareJob.Status.Alternatives[0].Time = metav1.NewTime(time.Now().Add(-time.Minute))
+			carbonAwareJob.Status.Alternatives[1].Time = metav1.NewTime(time.Now().Add(time.Hour))
+			result, err := reconciler.startFailed(context.Background(), carbonAwareJob, "Failed to create Job: exceeded quota", createErr)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically("~", time.Hour, time.Minute))
+			Expect(carbonAwareJob.Status.Alternatives).To(HaveLen(1))
+			condition := meta.FindStatusCondition(carbonAwareJob.Status.Conditions, ConditionWaiting)
+			Expect(condition.Reason).To(Equal(ReasonAlternativeScheduled))
+
+			// Conflicts are retried at the same time
+			conflict := apierrors.NewConflict(batchv1.Resource("jobs"), "alternatives", createErr)
+			_, err = reconciler.startFailed(context.Background(), carbonAwareJob, "Failed to resume Job", conflict)
+			Expect(err).To(Equal(conflict))
+
+			// Without an alternative left the error is returned to retry
+			carbonAwareJob.Status.Alternatives = nil
+			_, err = reconciler.startFailed(context.Background(), carbonAwareJob, "Failed to create Job: exceeded quota", createErr)
+			Expect(err).To(Equal(createErr))
+		})
+	})
+
+	It("Should recompute savings when a fresh forecast moves the job", func() {
+		scheduledTime := metav1.NewTime(now.Add(2 * time.Hour))
+		carbonAwareJob := &batchv1alpha1.CarbonAwareJob{Status: batchv1alpha1.CarbonAwareJobStatus{
+			ScheduledTime: &scheduledTime,
+			CarbonSavings: newCarbonSavings(75, 66.67, 50),
+			SchedulingDecision: &batchv1alpha1.SchedulingDecision{
+				OptimalTime:                   &scheduledTime,
+				Region:                        "eu-west-1",
+				WorstCaseIntensityGramsPerKWh: decimalQuantity(400),
+				ImmediateIntensityGramsPerKWh: decimalQuantity(300),
+				MedianIntensityGramsPerKWh:    decimalQuantity(200),
+			},
+		}}
+		revised := &schedulingclient.ScheduleResponse{
+			Ideal:      option(4*time.Hour, west, 150),
+			NaiveCase:  option(0, west, 250),
+			MedianCase: option(time.Hour, west, 180),
+			WorstCase:  option(3*time.Hour, west, 260),
+		}
+
+		Expect(applyRevisedSchedule(carbonAwareJob, revised, nil, nil, time.Hour, now, windowEnd)).To(BeTrue())
+		Expect(carbonAwareJob.Status.ScheduledTime.Time).To(Equal(now.Add(4 * time.Hour)))
+		// Savings stay relative to the cases of the original decision
+		Expect(carbonAwareJob.Status.CarbonSavings.VsNaiveCase).To(Equal("-50.00%"))
+		Expect(carbonAwareJob.Status.CarbonSavings.VsMedianCase).To(Equal("-25.00%"))
+		Expect(carbonAwareJob.Status.CarbonSavings.VsWorstCase).To(Equal("-62.50%"))
+
+		// Decisions without known cases use the fresh forecast
+		carbonAwareJob.Status.SchedulingDecision = &batchv1alpha1.SchedulingDecision{ImmediateIntensity: "unknown"}
+		revised.Ideal = option(6*time.Hour, west, 125)
+		Expect(applyRevisedSchedule(carbonAwareJob, revised, nil, nil, time.Hour, now, windowEnd)).To(BeTrue())
+		Expect(carbonAwareJob.Status.CarbonSavings.VsNaiveCase).To(Equal("-50.00%"))
+	})
+
+	It("Should word the revision reason from the intensity change and its cause", func() {
+		scheduledTime := metav1.NewTime(now.Add(2 * time.Hour))
+		carbonAwareJob := &batchv1alpha1.CarbonAwareJob{Status: batchv1alpha1.CarbonAwareJobStatus{
+			ScheduledTime:              &scheduledTime,
+			CarbonIntensityGramsPerKWh: decimalQuantity(120),
+			SchedulingDecision:         &batchv1alpha1.SchedulingDecision{OptimalTime: &scheduledTime, Region: "eu-west-1"},
+		}}
+		revised := &schedulingclient.ScheduleResponse{
+			Ideal:      option(4*time.Hour, west, 100),
+			NaiveCase:  option(0, west, 300),
+			MedianCase: option(time.Hour, west, 200),
+			WorstCase:  option(3*time.Hour, west, 400),
+			Candidates: []schedulingclient.ScheduleOption{
+				option(0, west, 300),
+				option(time.Hour, west, 200),
+				option(3*time.Hour, west, 400),
+				option(4*time.Hour, west, 100),
+			},
+		}
+		lastReason := func() string {
+			return carbonAwareJob.Status.ScheduleRevisions[len(carbonAwareJob.Status.ScheduleRevisions)-1].Reason
+		}
+
+		Expect(applyRevisedSchedule(carbonAwareJob, revised, nil, nil, time.Hour, now, windowEnd)).To(BeTrue())
+		Expect(lastReason()).To(Equal(fmt.Sprintf("Updated forecast found a greener slot at %s (100.00 gCO2eq/kWh, down from 120.00 gCO2eq/kWh)",
+			now.Add(4*time.Hour).UTC().Format(time.RFC3339))))
+
+		// A concurrency limit that is full at the greenest time moves the job to a worse slot
+		limit := &concurrencyLimit{maxJobs: 1, scope: "namespace default", duration: time.Hour, runs: []jobRun{
+			{start: now.Add(4 * time.Hour), end: now.Add(5 * time.Hour)},
+		}}
+		Expect(applyRevisedSchedule(carbonAwareJob, revised, nil, limit, time.Hour, now, windowEnd)).To(BeTrue())
+		Expect(lastReason()).To(Equal(fmt.Sprintf("Updated forecast moved the start to %s (200.00 gCO2eq/kWh, up from 100.00 gCO2eq/kWh). "+
+			"1 jobs of namespace default are already scheduled at that time, starting at the next best time in aws:eu-west-1",
+			now.Add(time.Hour).UTC().Format(time.RFC3339))))
+	})
+
+	It("Should detect Jobs without a ready pod after the start timeout", func() {
+		reconciler := &CarbonAwareJobReconciler{StartTimeout: 10 * time.Minute}
+		carbonAwareJob := &batchv1alpha1.CarbonAwareJob{}
+		ready := int32(0)
+		job := &batchv1.Job{Status: batchv1.JobStatus{StartTime: &metav1.Time{Time: now}, Active: 1, Ready: &ready}}
+		Expect(reconciler.startTimedOut(carbonAwareJob, job, now.Add(5*time.Minute))).To(BeFalse())
+		Expect(reconciler.startTimedOut(carbonAwareJob, job, now.Add(15*time.Minute))).To(BeTrue())
+
+		ready = 1
+		Expect(reconciler.startTimedOut(carbonAwareJob, job, now.Add(15*time.Minute))).To(BeFalse())
+
+		ready = 0
+		job.Status.Succeeded = 1
+		Expect(reconciler.startTimedOut(carbonAwareJob, job, now.Add(15*time.Minute))).To(BeFalse())
+
+		job.Status.Succeeded = 0
+		reconciler.StartTimeout = 0
+		Expect(reconciler.startTimedOut(carbonAwareJob, job, now.Add(15*time.Minute))).To(BeFalse())
+
+		// A Job that was ready once never times out
+		reconciler.StartTimeout = 10 * time.Minute
+		carbonAwareJob.Status.ReadyTime = &metav1.Time{Time: now.Add(time.Minute)}
+		Expect(reconciler.startTimedOut(carbonAwareJob, job, now.Add(15*time.Minute))).To(BeFalse())
+	})
+
+	It("Should not restart a Job that was ready once", func() {
+		started := time.Now().Add(-time.Hour)
+		alternative := metav1.NewTime(time.Now().Add(time.Hour))
+		carbonAwareJob := &batchv1alpha1.CarbonAwareJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "ready-once", Namespace: "default"},
+			Status: batchv1alpha1.CarbonAwareJobStatus{
+				ScheduledTime:   &metav1.Time{Time: started},
+				JobName:         "ready-once-1",
+				SchedulingState: string(SchedulingStateRunning),
+				Alternatives:    []batchv1alpha1.ScheduleAlternative{{Time: alternative, Region: "eu-west-1"}},
+			},
+		}
+		ready := int32(1)
+		job := &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "ready-once-1", Namespace: "default"},
+			Status:     batchv1.JobStatus{StartTime: &metav1.Time{Time: started}, Active: 1, Ready: &ready},
+		}
+
+		scheme := runtime.NewScheme()
+		Expect(batchv1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(batchv1.AddToScheme(scheme)).To(Succeed())
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(carbonAwareJob, job).WithStatusSubresource(carbonAwareJob, job).Build()
+		reconciler := &CarbonAwareJobReconciler{
+			Client:       fakeClient,
+			Recorder:     record.NewFakeRecorder(10),
+			StartTimeout: 10 * time.Minute,
+		}
+
+		_, err := reconciler.handleScheduledJob(context.Background(), carbonAwareJob)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(carbonAwareJob.Status.ReadyTime).NotTo(BeNil())
+
+		// The pod restarts in place and is not ready for a while
+		ready = 0
+		job.Status.Ready = &ready
+		Expect(fakeClient.Status().Update(context.Background(), job)).To(Succeed())
+		_, err = reconciler.handleScheduledJob(context.Background(), carbonAwareJob)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeClient.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "ready-once-1"}, &batchv1.Job{})).To(Succeed())
+		Expect(carbonAwareJob.Status.JobName).To(Equal("ready-once-1"))
+		Expect(carbonAwareJob.Status.SchedulingState).To(Equal(string(SchedulingStateRunning)))
+		Expect(carbonAwareJob.Status.Alternatives).To(HaveLen(1))
+	})
 })
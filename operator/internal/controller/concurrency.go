@@ -0,0 +1,204 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	batchv1alpha1 "github.com/carbon-aware-kube/operator/api/v1alpha1"
+	schedulingclient "github.com/carbon-aware-kube/operator/internal/client"
+	"github.com/carbon-aware-kube/operator/internal/policy"
+)
+
+// concurrencyLimit is the concurrency limit of a CarbonAwareJob's policy together with the
+// expected run times of the other jobs sharing it
+type concurrencyLimit struct {
+	// maxJobs is the number of jobs that may run at the same time
+	maxJobs int
+	// scope describes the jobs sharing the limit, e.g. "CarbonPolicy team-a/default"
+	scope string
+	// duration is the expected run time of the job being scheduled
+	duration time.Duration
+	// runs are the expected run times of the other pending and running jobs
+	runs []jobRun
+}
+
+// jobRun is the expected run time of a CarbonAwareJob
+type jobRun struct {
+	start, end time.Time
+	// started is set once the Job has been created or resumed
+	started bool
+}
+
+// concurrencyLimit returns the concurrency limit of a CarbonAwareJob's policy, or nil if it has none.
+// Jobs share the limit if the same policy was applied to them.
+func (r *CarbonAwareJobReconciler) concurrencyLimit(ctx context.Context, carbonAwareJob *batchv1alpha1.CarbonAwareJob, jobPolicy *policy.Policy) (*concurrencyLimit, error) {
+	if jobPolicy == nil || jobPolicy.Spec.Concurrency == nil {
+		return nil, nil
+	}
+
+	limit := &concurrencyLimit{
+		maxJobs:  int(jobPolicy.Spec.Concurrency.MaxJobs),
+		scope:    jobPolicy.String(),
+		duration: r.jobDuration(carbonAwareJob),
+	}
+	var opts []ctrlclient.ListOption
+	if jobPolicy.Ref.Kind == policy.KindCarbonPolicy {
+		opts = append(opts, ctrlclient.InNamespace(carbonAwareJob.Namespace))
+	} else if jobPolicy.Spec.Concurrency.Scope == batchv1alpha1.ConcurrencyScopeNamespace {
+		opts = append(opts, ctrlclient.InNamespace(carbonAwareJob.Namespace))
+		limit.scope = fmt.Sprintf("%s in namespace %s", jobPolicy, carbonAwareJob.Namespace)
+	}
+
+	var carbonAwareJobs batchv1alpha1.CarbonAwareJobList
+	if err := r.List(ctx, &carbonAwareJobs, opts...); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list CarbonAwareJobs sharing the concurrency limit")
+		return nil, err
+	}
+
+	// A Job that runs longer than expected keeps its slot at least until its status is checked again
+	minEnd := time.Now().Add(r.statusCheckInterval())
+	for i := range carbonAwareJobs.Items {
+		other := &carbonAwareJobs.Items[i]
+		if other.UID == carbonAwareJob.UID || other.Status.ScheduledTime == nil ||
+			other.Status.Policy == nil || *other.Status.Policy != jobPolicy.Ref {
+			continue
+		}
+
+		run := jobRun{start: other.Status.ScheduledTime.Time}
+		switch other.Status.SchedulingState {
+		case string(SchedulingStatePending):
+		case string(SchedulingStateScheduled), string(SchedulingStateRunning):
+			run.started = true
+			if jobStatus := other.Status.JobStatus; jobStatus != nil && jobStatus.StartTime != nil {
+				run.start = jobStatus.StartTime.Time
+			}
+		default:
+			continue
+		}
+		jobPolicy.ApplyDefaults(&other.Spec)
+		run.end = run.start.Add(r.jobDuration(other))
+		if run.started && run.end.Before(minEnd) {
+			run.end = minEnd
+		}
+		limit.runs = append(limit.runs, run)
+	}
+	return limit, nil
+}
+
+// fullAt reports whether a job starting at t would run alongside the maximum number of other jobs
+// at any point of its expected run time
+func (l *concurrencyLimit) fullAt(t time.Time) bool {
+	if l == nil {
+		return false
+	}
+	end := t.Add(l.duration)
+	points := []time.Time{t}
+	for _, run := range l.runs {
+		if run.start.After(t) && run.start.Before(end) {
+			points = append(points, run.start)
+		}
+	}
+	for _, point := range points {
+		active := 0
+		for _, run := range l.runs {
+			if !run.start.After(point) && point.Before(run.end) {
+				active++
+			}
+		}
+		if active >= l.maxJobs {
+			return true
+		}
+	}
+	return false
+}
+
+// saturated reports whether the maximum number of other jobs have been started and not finished
+func (l *concurrencyLimit) saturated() bool {
+	if l == nil {
+		return false
+	}
+	started := 0
+	for _, run := range l.runs {
+		if run.started {
+			started++
+		}
+	}
+	return started >= l.maxJobs
+}
+
+// spreadOption moves the start time of a job to the next best option of a schedule response if
+// the chosen one would exceed the concurrency limit, so that jobs sharing a window do not all start
+// at its greenest time. Options are tried in order of intensity and must be allowed by the policy.
+// The chosen option is kept if every option is full.
+func spreadOption(limit *concurrencyLimit, jobPolicy *policy.Policy, scheduleResp *schedulingclient.ScheduleResponse, chosen schedulingclient.ScheduleOption, reason string, windowEnd time.Time) (schedulingclient.ScheduleOption, string) {
+	if !limit.fullAt(chosen.Time) {
+		return chosen, reason
+	}
+
+	candidates := candidateOptions(scheduleResp)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].CO2Intensity != candidates[j].CO2Intensity {
+			return candidates[i].CO2Intensity < candidates[j].CO2Intensity
+		}
+		return candidates[i].Time.Before(candidates[j].Time)
+	})
+
+	for _, option := range candidates {
+		if !optionAllowed(jobPolicy, scheduleResp.NaiveCase, option, windowEnd) || limit.fullAt(option.Time) {
+			continue
+		}
+		return option, fmt.Sprintf("%s. %d jobs of %s are already scheduled at that time, starting at the next best time in %s",
+			reason, limit.maxJobs, limit.scope, zoneName(option.Zone))
+	}
+	return chosen, reason
+}
+
+// waitForSlot keeps a CarbonAwareJob whose start time has come pending while the maximum number
+// of jobs sharing its concurrency limit are running, and checks again after the status check
+// interval, at the latest at the end of its window
+func (r *CarbonAwareJobReconciler) waitForSlot(ctx context.Context, carbonAwareJob *batchv1alpha1.CarbonAwareJob, limit *concurrencyLimit, windowEnd time.Time) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	logger.Info("Concurrency limit reached, waiting for a free slot", "name", carbonAwareJob.Name, "scope", limit.scope)
+
+	if condition := meta.FindStatusCondition(carbonAwareJob.Status.Conditions, ConditionWaiting); condition == nil || condition.Reason != ReasonConcurrencyLimited {
+		message := fmt.Sprintf("Waiting for one of the %d running jobs of %s to finish, at the latest until %s",
+			limit.maxJobs, limit.scope, windowEnd.UTC().Format(time.RFC3339))
+		r.Recorder.Event(carbonAwareJob, corev1.EventTypeNormal, ReasonConcurrencyLimited, message)
+		setCondition(carbonAwareJob, ConditionWaiting, metav1.ConditionTrue, ReasonConcurrencyLimited, message)
+		if err := r.Status().Update(ctx, carbonAwareJob); err != nil {
+			logger.Error(err, "Failed to update CarbonAwareJob status")
+			return ctrl.Result{}, err
+		}
+	}
+
+	retryInterval := r.statusCheckInterval()
+	if untilWindowEnd := time.Until(windowEnd); untilWindowEnd < retryInterval {
+		retryInterval = untilWindowEnd
+	}
+	return ctrl.Result{RequeueAfter: retryInterval}, nil
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	batchv1alpha1 "github.com/carbon-aware-kube/operator/api/v1alpha1"
+)
+
+// Condition types of a CarbonAwareJob
+const (
+	// ConditionForecastObtained is True when the start time is based on a carbon intensity forecast
+	ConditionForecastObtained = "ForecastObtained"
+
+	// ConditionWaiting is True while the CarbonAwareJob waits for its start time or a forecast
+	ConditionWaiting = "Waiting"
+
+	// ConditionJobCreated is True once the underlying Job has been created
+	ConditionJobCreated = "JobCreated"
+
+	// ConditionComplete is True when the underlying Job has completed successfully
+	ConditionComplete = "Complete"
+
+	// ConditionFailed is True when the underlying Job failed or the CarbonAwareJob cannot run
+	ConditionFailed = "Failed"
+
+	// ConditionDegraded is True when the start time was chosen by the fallback policy
+	ConditionDegraded = "Degraded"
+)
+
+// Reasons used by the events and status conditions of a CarbonAwareJob
+const (
+	// ReasonForecastReceived indicates that a forecast was obtained and a start time was chosen
+	ReasonForecastReceived = "ForecastReceived"
+
+	// ReasonForecastFailed indicates that the forecast could not be obtained and is being retried
+	ReasonForecastFailed = "ForecastFailed"
+
+	// ReasonFallbackApplied indicates that the start time was chosen by the fallback policy
+	ReasonFallbackApplied = "FallbackApplied"
+
+	// ReasonScheduleRevised indicates that an updated forecast moved the start time or region
+	ReasonScheduleRevised = "ScheduleRevised"
+
+	// ReasonPolicyApplied indicates that a CarbonPolicy or ClusterCarbonPolicy was merged into the spec
+	ReasonPolicyApplied = "PolicyApplied"
+
+	// ReasonConcurrencyLimited indicates that the start time has come but the concurrency limit
+	// of the policy is reached
+	ReasonConcurrencyLimited = "ConcurrencyLimited"
+
+	// ReasonAlternativeScheduled indicates that the Job could not be started at the scheduled time
+	// and the start time moved to the next alternative of the forecast
+	ReasonAlternativeScheduled = "AlternativeScheduled"
+
+	// ReasonDeadlineUnmet indicates that the deadline cannot be met by any start time
+	ReasonDeadlineUnmet = "DeadlineUnmet"
+
+	// ReasonJobCreated indicates that the underlying Job was created
+	ReasonJobCreated = "JobCreated"
+
+	// ReasonJobCreateFailed indicates that the underlying Job could not be created
+	ReasonJobCreateFailed = "JobCreateFailed"
+
+	// ReasonJobResumed indicates that the suspended underlying Job was resumed at its start time
+	ReasonJobResumed = "JobResumed"
+
+	// ReasonJobCompleted indicates that the underlying Job completed successfully
+	ReasonJobCompleted = "JobCompleted"
+
+	// ReasonJobFailed indicates that the underlying Job failed
+	ReasonJobFailed = "JobFailed"
+
+	// ReasonJobMissing indicates that the underlying Job was deleted before it finished
+	ReasonJobMissing = "JobMissing"
+
+	// ReasonEmissionsEstimated indicates that the energy and emissions of the finished Job
+	// were estimated
+	ReasonEmissionsEstimated = "EmissionsEstimated"
+
+	// ReasonSchedulingGateRemoved indicates that a gated Pod was released for scheduling
+	ReasonSchedulingGateRemoved = "SchedulingGateRemoved"
+)
+
+// setCondition sets a status condition of a CarbonAwareJob for its current generation
+func setCondition(carbonAwareJob *batchv1alpha1.CarbonAwareJob, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&carbonAwareJob.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: carbonAwareJob.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
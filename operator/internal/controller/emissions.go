@@ -0,0 +1,172 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	batchv1alpha1 "github.com/carbon-aware-kube/operator/api/v1alpha1"
+	schedulingclient "github.com/carbon-aware-kube/operator/internal/client"
+	"github.com/carbon-aware-kube/operator/internal/config"
+)
+
+// estimateEmissions estimates the energy a CarbonAwareJob used from the resource requests of its
+// pods and the actual run time of its finished Job, and the emissions from the carbon intensity
+// reported for that interval. The avoided emissions compare them to running for as long from the
+// time the job was submitted, measured the same way. If the forecast provider cannot report both
+// intensities, the forecast intensities at the scheduled and the submission time are compared
+// instead. If neither is known, only the energy is estimated. Nil is returned if the Job never
+// started.
+func (r *CarbonAwareJobReconciler) estimateEmissions(ctx context.Context, carbonAwareJob *batchv1alpha1.CarbonAwareJob, job *batchv1.Job) *batchv1alpha1.EmissionsEstimate {
+	if job.Status.StartTime == nil {
+		return nil
+	}
+	start := job.Status.StartTime.Time
+	end := jobEndTime(job)
+	runTime := max(end.Sub(start), 0)
+
+	energy := estimatedEnergyKWh(r.powerModel(), carbonAwareJob, runTime)
+	estimate := &batchv1alpha1.EmissionsEstimate{
+		StartTime: metav1.NewTime(start),
+		EndTime:   metav1.NewTime(end),
+		EnergyKWh: *resource.NewScaledQuantity(int64(math.Round(energy*1e6)), resource.Micro),
+	}
+
+	intensity, naive, err := r.realizedIntensities(ctx, carbonAwareJob, start, runTime)
+	naiveKnown := err == nil
+	source := batchv1alpha1.IntensitySourceRealized
+	if err != nil {
+		log.FromContext(ctx).Info("Realized carbon intensity not available, using the forecast", "error", err.Error())
+		var ok bool
+		if intensity, ok = statusIntensity(carbonAwareJob.Status.CarbonIntensityGramsPerKWh, carbonAwareJob.Status.CarbonIntensity); !ok {
+			return estimate
+		}
+		if decision := carbonAwareJob.Status.SchedulingDecision; decision != nil {
+			naive, naiveKnown = statusIntensity(decision.ImmediateIntensityGramsPerKWh, decision.ImmediateIntensity)
+		}
+		source = batchv1alpha1.IntensitySourceForecast
+	}
+	estimate.IntensitySource = source
+	estimate.IntensityGramsPerKWh = decimalQuantity(intensity)
+	estimate.EmittedGrams = decimalQuantity(energy * intensity)
+	if naiveKnown {
+		estimate.AvoidedGrams = decimalQuantity(energy * (naive - intensity))
+	}
+	return estimate
+}
+
+// realizedIntensities asks the forecast provider for the average carbon intensity measured over
+// the interval a job ran, and over an interval as long from its submission time, in the zone it
+// was scheduled in. Clients that only offer forecasts, and decisions without a region, report
+// ErrRealizedIntensityUnavailable.
+func (r *CarbonAwareJobReconciler) realizedIntensities(ctx context.Context, carbonAwareJob *batchv1alpha1.CarbonAwareJob, start time.Time, runTime time.Duration) (float64, float64, error) {
+	historyClient, ok := r.SchedulingClient.(schedulingclient.RealizedIntensityClient)
+	if !ok {
+		return 0, 0, schedulingclient.ErrRealizedIntensityUnavailable
+	}
+	zone, ok := r.decisionZone(ctx, carbonAwareJob)
+	if !ok || carbonAwareJob.Status.SubmissionTime == nil {
+		return 0, 0, schedulingclient.ErrRealizedIntensityUnavailable
+	}
+	runTime = max(runTime, time.Minute)
+
+	intensity, err := historyClient.RealizedIntensity(ctx, zone, start, start.Add(runTime))
+	if err != nil {
+		return 0, 0, err
+	}
+	submitted := carbonAwareJob.Status.SubmissionTime.Time
+	naive, err := historyClient.RealizedIntensity(ctx, zone, submitted, submitted.Add(runTime))
+	if err != nil {
+		return 0, 0, err
+	}
+	return intensity, naive, nil
+}
+
+// decisionZone returns the zone recorded in the scheduling decision of a CarbonAwareJob, which
+// follows it to alternative start times. Decisions of the fallback policy have no zone.
+func (r *CarbonAwareJobReconciler) decisionZone(ctx context.Context, carbonAwareJob *batchv1alpha1.CarbonAwareJob) (schedulingclient.CloudZone, bool) {
+	decision := carbonAwareJob.Status.SchedulingDecision
+	if decision == nil || decision.Region == "" {
+		return schedulingclient.CloudZone{}, false
+	}
+	// Decisions do not record the cloud provider, which is that of the cluster
+	return schedulingclient.CloudZone{
+		Provider: cloudZone(ctx, r.CloudEnvironment).Provider,
+		Region:   decision.Region,
+		GridZone: decision.GridZone,
+	}, true
+}
+
+// jobEndTime returns when a finished Job completed or failed, or the current time if the Job
+// does not report it
+func jobEndTime(job *batchv1.Job) time.Time {
+	if job.Status.CompletionTime != nil {
+		return job.Status.CompletionTime.Time
+	}
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			return condition.LastTransitionTime.Time
+		}
+	}
+	return time.Now()
+}
+
+// estimatedEnergyKWh estimates the energy a CarbonAwareJob uses over duration from the CPU,
+// memory and GPU requests of its pods with a power model. Pods without CPU requests count as
+// one CPU.
+func estimatedEnergyKWh(model config.EmissionsConfig, carbonAwareJob *batchv1alpha1.CarbonAwareJob, duration time.Duration) float64 {
+	podSpec := carbonAwareJob.Spec.Template.Spec.Template.Spec
+	var cpus, memoryGiB, gpus float64
+	for _, container := range podSpec.Containers {
+		cpus += container.Resources.Requests.Cpu().AsApproximateFloat64()
+		memoryGiB += container.Resources.Requests.Memory().AsApproximateFloat64() / (1 << 30)
+		for name, quantity := range container.Resources.Requests {
+			// nvidia.com/gpu, amd.com/gpu, gpu.intel.com/i915 and the like
+			if strings.HasSuffix(string(name), "/gpu") || strings.HasPrefix(string(name), "gpu.") {
+				gpus += quantity.AsApproximateFloat64()
+			}
+		}
+	}
+	if cpus == 0 {
+		cpus = 1
+	}
+
+	pods := 1.0
+	if parallelism := carbonAwareJob.Spec.Template.Spec.Parallelism; parallelism != nil && *parallelism > 1 {
+		pods = float64(*parallelism)
+	}
+
+	watts := (cpus*model.WattsPerCPU + memoryGiB*model.WattsPerGiBMemory + gpus*model.WattsPerGPU) * pods
+	return watts * max(model.PowerUsageEffectiveness, 1) / 1000 * duration.Hours()
+}
+
+// powerModel returns the configured power model, or the default one if none was applied
+func (r *CarbonAwareJobReconciler) powerModel() config.EmissionsConfig {
+	if r.PowerModel == (config.EmissionsConfig{}) {
+		return config.Defaults().Emissions
+	}
+	return r.PowerModel
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sync"
+	"time"
+
+	schedulingclient "github.com/carbon-aware-kube/operator/internal/client"
+)
+
+// lowHourTracker learns, per zone, at which hour of the day (UTC) forecasts most often
+// placed the ideal start time. It backs the HistoricalLow fallback strategy.
+type lowHourTracker struct {
+	mu     sync.Mutex
+	counts map[schedulingclient.CloudZone]*[24]int
+}
+
+// record counts the hour of an ideal start time returned by a forecast for the zone
+func (t *lowHourTracker) record(zone schedulingclient.CloudZone, ideal time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.counts == nil {
+		t.counts = make(map[schedulingclient.CloudZone]*[24]int)
+	}
+	key := schedulingclient.CloudZone{Provider: zone.Provider, Region: zone.Region}
+	hours, ok := t.counts[key]
+	if !ok {
+		hours = &[24]int{}
+		t.counts[key] = hours
+	}
+	hours[ideal.UTC().Hour()]++
+}
+
+// lowHour returns the most frequent ideal hour for the zone, if any forecast was recorded
+func (t *lowHourTracker) lowHour(zone schedulingclient.CloudZone) (int, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	hours, ok := t.counts[schedulingclient.CloudZone{Provider: zone.Provider, Region: zone.Region}]
+	if !ok {
+		return 0, false
+	}
+
+	best := 0
+	for hour, count := range hours {
+		if count > hours[best] {
+			best = hour
+		}
+	}
+	return best, hours[best] > 0
+}
+
+// nextHourOccurrence returns the first time at or after now that falls in the given hour
+// of the day (UTC), or false if that is after windowEnd
+func nextHourOccurrence(hour int, now, windowEnd time.Time) (time.Time, bool) {
+	utcNow := now.UTC()
+	start := time.Date(utcNow.Year(), utcNow.Month(), utcNow.Day(), hour, 0, 0, 0, time.UTC)
+	if start.Before(utcNow) {
+		if utcNow.Sub(start) < time.Hour {
+			// We're already inside the low hour
+			start = utcNow
+		} else {
+			start = start.Add(24 * time.Hour)
+		}
+	}
+	if start.After(windowEnd) {
+		return time.Time{}, false
+	}
+	return start, true
+}
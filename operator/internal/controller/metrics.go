@@ -0,0 +1,243 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	batchv1alpha1 "github.com/carbon-aware-kube/operator/api/v1alpha1"
+	schedulingclient "github.com/carbon-aware-kube/operator/internal/client"
+)
+
+var (
+	jobsByState = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "carbonaware_jobs",
+			Help: "Number of CarbonAwareJobs by scheduling state",
+		},
+		[]string{"state"},
+	)
+
+	schedulingDelay = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "carbonaware_job_scheduling_delay_seconds",
+			Help:    "Delay applied to CarbonAwareJobs, from submission to the scheduled start time",
+			Buckets: []float64{0, 60, 300, 900, 1800, 3600, 7200, 14400, 28800, 43200, 86400, 172800},
+		},
+	)
+
+	scheduledIntensity = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "carbonaware_job_forecast_intensity_gco2eq_per_kwh",
+			Help:    "Forecast carbon intensity of CarbonAwareJobs at the chosen start time (case=\"chosen\") and if run immediately (case=\"naive\")",
+			Buckets: prometheus.LinearBuckets(0, 50, 20),
+		},
+		[]string{"case"},
+	)
+
+	emissionsAvoided = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "carbonaware_emissions_avoided_grams_total",
+			Help: "Estimated gCO2eq avoided by delaying finished CarbonAwareJobs compared to running them when submitted, as in status.emissions.avoidedGrams",
+		},
+		[]string{"namespace"},
+	)
+
+	emissionsIncreased = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "carbonaware_emissions_increased_grams_total",
+			Help: "Estimated gCO2eq added by delaying finished CarbonAwareJobs whose status.emissions.avoidedGrams is negative",
+		},
+		[]string{"namespace"},
+	)
+
+	emissionsEmitted = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "carbonaware_emissions_grams_total",
+			Help: "Estimated gCO2eq emitted by finished CarbonAwareJobs over their actual run time",
+		},
+		[]string{"namespace"},
+	)
+
+	forecastRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "carbonaware_forecast_request_duration_seconds",
+			Help:    "Latency of forecast requests to the scheduler API or forecast provider, including retries",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"zone"},
+	)
+
+	forecastRequestErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "carbonaware_forecast_request_errors_total",
+			Help: "Number of failed forecast requests to the scheduler API or forecast provider",
+		},
+		[]string{"zone"},
+	)
+
+	fallbacks = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "carbonaware_fallbacks_total",
+			Help: "Number of CarbonAwareJobs scheduled by their fallback policy because no forecast was available",
+		},
+		[]string{"strategy"},
+	)
+)
+
+func init() {
+	// Register custom metrics with the global prometheus registry
+	metrics.Registry.MustRegister(
+		jobsByState,
+		schedulingDelay,
+		scheduledIntensity,
+		emissionsAvoided,
+		emissionsIncreased,
+		emissionsEmitted,
+		forecastRequestDuration,
+		forecastRequestErrors,
+		fallbacks,
+	)
+}
+
+// stateTracker keeps the jobsByState gauge in line with the last observed state of every CarbonAwareJob
+type stateTracker struct {
+	mu     sync.Mutex
+	states map[types.NamespacedName]string
+}
+
+// set records the state of a CarbonAwareJob
+func (t *stateTracker) set(key types.NamespacedName, state string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.states == nil {
+		t.states = make(map[types.NamespacedName]string)
+	}
+
+	previous, ok := t.states[key]
+	if ok && previous == state {
+		return
+	}
+	if ok {
+		jobsByState.WithLabelValues(previous).Dec()
+	}
+	t.states[key] = state
+	jobsByState.WithLabelValues(state).Inc()
+}
+
+// forget removes a deleted CarbonAwareJob
+func (t *stateTracker) forget(key types.NamespacedName) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if previous, ok := t.states[key]; ok {
+		jobsByState.WithLabelValues(previous).Dec()
+		delete(t.states, key)
+	}
+}
+
+// observeForecastRequest records the latency and outcome of a forecast request for clients that
+// do not report zones separately. The scheduler API answers for all zones in one request, so the
+// request is recorded once for each zone it asked for, with the latency and outcome of the whole
+// request.
+func observeForecastRequest(zones []schedulingclient.CloudZone, elapsed time.Duration, err error) {
+	observed := make(map[string]bool, len(zones))
+	for _, zone := range zones {
+		label := forecastZoneLabel(zone)
+		if observed[label] {
+			continue
+		}
+		observed[label] = true
+		forecastRequestDuration.WithLabelValues(label).Observe(elapsed.Seconds())
+		if err != nil {
+			forecastRequestErrors.WithLabelValues(label).Inc()
+		}
+	}
+}
+
+// observeForecastZone records the latency and outcome of fetching the forecast of one zone, for
+// clients that fetch zones separately
+func observeForecastZone(zone schedulingclient.CloudZone, elapsed time.Duration, err error) {
+	label := forecastZoneLabel(zone)
+	forecastRequestDuration.WithLabelValues(label).Observe(elapsed.Seconds())
+	if err != nil {
+		forecastRequestErrors.WithLabelValues(label).Inc()
+	}
+}
+
+// forecastZoneLabel returns the zone label of the forecast request metrics
+func forecastZoneLabel(zone schedulingclient.CloudZone) string {
+	return zone.Provider + ":" + zone.Region
+}
+
+// observeScheduleDecision records the delay applied to a CarbonAwareJob and the forecast intensities
+// at the chosen and the naive time
+func observeScheduleDecision(carbonAwareJob *batchv1alpha1.CarbonAwareJob, scheduleResp *schedulingclient.ScheduleResponse) {
+	if carbonAwareJob.Status.SubmissionTime != nil && carbonAwareJob.Status.ScheduledTime != nil {
+		delay := carbonAwareJob.Status.ScheduledTime.Sub(carbonAwareJob.Status.SubmissionTime.Time)
+		schedulingDelay.Observe(max(delay, 0).Seconds())
+	}
+	if scheduleResp != nil {
+		if chosen, ok := statusIntensity(carbonAwareJob.Status.CarbonIntensityGramsPerKWh, carbonAwareJob.Status.CarbonIntensity); ok {
+			scheduledIntensity.WithLabelValues("chosen").Observe(chosen)
+		}
+		scheduledIntensity.WithLabelValues("naive").Observe(scheduleResp.NaiveCase.CO2Intensity)
+	}
+}
+
+// observeEmissions adds the emissions estimated for a CarbonAwareJob whose Job has finished, as
+// recorded in its status. Counters cannot decrease, so avoided emissions that are negative are
+// added to emissionsIncreased instead.
+func observeEmissions(carbonAwareJob *batchv1alpha1.CarbonAwareJob) {
+	emissions := carbonAwareJob.Status.Emissions
+	if emissions == nil {
+		return
+	}
+	if emissions.EmittedGrams != nil {
+		emissionsEmitted.WithLabelValues(carbonAwareJob.Namespace).Add(emissions.EmittedGrams.AsApproximateFloat64())
+	}
+	if emissions.AvoidedGrams != nil {
+		if avoided := emissions.AvoidedGrams.AsApproximateFloat64(); avoided >= 0 {
+			emissionsAvoided.WithLabelValues(carbonAwareJob.Namespace).Add(avoided)
+		} else {
+			emissionsIncreased.WithLabelValues(carbonAwareJob.Namespace).Add(-avoided)
+		}
+	}
+}
+
+// statusIntensity returns an intensity from its numeric status field, or parses its display
+// string for CarbonAwareJobs scheduled before the numeric fields were added
+func statusIntensity(quantity *resource.Quantity, display string) (float64, bool) {
+	if quantity != nil {
+		return quantity.AsApproximateFloat64(), true
+	}
+	return parseIntensity(display)
+}
+
+// parseIntensity parses an intensity formatted as "123.45 gCO2eq/kWh"
+func parseIntensity(s string) (float64, bool) {
+	value, _, _ := strings.Cut(s, " ")
+	intensity, err := strconv.ParseFloat(value, 64)
+	return intensity, err == nil
+}
@@ -0,0 +1,260 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+
+	batchv1alpha1 "github.com/carbon-aware-kube/operator/api/v1alpha1"
+	schedulingclient "github.com/carbon-aware-kube/operator/internal/client"
+	cloudinfo "github.com/carbon-aware-kube/operator/internal/introspection"
+	"github.com/carbon-aware-kube/operator/internal/policy"
+)
+
+const (
+	// regionLabel is the well-known node label holding the cloud region
+	regionLabel = "topology.kubernetes.io/region"
+
+	// zoneLabel is the well-known node label holding the availability zone
+	zoneLabel = "topology.kubernetes.io/zone"
+)
+
+// placement is the resolved set of locations a CarbonAwareJob may run in
+type placement struct {
+	// zones are the cloud zones to request forecasts for, in order of preference
+	zones []schedulingclient.CloudZone
+	// allowedZones maps each allowed region to the availability zones listed for it in the spec
+	allowedZones map[string][]string
+	// restricted is set when the spec constrains placement and the Job needs node affinity
+	restricted bool
+}
+
+// resolvePlacement returns the zones a CarbonAwareJob may run in. Only the nodes its pod
+// template's nodeSelector and required node affinity allow are considered. Jobs without a
+// placement run in the regions of those nodes, or in the configured cloud zone if it is
+// overridden or no node matches.
+func (r *CarbonAwareJobReconciler) resolvePlacement(ctx context.Context, carbonAwareJob *batchv1alpha1.CarbonAwareJob) (*placement, error) {
+	defaultZone := cloudZone(ctx, r.CloudEnvironment)
+	podSpec := &carbonAwareJob.Spec.Template.Spec.Template.Spec
+	spec := carbonAwareJob.Spec.Placement
+	if spec == nil {
+		if r.OverrideCloudEnvironment || r.Nodes == nil {
+			return &placement{zones: []schedulingclient.CloudZone{defaultZone}}, nil
+		}
+		return nodePlacement(defaultZone, r.Nodes.Locations(podSpec)), nil
+	}
+
+	var nodeLocations []cloudinfo.CloudEnvironment
+	if spec.AnyNodeRegion || len(spec.Zones) > 0 {
+		if r.Nodes != nil {
+			nodeLocations = r.Nodes.Locations(podSpec)
+		} else {
+			var err error
+			if nodeLocations, err = cloudinfo.DetectNodeLocations(ctx, r.Client); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return buildPlacement(spec, defaultZone, nodeLocations)
+}
+
+// restrictPlacement resolves the placement of a CarbonAwareJob and restricts it to the regions
+// its policy allows
+func (r *CarbonAwareJobReconciler) restrictPlacement(ctx context.Context, carbonAwareJob *batchv1alpha1.CarbonAwareJob, jobPolicy *policy.Policy) (*placement, error) {
+	p, err := r.resolvePlacement(ctx, carbonAwareJob)
+	if err != nil {
+		return nil, err
+	}
+	return p.allowedBy(jobPolicy)
+}
+
+// allowedBy returns the placement without the regions a policy does not allow. A Job that could
+// have run in a region that is not allowed is pinned to the allowed ones.
+func (p *placement) allowedBy(jobPolicy *policy.Policy) (*placement, error) {
+	var zones []schedulingclient.CloudZone
+	var regions []string
+	for _, zone := range p.zones {
+		regions = append(regions, zone.Region)
+		if jobPolicy.RegionAllowed(zone.Region) {
+			zones = append(zones, zone)
+		}
+	}
+	if len(zones) == len(p.zones) {
+		return p, nil
+	}
+	if len(zones) == 0 {
+		return nil, fmt.Errorf("none of the regions %v is allowed by %s", regions, jobPolicy)
+	}
+
+	allowed := &placement{
+		zones:        zones,
+		allowedZones: make(map[string][]string, len(zones)),
+		restricted:   true,
+	}
+	for _, zone := range zones {
+		allowed.allowedZones[zone.Region] = p.allowedZones[zone.Region]
+	}
+	return allowed, nil
+}
+
+// nodePlacement returns the placement of a Job without a placement spec from the locations of
+// the nodes it can run on. A Job whose nodes are all in one region is left to the Kubernetes
+// scheduler. A Job whose nodes span regions is pinned to the region chosen from the forecast,
+// so that it starts where the forecast applies. Without nodes, the Job runs in defaultZone.
+func nodePlacement(defaultZone schedulingclient.CloudZone, nodeLocations []cloudinfo.CloudEnvironment) *placement {
+	p, err := buildPlacement(&batchv1alpha1.Placement{AnyNodeRegion: true}, defaultZone, nodeLocations)
+	if err != nil {
+		return &placement{zones: []schedulingclient.CloudZone{defaultZone}}
+	}
+	p.restricted = len(p.zones) > 1
+	return p
+}
+
+// podZone returns the zone to request forecasts for a Pod that cannot be moved to another
+// region: the location of most of the nodes it can run on, or the configured cloud zone if it
+// is overridden or no node matches
+func podZone(ctx context.Context, nodes *cloudinfo.NodeInventory, env *cloudinfo.CloudEnvironment, override bool, podSpec *corev1.PodSpec) schedulingclient.CloudZone {
+	defaultZone := cloudZone(ctx, env)
+	if override || nodes == nil {
+		return defaultZone
+	}
+	return nodePlacement(defaultZone, nodes.Locations(podSpec)).zones[0]
+}
+
+// buildPlacement resolves a placement spec against the locations of the cluster's nodes
+func buildPlacement(spec *batchv1alpha1.Placement, defaultZone schedulingclient.CloudZone, nodeLocations []cloudinfo.CloudEnvironment) (*placement, error) {
+	p := &placement{
+		allowedZones: make(map[string][]string),
+		restricted:   true,
+	}
+
+	addRegion := func(provider, region string) {
+		if _, ok := p.allowedZones[region]; ok {
+			return
+		}
+		if provider == "" || provider == cloudinfo.ProviderUnknown {
+			provider = defaultZone.Provider
+		}
+		p.allowedZones[region] = nil
+		p.zones = append(p.zones, schedulingclient.CloudZone{Provider: provider, Region: region})
+	}
+
+	for _, region := range spec.Regions {
+		addRegion(defaultZone.Provider, region)
+	}
+	for _, loc := range nodeLocations {
+		if slices.Contains(spec.Zones, loc.Zone) {
+			addRegion(loc.Provider, loc.Region)
+			if !slices.Contains(p.allowedZones[loc.Region], loc.Zone) {
+				p.allowedZones[loc.Region] = append(p.allowedZones[loc.Region], loc.Zone)
+			}
+		} else if spec.AnyNodeRegion {
+			addRegion(loc.Provider, loc.Region)
+		}
+	}
+
+	if len(p.zones) == 0 {
+		return nil, fmt.Errorf("placement does not match any region: regions %v, zones %v", spec.Regions, spec.Zones)
+	}
+	return p, nil
+}
+
+// nodeSelectorTerms returns the node affinity terms that pin a Job to region, or to any allowed
+// region if region is empty or not allowed. Nil is returned if placement is unrestricted.
+func (p *placement) nodeSelectorTerms(region string) []corev1.NodeSelectorTerm {
+	if !p.restricted {
+		return nil
+	}
+
+	regions := []string{region}
+	if _, ok := p.allowedZones[region]; !ok {
+		regions = regions[:0]
+		for _, zone := range p.zones {
+			regions = append(regions, zone.Region)
+		}
+	}
+
+	terms := make([]corev1.NodeSelectorTerm, 0, len(regions))
+	for _, region := range regions {
+		term := corev1.NodeSelectorTerm{
+			MatchExpressions: []corev1.NodeSelectorRequirement{{
+				Key:      p.regionKey(region),
+				Operator: corev1.NodeSelectorOpIn,
+				Values:   []string{region},
+			}},
+		}
+		if zones := p.allowedZones[region]; len(zones) > 0 {
+			term.MatchExpressions = append(term.MatchExpressions, corev1.NodeSelectorRequirement{
+				Key:      zoneLabel,
+				Operator: corev1.NodeSelectorOpIn,
+				Values:   zones,
+			})
+		}
+		terms = append(terms, term)
+	}
+	return terms
+}
+
+// regionKey returns the node label holding a region of the placement. Grid zones of on-prem
+// nodes are held by the grid region label, cloud regions by the well-known region label.
+func (p *placement) regionKey(region string) string {
+	for _, zone := range p.zones {
+		if zone.Region == region && zone.Provider == cloudinfo.ProviderGrid {
+			return cloudinfo.GridRegionLabel
+		}
+	}
+	return regionLabel
+}
+
+// addRequiredNodeAffinity restricts a pod to nodes matching one of terms, in addition to any
+// required node affinity it already has. Terms are ORed, so each existing term is combined
+// with each new one.
+func addRequiredNodeAffinity(podSpec *corev1.PodSpec, terms []corev1.NodeSelectorTerm) {
+	if len(terms) == 0 {
+		return
+	}
+	if podSpec.Affinity == nil {
+		podSpec.Affinity = &corev1.Affinity{}
+	}
+	if podSpec.Affinity.NodeAffinity == nil {
+		podSpec.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	nodeAffinity := podSpec.Affinity.NodeAffinity
+	if nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{}
+	}
+	selector := nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+
+	if len(selector.NodeSelectorTerms) == 0 {
+		selector.NodeSelectorTerms = terms
+		return
+	}
+
+	combined := make([]corev1.NodeSelectorTerm, 0, len(selector.NodeSelectorTerms)*len(terms))
+	for _, existing := range selector.NodeSelectorTerms {
+		for _, term := range terms {
+			merged := *existing.DeepCopy()
+			merged.MatchExpressions = append(merged.MatchExpressions, term.MatchExpressions...)
+			combined = append(combined, merged)
+		}
+	}
+	selector.NodeSelectorTerms = combined
+}
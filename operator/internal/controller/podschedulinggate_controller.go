@@ -0,0 +1,302 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	batchv1alpha1 "github.com/carbon-aware-kube/operator/api/v1alpha1"
+	schedulingclient "github.com/carbon-aware-kube/operator/internal/client"
+	"github.com/carbon-aware-kube/operator/internal/config"
+	"github.com/carbon-aware-kube/operator/internal/gridzone"
+	cloudinfo "github.com/carbon-aware-kube/operator/internal/introspection"
+)
+
+// PodSchedulingGateReconciler releases Pods gated by the pod webhook at the start time chosen
+// from the forecast. The start time is chosen like that of a CarbonAwareJob whose MaxDelay and
+// MaxDuration are taken from the Pod's annotations, and is recorded on the Pod so that it
+// survives restarts of the operator.
+type PodSchedulingGateReconciler struct {
+	ctrlclient.Client
+	// Recorder emits events on the gated Pods
+	Recorder record.EventRecorder
+	// SchedulingClient is a client for fetching carbon intensity forecasts
+	SchedulingClient schedulingclient.SchedulingClientInterface
+	// CloudEnvironment is the cloud environment of Pods whose nodes do not report one, or of
+	// all Pods if OverrideCloudEnvironment is set
+	CloudEnvironment *cloudinfo.CloudEnvironment
+	// OverrideCloudEnvironment requests forecasts for CloudEnvironment instead of the region
+	// of the nodes a Pod can run on
+	OverrideCloudEnvironment bool
+	// Nodes groups the cluster's nodes by location. If nil, CloudEnvironment is used
+	Nodes *cloudinfo.NodeInventory
+	// GridZones maps cloud regions to the grid zones sent with forecast requests
+	GridZones *gridzone.Table
+	// DefaultMaxDuration is the expected run time of Pods without the max-duration annotation
+	DefaultMaxDuration time.Duration
+	// ForecastRetryInterval is how often a failed forecast is retried
+	ForecastRetryInterval time.Duration
+	// ForecastRetryCutoff is how long before the end of the window the controller stops
+	// retrying the forecast and releases the Pod
+	ForecastRetryCutoff time.Duration
+	// Config provides the operator configuration and its reloads. The fields above are kept if nil
+	Config *config.Watcher
+
+	// settings guards the fields updated by ApplyConfig. Reconcile works on a snapshot, which
+	// must copy every field above.
+	settings sync.RWMutex
+}
+
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;update;patch
+
+// Reconcile chooses the start time of a gated Pod and removes the scheduling gate once it has come
+func (r *PodSchedulingGateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	// The settings are copied rather than locked for the whole reconciliation, so that a reload
+	// does not wait for forecast requests
+	return r.snapshot().reconcile(ctx, req)
+}
+
+// snapshot returns a copy of the reconciler with the settings in effect
+func (r *PodSchedulingGateReconciler) snapshot() *PodSchedulingGateReconciler {
+	r.settings.RLock()
+	defer r.settings.RUnlock()
+	return &PodSchedulingGateReconciler{
+		Client:                   r.Client,
+		Recorder:                 r.Recorder,
+		SchedulingClient:         r.SchedulingClient,
+		CloudEnvironment:         r.CloudEnvironment,
+		OverrideCloudEnvironment: r.OverrideCloudEnvironment,
+		Nodes:                    r.Nodes,
+		GridZones:                r.GridZones,
+		DefaultMaxDuration:       r.DefaultMaxDuration,
+		ForecastRetryInterval:    r.ForecastRetryInterval,
+		ForecastRetryCutoff:      r.ForecastRetryCutoff,
+		Config:                   r.Config,
+	}
+}
+
+// reconcile handles a gated Pod with the settings of a snapshot
+func (r *PodSchedulingGateReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var pod corev1.Pod
+	if err := r.Get(ctx, req.NamespacedName, &pod); err != nil {
+		return ctrl.Result{}, ctrlclient.IgnoreNotFound(err)
+	}
+	if !hasSchedulingGate(&pod) || !pod.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	// Keep a start time chosen earlier rather than asking for a new forecast
+	now := time.Now()
+	if value, ok := pod.Annotations[annotationScheduledTime]; ok {
+		if scheduledTime, err := time.Parse(time.RFC3339, value); err == nil {
+			if now.Before(scheduledTime) {
+				return ctrl.Result{RequeueAfter: scheduledTime.Sub(now)}, nil
+			}
+			return ctrl.Result{}, r.releasePod(ctx, &pod, fmt.Sprintf("Start time %s reached", value))
+		}
+	}
+
+	carbonAwareJob, err := podCarbonAwareJob(&pod, r.DefaultMaxDuration)
+	if err != nil {
+		// The webhook rejects invalid annotations, so this Pod was changed afterwards. Running
+		// it is better than holding it back forever.
+		return ctrl.Result{}, r.releasePod(ctx, &pod, fmt.Sprintf("%v. Scheduling immediately.", err))
+	}
+	jobDuration := carbonAwareJob.Spec.MaxDuration.Duration
+	_, windowEnd, err := schedulingWindow(carbonAwareJob, pod.CreationTimestamp.Time, jobDuration)
+	if err != nil || !now.Before(windowEnd) {
+		return ctrl.Result{}, r.releasePod(ctx, &pod, "Scheduling window has ended")
+	}
+
+	zone := podZone(ctx, r.Nodes, r.CloudEnvironment, r.OverrideCloudEnvironment, &pod.Spec)
+	scheduleResp, err := getOptimalSchedule(ctx, r.SchedulingClient, r.GridZones, now, windowEnd.Sub(now), jobDuration,
+		[]schedulingclient.CloudZone{zone})
+	if err != nil {
+		logger.Error(err, "Failed to get optimal schedule for Pod")
+
+		retryCutoff := windowEnd.Add(-r.ForecastRetryCutoff)
+		if now.Before(retryCutoff) {
+			r.Recorder.Event(&pod, corev1.EventTypeWarning, ReasonForecastFailed, fmt.Sprintf(
+				"Failed to get forecast: %v. Retrying until %s.", err, retryCutoff.UTC().Format(time.RFC3339)))
+			retryInterval := r.ForecastRetryInterval
+			if retryInterval <= 0 {
+				retryInterval = defaultForecastRetryInterval
+			}
+			if untilCutoff := retryCutoff.Sub(now); untilCutoff < retryInterval {
+				retryInterval = untilCutoff
+			}
+			return ctrl.Result{RequeueAfter: retryInterval}, nil
+		}
+
+		message := fmt.Sprintf("Failed to get forecast: %v. Scheduling immediately.", err)
+		r.Recorder.Event(&pod, corev1.EventTypeWarning, ReasonFallbackApplied, message)
+		return ctrl.Result{}, r.releasePod(ctx, &pod, message)
+	}
+
+	// Choose the start time with the same strategy as a CarbonAwareJob
+	chosen, decisionReason := selectOption(carbonAwareJob, scheduleResp)
+	startTime := chosen.Time
+	if startTime.After(windowEnd) {
+		startTime = windowEnd
+	}
+	if startTime.Before(now) {
+		startTime = now
+	}
+	scheduledTime := metav1.NewTime(startTime)
+	carbonAwareJob.Status.ScheduledTime = &scheduledTime
+	carbonAwareJob.Status.CarbonIntensity = formatIntensity(chosen.CO2Intensity)
+	carbonAwareJob.Status.CarbonSavings = carbonSavingsFor(scheduleResp, chosen.CO2Intensity)
+	carbonAwareJob.Status.SchedulingDecision = &batchv1alpha1.SchedulingDecision{GridZone: chosen.Zone.GridZone}
+	setScheduleAnnotations(&pod, carbonAwareJob)
+
+	r.Recorder.Event(&pod, corev1.EventTypeNormal, ReasonForecastReceived, fmt.Sprintf(
+		"%s: starting at %s at %s, %s vs running immediately", decisionReason,
+		startTime.UTC().Format(time.RFC3339), carbonAwareJob.Status.CarbonIntensity,
+		carbonAwareJob.Status.CarbonSavings.VsNaiveCase))
+
+	if !now.Before(startTime) {
+		return ctrl.Result{}, r.releasePod(ctx, &pod, "Starting immediately")
+	}
+	if err := r.Update(ctx, &pod); err != nil {
+		logger.Error(err, "Failed to record start time on Pod")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: time.Until(startTime)}, nil
+}
+
+// releasePod removes the carbon-aware scheduling gate from a Pod, together with any pending
+// changes to its annotations. The gated label goes with it, which drops the Pod from the cache.
+func (r *PodSchedulingGateReconciler) releasePod(ctx context.Context, pod *corev1.Pod, message string) error {
+	gates := pod.Spec.SchedulingGates[:0]
+	for _, gate := range pod.Spec.SchedulingGates {
+		if gate.Name != batchv1alpha1.PodSchedulingGate {
+			gates = append(gates, gate)
+		}
+	}
+	pod.Spec.SchedulingGates = gates
+	delete(pod.Labels, batchv1alpha1.LabelSchedulingGated)
+
+	if err := r.Update(ctx, pod); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to remove scheduling gate from Pod")
+		return err
+	}
+	r.Recorder.Event(pod, corev1.EventTypeNormal, ReasonSchedulingGateRemoved, message)
+	return nil
+}
+
+// podCarbonAwareJob describes a gated Pod as a CarbonAwareJob, so that its window and start
+// time are derived by the same logic
+func podCarbonAwareJob(pod *corev1.Pod, defaultMaxDuration time.Duration) (*batchv1alpha1.CarbonAwareJob, error) {
+	maxDelay, err := time.ParseDuration(pod.Annotations[batchv1alpha1.AnnotationMaxDelay])
+	if err != nil || maxDelay <= 0 {
+		return nil, fmt.Errorf("invalid annotation %s %q", batchv1alpha1.AnnotationMaxDelay,
+			pod.Annotations[batchv1alpha1.AnnotationMaxDelay])
+	}
+
+	maxDuration := defaultMaxDuration
+	if value, ok := pod.Annotations[batchv1alpha1.AnnotationMaxDuration]; ok {
+		if maxDuration, err = time.ParseDuration(value); err != nil || maxDuration <= 0 {
+			return nil, fmt.Errorf("invalid annotation %s %q", batchv1alpha1.AnnotationMaxDuration, value)
+		}
+	}
+	if maxDuration <= 0 {
+		maxDuration = batchv1alpha1.DefaultMaxDuration
+	}
+
+	return &batchv1alpha1.CarbonAwareJob{
+		ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace},
+		Spec: batchv1alpha1.CarbonAwareJobSpec{
+			MaxDelay:    metav1.Duration{Duration: maxDelay},
+			MaxDuration: &metav1.Duration{Duration: maxDuration},
+		},
+	}, nil
+}
+
+// hasSchedulingGate reports whether a Pod is held back by the carbon-aware scheduling gate
+func hasSchedulingGate(pod *corev1.Pod) bool {
+	for _, gate := range pod.Spec.SchedulingGates {
+		if gate.Name == batchv1alpha1.PodSchedulingGate {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyConfig updates the settings that can change while the operator is running, and rebuilds
+// the scheduling client for the configured forecast provider. It waits for the reconciliation in
+// progress to finish.
+func (r *PodSchedulingGateReconciler) ApplyConfig(cfg *config.OperatorConfig) {
+	if err := r.applyConfig(cfg); err != nil {
+		ctrl.Log.Error(err, "Failed to create the forecast client of the Pod scheduling gates, keeping the previous one")
+	}
+}
+
+// applyConfig applies a configuration under the settings lock. The other settings are applied
+// even if the scheduling client cannot be created.
+func (r *PodSchedulingGateReconciler) applyConfig(cfg *config.OperatorConfig) error {
+	r.settings.Lock()
+	defer r.settings.Unlock()
+	r.DefaultMaxDuration = cfg.Jobs.DefaultMaxDuration.Duration
+	r.ForecastRetryInterval = cfg.Forecast.RetryInterval.Duration
+	r.ForecastRetryCutoff = cfg.Forecast.RetryCutoff.Duration
+	r.CloudEnvironment, r.OverrideCloudEnvironment = configuredCloudEnvironment(cfg)
+
+	schedulingClient, _, err := newSchedulingClient(cfg)
+	if err != nil {
+		return err
+	}
+	r.SchedulingClient = schedulingClient
+	return nil
+}
+
+// GatedPodCacheOptions restricts the manager's Pod cache to Pods labelled by the webhook, so
+// the operator does not hold every Pod in the cluster in memory
+func GatedPodCacheOptions() map[ctrlclient.Object]cache.ByObject {
+	return map[ctrlclient.Object]cache.ByObject{
+		&corev1.Pod{}: {Label: labels.SelectorFromSet(labels.Set{batchv1alpha1.LabelSchedulingGated: "true"})},
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager. The manager's Pod cache must be
+// restricted with GatedPodCacheOptions.
+func (r *PodSchedulingGateReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.Config != nil {
+		r.Config.Subscribe(r.ApplyConfig)
+		if err := r.applyConfig(r.Config.Current()); err != nil {
+			return err
+		}
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("podschedulinggate").
+		For(&corev1.Pod{}).
+		Complete(r)
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	batchv1alpha1 "github.com/carbon-aware-kube/operator/api/v1alpha1"
+	schedulingclient "github.com/carbon-aware-kube/operator/internal/client"
+)
+
+var _ = Describe("PodSchedulingGate Controller", func() {
+	const podName = "test-gated-pod"
+
+	var (
+		namespacedName types.NamespacedName
+		testNS         *corev1.Namespace
+		reconciler     *PodSchedulingGateReconciler
+		idealTime      time.Time
+	)
+
+	BeforeEach(func() {
+		testNS = &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-ns-",
+			},
+		}
+		Expect(k8sClient.Create(ctx, testNS)).To(Succeed())
+		namespacedName = types.NamespacedName{Name: podName, Namespace: testNS.Name}
+
+		idealTime = time.Now().Add(time.Hour).Truncate(time.Second)
+		reconciler = &PodSchedulingGateReconciler{
+			Client:   k8sClient,
+			Recorder: record.NewFakeRecorder(100),
+			SchedulingClient: &schedulingclient.MockSchedulingClient{
+				MockGetOptimalSchedule: func(_ context.Context, startTime time.Time, maxDelay time.Duration, _ time.Duration, locations []schedulingclient.CloudZone) (*schedulingclient.ScheduleResponse, error) {
+					ideal := schedulingclient.ScheduleOption{Time: idealTime, Zone: locations[0], CO2Intensity: 100}
+					naive := schedulingclient.ScheduleOption{Time: startTime, Zone: locations[0], CO2Intensity: 200}
+					return &schedulingclient.ScheduleResponse{Ideal: ideal, NaiveCase: naive, WorstCase: naive, MedianCase: naive}, nil
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(ctx, testNS)).To(Succeed())
+	})
+
+	It("Should hold the Pod until the optimal time and then remove the gate", func() {
+		By("Creating a gated Pod with a four hour window")
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        podName,
+				Namespace:   testNS.Name,
+				Annotations: map[string]string{batchv1alpha1.AnnotationMaxDelay: "4h"},
+				Labels:      map[string]string{batchv1alpha1.LabelSchedulingGated: "true"},
+			},
+			Spec: corev1.PodSpec{
+				Containers:      []corev1.Container{{Name: "test", Image: "test:latest"}},
+				RestartPolicy:   corev1.RestartPolicyNever,
+				SchedulingGates: []corev1.PodSchedulingGate{{Name: batchv1alpha1.PodSchedulingGate}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, pod)).To(Succeed())
+
+		result, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: namespacedName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically("~", time.Hour, time.Minute))
+
+		Expect(k8sClient.Get(ctx, namespacedName, pod)).To(Succeed())
+		Expect(hasSchedulingGate(pod)).To(BeTrue())
+		Expect(pod.Annotations).To(HaveKeyWithValue(annotationScheduledTime, idealTime.UTC().Format(time.RFC3339)))
+		Expect(pod.Annotations).To(HaveKeyWithValue("carbonaware.dev/carbon-savings-pct", "-50.00%"))
+
+		By("Releasing the Pod once the recorded start time has passed")
+		pod.Annotations[annotationScheduledTime] = time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+		Expect(k8sClient.Update(ctx, pod)).To(Succeed())
+
+		_, err = reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: namespacedName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(k8sClient.Get(ctx, namespacedName, pod)).To(Succeed())
+		Expect(hasSchedulingGate(pod)).To(BeFalse())
+		Expect(pod.Labels).NotTo(HaveKey(batchv1alpha1.LabelSchedulingGated))
+	})
+
+	It("Should describe a Pod by its annotations", func() {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+			batchv1alpha1.AnnotationMaxDelay: "6h",
+		}}}
+		carbonAwareJob, err := podCarbonAwareJob(pod, 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(carbonAwareJob.Spec.MaxDelay.Duration).To(Equal(6 * time.Hour))
+		Expect(carbonAwareJob.Spec.MaxDuration.Duration).To(Equal(batchv1alpha1.DefaultMaxDuration))
+
+		pod.Annotations[batchv1alpha1.AnnotationMaxDuration] = "-1h"
+		_, err = podCarbonAwareJob(pod, 0)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,132 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	batchv1alpha1 "github.com/carbon-aware-kube/operator/api/v1alpha1"
+	schedulingclient "github.com/carbon-aware-kube/operator/internal/client"
+	"github.com/carbon-aware-kube/operator/internal/optimizer"
+	"github.com/carbon-aware-kube/operator/internal/policy"
+)
+
+// applyPolicy returns the policy of a CarbonAwareJob that is being scheduled and merges its defaults
+// into the spec. The merged spec is only kept in memory, so that the job's spec stays as submitted.
+// The policy is chosen when the job is first scheduled and recorded in status, later reconciles
+// apply the current settings of the recorded policy.
+func (r *CarbonAwareJobReconciler) applyPolicy(ctx context.Context, carbonAwareJob *batchv1alpha1.CarbonAwareJob) (*policy.Policy, error) {
+	logger := log.FromContext(ctx)
+
+	var jobPolicy *policy.Policy
+	var err error
+	if ref := carbonAwareJob.Status.Policy; ref != nil {
+		jobPolicy, err = policy.Get(ctx, r.Client, *ref, carbonAwareJob.Namespace)
+	} else if carbonAwareJob.Status.SchedulingState != string(SchedulingStatePending) {
+		jobPolicy, err = policy.Resolve(ctx, r.Client, carbonAwareJob, carbonAwareJob.Namespace)
+		if jobPolicy != nil {
+			ref := jobPolicy.Ref
+			carbonAwareJob.Status.Policy = &ref
+			r.Recorder.Event(carbonAwareJob, corev1.EventTypeNormal, ReasonPolicyApplied, fmt.Sprintf("Applying %s", jobPolicy))
+		}
+	}
+	if err != nil {
+		logger.Error(err, "Failed to resolve the CarbonAwareJob's policy")
+		return nil, err
+	}
+
+	jobPolicy.ApplyDefaults(&carbonAwareJob.Spec)
+	return jobPolicy, nil
+}
+
+// applyPolicyConstraints moves a start time chosen from the forecast out of the forbidden time
+// ranges of a policy, and starts the job immediately if the delay saves less than the policy
+// requires. It returns the option to start at and the decision reason.
+func applyPolicyConstraints(jobPolicy *policy.Policy, scheduleResp *schedulingclient.ScheduleResponse, chosen schedulingclient.ScheduleOption, reason string, windowEnd time.Time) (schedulingclient.ScheduleOption, string) {
+	if jobPolicy == nil {
+		return chosen, reason
+	}
+
+	if jobPolicy.Forbidden(chosen.Time) {
+		if option, ok := bestAllowedOption(jobPolicy, scheduleResp, windowEnd); ok {
+			chosen = option
+			reason = fmt.Sprintf("%s. The start time is forbidden by %s, starting at the best allowed time in %s",
+				reason, jobPolicy, zoneName(option.Zone))
+		} else {
+			// No forecast option is allowed, so the intensity at the next allowed time is unknown
+			chosen = schedulingclient.ScheduleOption{
+				Time:         allowedStartTime(jobPolicy, chosen.Time, windowEnd),
+				Zone:         chosen.Zone,
+				CO2Intensity: scheduleResp.MedianCase.CO2Intensity,
+			}
+			reason = fmt.Sprintf("%s. The start time is forbidden by %s, starting at the next allowed time assuming the median intensity",
+				reason, jobPolicy)
+		}
+	}
+
+	naive := scheduleResp.NaiveCase
+	if minSavings := jobPolicy.MinSavingsPercent(); minSavings > 0 && chosen != naive &&
+		!naive.Time.IsZero() && !jobPolicy.Forbidden(naive.Time) {
+		if savings := optimizer.SavingsPct(naive.CO2Intensity, chosen.CO2Intensity); savings < minSavings {
+			return naive, fmt.Sprintf("Delaying would save %.2f%%, less than the %.0f%% required by %s. Starting immediately",
+				savings, minSavings, jobPolicy)
+		}
+	}
+	return chosen, reason
+}
+
+// bestAllowedOption returns the option of a schedule response with the lowest intensity that
+// starts within the window at a time the policy allows. Ties are broken by the earlier time.
+func bestAllowedOption(jobPolicy *policy.Policy, scheduleResp *schedulingclient.ScheduleResponse, windowEnd time.Time) (schedulingclient.ScheduleOption, bool) {
+	var best schedulingclient.ScheduleOption
+	found := false
+	for _, option := range candidateOptions(scheduleResp) {
+		if option.Time.IsZero() || option.Time.After(windowEnd) || jobPolicy.Forbidden(option.Time) {
+			continue
+		}
+		if !found || option.CO2Intensity < best.CO2Intensity ||
+			(option.CO2Intensity == best.CO2Intensity && option.Time.Before(best.Time)) {
+			best, found = option, true
+		}
+	}
+	return best, found
+}
+
+// optionAllowed reports whether a job may start at an option: within the window, at a time the
+// policy allows and, unless the option is the naive case, saving at least the policy's minimum
+func optionAllowed(jobPolicy *policy.Policy, naive, option schedulingclient.ScheduleOption, windowEnd time.Time) bool {
+	if option.Time.IsZero() || option.Time.After(windowEnd) || jobPolicy.Forbidden(option.Time) {
+		return false
+	}
+	minSavings := jobPolicy.MinSavingsPercent()
+	return minSavings <= 0 || option == naive || optimizer.SavingsPct(naive.CO2Intensity, option.CO2Intensity) >= minSavings
+}
+
+// allowedStartTime returns the earliest time at or after t at which the policy allows a job to
+// start, but no later than the end of the job's window
+func allowedStartTime(jobPolicy *policy.Policy, t, windowEnd time.Time) time.Time {
+	start := jobPolicy.NextAllowed(t)
+	if start.After(windowEnd) {
+		return windowEnd
+	}
+	return start
+}
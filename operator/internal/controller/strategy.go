@@ -0,0 +1,221 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	batchv1alpha1 "github.com/carbon-aware-kube/operator/api/v1alpha1"
+	schedulingclient "github.com/carbon-aware-kube/operator/internal/client"
+	"github.com/carbon-aware-kube/operator/internal/optimizer"
+)
+
+// strategyFallback is recorded as the strategy of decisions made by the fallback policy
+const strategyFallback = "Fallback"
+
+// schedulingStrategy returns the scheduling strategy of a CarbonAwareJob, defaulting to Optimal
+func schedulingStrategy(carbonAwareJob *batchv1alpha1.CarbonAwareJob) batchv1alpha1.SchedulingStrategyType {
+	if carbonAwareJob.Spec.Strategy != nil && carbonAwareJob.Spec.Strategy.Type != "" {
+		return carbonAwareJob.Spec.Strategy.Type
+	}
+	return batchv1alpha1.StrategyOptimal
+}
+
+// selectOption chooses the start time from a schedule response according to the strategy of a
+// CarbonAwareJob and returns it with a human readable reason. The Threshold and
+// FirstBelowPercentile strategies pick the earliest start time in the window at or below their
+// limit, and the ideal option if no start time reaches it or the response has no timeline to
+// search.
+func selectOption(carbonAwareJob *batchv1alpha1.CarbonAwareJob, scheduleResp *schedulingclient.ScheduleResponse) (schedulingclient.ScheduleOption, string) {
+	ideal := scheduleResp.Ideal
+	optimal := fmt.Sprintf("Optimal time determined for %s based on carbon intensity forecast", zoneName(ideal.Zone))
+
+	strategy := carbonAwareJob.Spec.Strategy
+	if needsTimeline(schedulingStrategy(carbonAwareJob)) && len(scheduleResp.Candidates) == 0 {
+		return ideal, fmt.Sprintf("The %s strategy needs every start time of the forecast, which the scheduler does not report. %s",
+			schedulingStrategy(carbonAwareJob), optimal)
+	}
+	switch schedulingStrategy(carbonAwareJob) {
+	case batchv1alpha1.StrategyThreshold:
+		if strategy.ThresholdIntensity == nil {
+			return ideal, optimal
+		}
+		threshold := float64(*strategy.ThresholdIntensity)
+		if option, ok := firstOptionAtOrBelow(scheduleResp, threshold); ok {
+			return option, fmt.Sprintf("First time the forecast for %s is at or below %d gCO2eq/kWh",
+				zoneName(option.Zone), *strategy.ThresholdIntensity)
+		}
+		return ideal, fmt.Sprintf("Forecast never reaches %d gCO2eq/kWh within the window. %s",
+			*strategy.ThresholdIntensity, optimal)
+	case batchv1alpha1.StrategyFirstBelowPercentile:
+		if strategy.Percentile == nil {
+			return ideal, optimal
+		}
+		limit := percentileIntensity(scheduleResp, float64(*strategy.Percentile))
+		if option, ok := firstOptionAtOrBelow(scheduleResp, limit); ok {
+			return option, fmt.Sprintf("First time the forecast for %s is at or below its %dth percentile (%.2f gCO2eq/kWh)",
+				zoneName(option.Zone), *strategy.Percentile, limit)
+		}
+		return ideal, optimal
+	}
+
+	return ideal, optimal
+}
+
+// needsTimeline reports whether a strategy searches every start time of the forecast in
+// chronological order, which only in-process forecast providers report
+func needsTimeline(strategy batchv1alpha1.SchedulingStrategyType) bool {
+	return strategy == batchv1alpha1.StrategyThreshold || strategy == batchv1alpha1.StrategyFirstBelowPercentile
+}
+
+// firstOptionAtOrBelow returns the earliest start time of a schedule response whose intensity is
+// at or below limit. Ties in time are broken by intensity.
+func firstOptionAtOrBelow(scheduleResp *schedulingclient.ScheduleResponse, limit float64) (schedulingclient.ScheduleOption, bool) {
+	for _, option := range scheduleResp.Candidates {
+		if option.CO2Intensity <= limit {
+			return option, true
+		}
+	}
+	return schedulingclient.ScheduleOption{}, false
+}
+
+// timeline returns every start time a schedule response evaluated in chronological order. A
+// response without candidates, such as the scheduler's, falls back to its known options.
+func timeline(scheduleResp *schedulingclient.ScheduleResponse) []schedulingclient.ScheduleOption {
+	if len(scheduleResp.Candidates) > 0 {
+		return scheduleResp.Candidates
+	}
+
+	var options []schedulingclient.ScheduleOption
+	seen := map[string]bool{}
+	for _, option := range candidateOptions(scheduleResp) {
+		key := fmt.Sprintf("%d/%s", option.Time.UnixNano(), zoneName(option.Zone))
+		if option.Time.IsZero() || seen[key] {
+			continue
+		}
+		seen[key] = true
+		options = append(options, option)
+	}
+	schedulingclient.SortChronologically(options)
+	return options
+}
+
+// candidateOptions returns every option of a schedule response a job could start at: the naive,
+// ideal, median and worst cases followed by the ranked options. Options without a time are unknown.
+func candidateOptions(scheduleResp *schedulingclient.ScheduleResponse) []schedulingclient.ScheduleOption {
+	return append([]schedulingclient.ScheduleOption{
+		scheduleResp.NaiveCase,
+		scheduleResp.Ideal,
+		scheduleResp.MedianCase,
+		scheduleResp.WorstCase,
+	}, scheduleResp.Options...)
+}
+
+// percentileIntensity returns the given percentile of the intensities of every start time in the
+// window, interpolating linearly between the closest ranks
+func percentileIntensity(scheduleResp *schedulingclient.ScheduleResponse, percentile float64) float64 {
+	options := scheduleResp.Candidates
+	if len(options) == 0 {
+		return scheduleResp.Ideal.CO2Intensity
+	}
+
+	intensities := make([]float64, len(options))
+	for i, option := range options {
+		intensities[i] = option.CO2Intensity
+	}
+	sort.Float64s(intensities)
+
+	rank := math.Min(math.Max(percentile, 0), 100) / 100 * float64(len(intensities)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return intensities[lower] + (intensities[upper]-intensities[lower])*(rank-float64(lower))
+}
+
+// carbonSavingsFor reports the savings of starting at an option with the given intensity
+// compared to the worst, naive and median cases of a schedule response
+func carbonSavingsFor(scheduleResp *schedulingclient.ScheduleResponse, intensity float64) *batchv1alpha1.CarbonSavings {
+	return newCarbonSavings(
+		optimizer.SavingsPct(scheduleResp.WorstCase.CO2Intensity, intensity),
+		optimizer.SavingsPct(scheduleResp.NaiveCase.CO2Intensity, intensity),
+		optimizer.SavingsPct(scheduleResp.MedianCase.CO2Intensity, intensity),
+	)
+}
+
+// decisionSavings reports the savings of starting at an option with the given intensity compared
+// to the worst, naive and median cases a scheduling decision was made against. Nil is returned if
+// the worst or naive case is unknown, e.g. for decisions of the fallback policy, and savings
+// against an unknown median case are reported as zero.
+func decisionSavings(decision *batchv1alpha1.SchedulingDecision, intensity float64) *batchv1alpha1.CarbonSavings {
+	if decision == nil {
+		return nil
+	}
+	worst, ok := statusIntensity(decision.WorstCaseIntensityGramsPerKWh, decision.WorstCaseIntensity)
+	if !ok {
+		return nil
+	}
+	naive, ok := statusIntensity(decision.ImmediateIntensityGramsPerKWh, decision.ImmediateIntensity)
+	if !ok {
+		return nil
+	}
+	var median float64
+	if decision.MedianIntensityGramsPerKWh != nil {
+		median = decision.MedianIntensityGramsPerKWh.AsApproximateFloat64()
+	}
+	return newCarbonSavings(
+		optimizer.SavingsPct(worst, intensity),
+		optimizer.SavingsPct(naive, intensity),
+		optimizer.SavingsPct(median, intensity),
+	)
+}
+
+// newCarbonSavings reports savings percentages both for display and as numbers
+func newCarbonSavings(vsWorstCase, vsNaiveCase, vsMedianCase float64) *batchv1alpha1.CarbonSavings {
+	return &batchv1alpha1.CarbonSavings{
+		VsWorstCase:         formatSavings(vsWorstCase),
+		VsNaiveCase:         formatSavings(vsNaiveCase),
+		VsMedianCase:        formatSavings(vsMedianCase),
+		VsWorstCasePercent:  decimalQuantity(vsWorstCase),
+		VsNaiveCasePercent:  decimalQuantity(vsNaiveCase),
+		VsMedianCasePercent: decimalQuantity(vsMedianCase),
+	}
+}
+
+// formatSavings formats a savings percentage as a change in emissions, e.g. "-12.00%". An option
+// that is worse than the reference shows as an increase, e.g. "+3.00%"
+func formatSavings(pct float64) string {
+	return fmt.Sprintf("%+.2f%%", -pct)
+}
+
+// formatIntensity formats a carbon intensity for display, e.g. "123.45 gCO2eq/kWh"
+func formatIntensity(intensity float64) string {
+	return fmt.Sprintf("%.2f gCO2eq/kWh", intensity)
+}
+
+// decimalQuantity rounds a value such as an intensity, a percentage or grams to three decimal
+// places for the numeric status fields
+func decimalQuantity(value float64) *resource.Quantity {
+	return resource.NewMilliQuantity(int64(math.Round(value*1000)), resource.DecimalSI)
+}
+
+// zoneName formats a cloud zone as provider:region
+func zoneName(zone schedulingclient.CloudZone) string {
+	return fmt.Sprintf("%s:%s", zone.Provider, zone.Region)
+}
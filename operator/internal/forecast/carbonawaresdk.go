@@ -0,0 +1,55 @@
+package forecast
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// carbonAwareSDK queries the current forecast of a Green Software Foundation Carbon Aware SDK
+// WebAPI deployment. Locations are the SDK's location names, e.g. "eastus".
+type carbonAwareSDK struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+type carbonAwareSDKForecast struct {
+	Location     string `json:"location"`
+	ForecastData []struct {
+		Timestamp time.Time `json:"timestamp"`
+		Duration  int       `json:"duration"`
+		Value     float64   `json:"value"`
+	} `json:"forecastData"`
+}
+
+func (p *carbonAwareSDK) Name() string {
+	return ProviderCarbonAwareSDK
+}
+
+func (p *carbonAwareSDK) Forecast(ctx context.Context, location string, start, end time.Time) ([]Point, error) {
+	query := url.Values{
+		"location":    {location},
+		"dataStartAt": {start.UTC().Format(time.RFC3339)},
+		"dataEndAt":   {end.UTC().Format(time.RFC3339)},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/emissions/forecasts/current?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var body []carbonAwareSDKForecast
+	if err := doJSON(p.httpClient, req, &body); err != nil {
+		return nil, err
+	}
+	if len(body) == 0 {
+		return nil, fmt.Errorf("no forecast returned for location %q", location)
+	}
+
+	points := make([]Point, 0, len(body[0].ForecastData))
+	for _, d := range body[0].ForecastData {
+		points = append(points, Point{Time: d.Timestamp, Intensity: d.Value})
+	}
+	return points, nil
+}
@@ -0,0 +1,152 @@
+package forecast
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	schedulingclient "github.com/carbon-aware-kube/operator/internal/client"
+	"github.com/carbon-aware-kube/operator/internal/optimizer"
+)
+
+// Client computes carbon-aware schedules in-process from the forecasts of a Provider
+type Client struct {
+	Provider Provider
+	// Locations maps "provider:region" or a grid zone to the provider's location code. Regions
+	// that are not listed are passed to the provider as their grid zone to providers that use
+	// grid zones, as "provider:region" to providers keyed by zone, and unchanged otherwise
+	Locations map[string]string
+	// NumOptions is the number of ranked options returned in ScheduleResponse.Options. Zero
+	// uses optimizer.DefaultNumOptions
+	NumOptions int
+	// ObserveZone, if set, is called after the forecast of each zone has been fetched, with the
+	// time the fetch took and its error, if any
+	ObserveZone func(zone schedulingclient.CloudZone, elapsed time.Duration, err error)
+}
+
+// Ensure Client implements SchedulingClientInterface and RealizedIntensityClient
+var (
+	_ schedulingclient.SchedulingClientInterface = (*Client)(nil)
+	_ schedulingclient.RealizedIntensityClient   = (*Client)(nil)
+)
+
+// NewClient creates a scheduling client backed by provider
+func NewClient(provider Provider, locations map[string]string) *Client {
+	return &Client{
+		Provider:  provider,
+		Locations: locations,
+	}
+}
+
+// ObservesZones reports whether forecast fetches are reported per zone through ObserveZone
+func (c *Client) ObservesZones() bool {
+	return c.ObserveZone != nil
+}
+
+// location returns the provider location code for a cloud zone
+func (c *Client) location(zone schedulingclient.CloudZone) string {
+	key := zone.Provider + ":" + zone.Region
+	if location, ok := c.Locations[key]; ok {
+		return location
+	}
+	if zone.GridZone != "" {
+		if location, ok := c.Locations[zone.GridZone]; ok {
+			return location
+		}
+		if p, ok := c.Provider.(gridZoneProvider); ok && p.UsesGridZones() {
+			return zone.GridZone
+		}
+	}
+	if p, ok := c.Provider.(zoneKeyedProvider); ok && p.KeyedByZone() {
+		return key
+	}
+	return zone.Region
+}
+
+// GetOptimalSchedule fetches the forecast of every zone and optimizes the start time within
+// [startTime, startTime+maxDelay] in-process. Zones whose forecast cannot be fetched are
+// skipped unless all of them fail.
+func (c *Client) GetOptimalSchedule(ctx context.Context, startTime time.Time, maxDelay time.Duration, jobDuration time.Duration, locations []schedulingclient.CloudZone) (*schedulingclient.ScheduleResponse, error) {
+	windowEnd := startTime.Add(maxDelay)
+
+	var (
+		series []optimizer.Series
+		errs   []error
+	)
+	for _, zone := range locations {
+		fetchStart := time.Now()
+		points, err := c.Provider.Forecast(ctx, c.location(zone), startTime, windowEnd.Add(jobDuration))
+		if err != nil {
+			err = fmt.Errorf("%s forecast for %s:%s: %w", c.Provider.Name(), zone.Provider, zone.Region, err)
+		} else if len(points) == 0 {
+			err = fmt.Errorf("%s forecast for %s:%s is empty", c.Provider.Name(), zone.Provider, zone.Region)
+		}
+		if c.ObserveZone != nil {
+			c.ObserveZone(zone, time.Since(fetchStart), err)
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		series = append(series, optimizer.Series{Zone: zone, Points: points})
+	}
+	if len(series) == 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return optimizer.Optimize(optimizer.Request{
+		Windows:       []schedulingclient.TimeRange{{Start: startTime, End: windowEnd}},
+		Duration:      jobDuration,
+		Series:        series,
+		Interpolation: c.interpolation(),
+		NumOptions:    c.NumOptions,
+	})
+}
+
+// RealizedIntensity returns the average carbon intensity measured in a zone over [start, end]
+// from the provider's history. It fails for providers that only offer forecasts.
+func (c *Client) RealizedIntensity(ctx context.Context, zone schedulingclient.CloudZone, start, end time.Time) (float64, error) {
+	p, ok := c.Provider.(historyProvider)
+	if !ok {
+		return 0, fmt.Errorf("%s does not report historical intensities: %w", c.Provider.Name(), schedulingclient.ErrRealizedIntensityUnavailable)
+	}
+	points, err := p.History(ctx, c.location(zone), start, end)
+	if err != nil {
+		return 0, fmt.Errorf("%s history for %s:%s: %w", c.Provider.Name(), zone.Provider, zone.Region, err)
+	}
+	if len(points) == 0 {
+		return 0, fmt.Errorf("%s history for %s:%s is empty: %w", c.Provider.Name(), zone.Provider, zone.Region, schedulingclient.ErrRealizedIntensityUnavailable)
+	}
+
+	// Average over the part of the interval the history covers. A window without delay makes
+	// the naive case the average over it.
+	from, to := optimizer.Coverage(points, c.interpolation())
+	if start.Before(from) {
+		start = from
+	}
+	if end.After(to) {
+		end = to
+	}
+	if end.Before(start) {
+		return 0, fmt.Errorf("%s history for %s:%s does not cover the interval: %w", c.Provider.Name(), zone.Provider, zone.Region, schedulingclient.ErrRealizedIntensityUnavailable)
+	}
+	resp, err := optimizer.Optimize(optimizer.Request{
+		Windows:       []schedulingclient.TimeRange{{Start: start, End: start}},
+		Duration:      end.Sub(start),
+		Series:        []optimizer.Series{{Zone: zone, Points: points}},
+		Interpolation: c.interpolation(),
+	})
+	if err != nil {
+		return 0, err
+	}
+	return resp.NaiveCase.CO2Intensity, nil
+}
+
+// interpolation returns how values between the provider's points are interpolated
+func (c *Client) interpolation() optimizer.Interpolation {
+	if p, ok := c.Provider.(periodProvider); ok && p.FixedPeriods() {
+		return optimizer.Step
+	}
+	return optimizer.Linear
+}
@@ -0,0 +1,197 @@
+package forecast
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	schedulingclient "github.com/carbon-aware-kube/operator/internal/client"
+	"github.com/carbon-aware-kube/operator/internal/optimizer"
+)
+
+// staticProvider returns fixed forecasts per location
+type staticProvider struct {
+	forecasts map[string][]Point
+	locations []string
+}
+
+func (p *staticProvider) Name() string {
+	return "static"
+}
+
+func (p *staticProvider) Forecast(_ context.Context, location string, _, _ time.Time) ([]Point, error) {
+	p.locations = append(p.locations, location)
+	points, ok := p.forecasts[location]
+	if !ok {
+		return nil, errors.New("no forecast")
+	}
+	return points, nil
+}
+
+// periodStaticProvider is a staticProvider whose points are period averages
+type periodStaticProvider struct {
+	*staticProvider
+}
+
+func (p *periodStaticProvider) FixedPeriods() bool {
+	return true
+}
+
+// gridZoneStaticProvider is a staticProvider whose locations are grid zones
+type gridZoneStaticProvider struct {
+	*staticProvider
+}
+
+func (p *gridZoneStaticProvider) UsesGridZones() bool {
+	return true
+}
+
+// historyStaticProvider is a staticProvider that reports its forecasts as measured history
+type historyStaticProvider struct {
+	*staticProvider
+}
+
+func (p *historyStaticProvider) History(ctx context.Context, location string, start, end time.Time) ([]Point, error) {
+	return p.Forecast(ctx, location, start, end)
+}
+
+var _ = Describe("Client", func() {
+	var (
+		ctx      context.Context
+		start    time.Time
+		provider *staticProvider
+		east     = schedulingclient.CloudZone{Provider: "aws", Region: "us-east-1"}
+		west     = schedulingclient.CloudZone{Provider: "aws", Region: "eu-west-1"}
+	)
+
+	hourly := func(values ...float64) []Point {
+		points := make([]Point, len(values))
+		for i, v := range values {
+			points[i] = Point{Time: start.Add(time.Duration(i) * time.Hour), Intensity: v}
+		}
+		return points
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		start = time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+		provider = &staticProvider{forecasts: map[string][]Point{}}
+	})
+
+	It("should compare zones and map regions to provider locations", func() {
+		provider.forecasts["US-MIDA-PJM"] = hourly(400, 400, 400)
+		provider.forecasts["eu-west-1"] = hourly(200, 100, 300)
+
+		client := NewClient(provider, map[string]string{"aws:us-east-1": "US-MIDA-PJM"})
+		resp, err := client.GetOptimalSchedule(ctx, start, 2*time.Hour, time.Hour, []schedulingclient.CloudZone{east, west})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(provider.locations).To(Equal([]string{"US-MIDA-PJM", "eu-west-1"}))
+		// The one hour average of 200 -> 100 -> 300 is lowest when the job starts 20 minutes in
+		Expect(resp.Ideal.Zone).To(Equal(west))
+		Expect(resp.Ideal.Time).To(Equal(start.Add(20 * time.Minute)))
+		Expect(resp.NaiveCase.Zone).To(Equal(east))
+		Expect(resp.Options).To(HaveLen(optimizer.DefaultNumOptions))
+
+		client.NumOptions = 3
+		resp, err = client.GetOptimalSchedule(ctx, start, 2*time.Hour, time.Hour, []schedulingclient.CloudZone{east, west})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Options).To(HaveLen(3))
+		Expect(resp.Options[0]).To(Equal(resp.Ideal))
+	})
+
+	It("should pass grid zones to providers that use them unless a location is configured", func() {
+		provider.forecasts["US-MIDA-PJM"] = hourly(400, 400)
+		provider.forecasts["IE"] = hourly(200, 200)
+		provider.forecasts["PJM_DC"] = hourly(300, 300)
+		eastGrid := schedulingclient.CloudZone{Provider: "aws", Region: "us-east-1", GridZone: "US-MIDA-PJM"}
+		westGrid := schedulingclient.CloudZone{Provider: "aws", Region: "eu-west-1", GridZone: "IE"}
+
+		client := NewClient(&gridZoneStaticProvider{staticProvider: provider}, nil)
+		resp, err := client.GetOptimalSchedule(ctx, start, time.Hour, time.Hour, []schedulingclient.CloudZone{eastGrid, westGrid})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(provider.locations).To(Equal([]string{"US-MIDA-PJM", "IE"}))
+		Expect(resp.Ideal.Zone).To(Equal(westGrid))
+
+		// Other providers get the region, or the location configured for the grid zone
+		provider.locations = nil
+		client = NewClient(provider, map[string]string{"US-MIDA-PJM": "PJM_DC"})
+		// eu-west-1 has no forecast and is skipped
+		_, err = client.GetOptimalSchedule(ctx, start, time.Hour, time.Hour, []schedulingclient.CloudZone{eastGrid, westGrid})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(provider.locations).To(Equal([]string{"PJM_DC", "eu-west-1"}))
+	})
+
+	It("should hold half-hourly periods constant for providers with fixed periods", func() {
+		periods := &periodStaticProvider{staticProvider: provider}
+		provider.forecasts["eu-west-1"] = []Point{
+			{Time: start, Intensity: 100},
+			{Time: start.Add(30 * time.Minute), Intensity: 300},
+		}
+
+		resp, err := NewClient(periods, nil).GetOptimalSchedule(ctx, start, 0, 30*time.Minute, []schedulingclient.CloudZone{west})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Ideal.CO2Intensity).To(Equal(100.0))
+	})
+
+	It("should skip zones without a forecast unless all fail", func() {
+		provider.forecasts["eu-west-1"] = hourly(200, 100)
+
+		resp, err := NewClient(provider, nil).GetOptimalSchedule(ctx, start, time.Hour, time.Hour, []schedulingclient.CloudZone{east, west})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Ideal.Zone).To(Equal(west))
+
+		delete(provider.forecasts, "eu-west-1")
+		_, err = NewClient(provider, nil).GetOptimalSchedule(ctx, start, time.Hour, time.Hour, []schedulingclient.CloudZone{east, west})
+		Expect(err).To(MatchError(ContainSubstring("us-east-1")))
+	})
+
+	It("should report each zone's fetch to the observer", func() {
+		provider.forecasts["eu-west-1"] = hourly(200, 100)
+		failed := map[string]bool{}
+
+		client := NewClient(provider, nil)
+		Expect(client.ObservesZones()).To(BeFalse())
+		client.ObserveZone = func(zone schedulingclient.CloudZone, _ time.Duration, err error) {
+			failed[zone.Region] = err != nil
+		}
+		Expect(client.ObservesZones()).To(BeTrue())
+
+		_, err := client.GetOptimalSchedule(ctx, start, time.Hour, time.Hour, []schedulingclient.CloudZone{east, west})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(failed).To(Equal(map[string]bool{"us-east-1": true, "eu-west-1": false}))
+	})
+
+	It("should average the measured history over an interval", func() {
+		provider.forecasts["eu-west-1"] = hourly(100, 300, 100)
+
+		intensity, err := NewClient(&historyStaticProvider{staticProvider: provider}, nil).RealizedIntensity(ctx, west, start, start.Add(2*time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(intensity).To(BeNumerically("~", 200, 1e-9))
+
+		By("Averaging only the part of the interval the history covers")
+		intensity, err = NewClient(&historyStaticProvider{staticProvider: provider}, nil).RealizedIntensity(ctx, west, start.Add(time.Hour), start.Add(5*time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(intensity).To(BeNumerically("~", 200, 1e-9))
+	})
+
+	It("should not schedule beyond the end of the forecast", func() {
+		provider.forecasts["eu-west-1"] = hourly(300, 200, 100)
+
+		// The lowest intensity is at the end of the forecast, but a job starting there would run
+		// past it
+		resp, err := NewClient(provider, nil).GetOptimalSchedule(ctx, start, 4*time.Hour, time.Hour, []schedulingclient.CloudZone{west})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Ideal.Time).To(Equal(start.Add(time.Hour)))
+		Expect(resp.Candidates[len(resp.Candidates)-1].Time).To(Equal(start.Add(time.Hour)))
+	})
+
+	It("should not report a realized intensity for providers that only forecast", func() {
+		provider.forecasts["eu-west-1"] = hourly(100, 300, 100)
+
+		_, err := NewClient(provider, nil).RealizedIntensity(ctx, west, start, start.Add(2*time.Hour))
+		Expect(err).To(MatchError(schedulingclient.ErrRealizedIntensityUnavailable))
+	})
+})
@@ -0,0 +1,93 @@
+package forecast
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const defaultElectricityMapsURL = "https://api.electricitymap.org"
+
+// electricityMaps queries the Electricity Maps v3 carbon intensity forecast.
+// Locations are Electricity Maps zone keys, e.g. "DE" or "US-MIDA-PJM".
+type electricityMaps struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+type electricityMapsForecast struct {
+	Zone     string `json:"zone"`
+	Forecast []struct {
+		CarbonIntensity float64   `json:"carbonIntensity"`
+		Datetime        time.Time `json:"datetime"`
+	} `json:"forecast"`
+}
+
+type electricityMapsHistory struct {
+	Zone string `json:"zone"`
+	Data []struct {
+		CarbonIntensity *float64  `json:"carbonIntensity"`
+		Datetime        time.Time `json:"datetime"`
+	} `json:"data"`
+}
+
+func (p *electricityMaps) Name() string {
+	return ProviderElectricityMaps
+}
+
+// UsesGridZones reports that locations are Electricity Maps zone keys
+func (p *electricityMaps) UsesGridZones() bool {
+	return true
+}
+
+// Forecast returns the provider's full forecast horizon, which does not accept a time range
+func (p *electricityMaps) Forecast(ctx context.Context, location string, start, end time.Time) ([]Point, error) {
+	query := url.Values{"zone": {location}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/v3/carbon-intensity/forecast?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("auth-token", p.token)
+
+	var body electricityMapsForecast
+	if err := doJSON(p.httpClient, req, &body); err != nil {
+		return nil, err
+	}
+
+	points := make([]Point, 0, len(body.Forecast))
+	for _, f := range body.Forecast {
+		points = append(points, Point{Time: f.Datetime, Intensity: f.CarbonIntensity})
+	}
+	return points, nil
+}
+
+// History returns the measured hourly intensities from the hour containing start up to end.
+// Hours the provider has no value for yet are skipped.
+func (p *electricityMaps) History(ctx context.Context, location string, start, end time.Time) ([]Point, error) {
+	query := url.Values{
+		"zone":  {location},
+		"start": {start.UTC().Truncate(time.Hour).Format(time.RFC3339)},
+		"end":   {end.UTC().Format(time.RFC3339)},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/v3/carbon-intensity/past-range?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("auth-token", p.token)
+
+	var body electricityMapsHistory
+	if err := doJSON(p.httpClient, req, &body); err != nil {
+		return nil, err
+	}
+
+	points := make([]Point, 0, len(body.Data))
+	for _, d := range body.Data {
+		if d.CarbonIntensity != nil {
+			points = append(points, Point{Time: d.Datetime, Intensity: *d.CarbonIntensity})
+		}
+	}
+	return points, nil
+}
@@ -0,0 +1,146 @@
+package forecast
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultNationalGridURL = "https://api.carbonintensity.org.uk"
+
+	// nationalGridTimeFormat is the ISO 8601 format without seconds used by the API
+	nationalGridTimeFormat = "2006-01-02T15:04Z"
+)
+
+// nationalGrid queries the 48 hour forecast of the UK National Grid ESO carbon intensity API.
+// An empty location or "national" selects the GB-wide forecast, a number selects a region ID
+// (1-17) and anything else is treated as an outward postcode, e.g. "RG10".
+type nationalGrid struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+type nationalGridPeriod struct {
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Intensity struct {
+		Forecast float64  `json:"forecast"`
+		Actual   *float64 `json:"actual"`
+	} `json:"intensity"`
+}
+
+func (p *nationalGrid) Name() string {
+	return ProviderNationalGrid
+}
+
+// FixedPeriods reports that forecasts are averages over half-hour periods
+func (p *nationalGrid) FixedPeriods() bool {
+	return true
+}
+
+func (p *nationalGrid) Forecast(ctx context.Context, location string, start, end time.Time) ([]Point, error) {
+	from := url.PathEscape(start.UTC().Format(nationalGridTimeFormat))
+
+	var (
+		path    string
+		periods []nationalGridPeriod
+		err     error
+	)
+	switch {
+	case location == "" || location == "national":
+		path = fmt.Sprintf("/intensity/%s/fw48h", from)
+		var body struct {
+			Data []nationalGridPeriod `json:"data"`
+		}
+		err = p.get(ctx, path, &body)
+		periods = body.Data
+	default:
+		if _, convErr := strconv.Atoi(location); convErr == nil {
+			path = fmt.Sprintf("/regional/intensity/%s/fw48h/regionid/%s", from, location)
+		} else {
+			path = fmt.Sprintf("/regional/intensity/%s/fw48h/postcode/%s", from, url.PathEscape(location))
+		}
+		// The regional endpoints document "data" as an object but may return a one-element array
+		var body struct {
+			Data json.RawMessage `json:"data"`
+		}
+		if err = p.get(ctx, path, &body); err == nil {
+			periods, err = decodeRegionalPeriods(body.Data)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]Point, 0, len(periods))
+	for _, period := range periods {
+		t, err := time.Parse(nationalGridTimeFormat, period.From)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse period start %q: %w", period.From, err)
+		}
+		points = append(points, Point{Time: t, Intensity: period.Intensity.Forecast})
+	}
+	return points, nil
+}
+
+// History returns the measured half-hourly intensities of the GB-wide grid covering [start, end].
+// Periods without a measurement yet are skipped. The regional endpoints only offer forecasts.
+func (p *nationalGrid) History(ctx context.Context, location string, start, end time.Time) ([]Point, error) {
+	if location != "" && location != "national" {
+		return nil, fmt.Errorf("no measured intensities for region %q, only for the national grid", location)
+	}
+
+	path := fmt.Sprintf("/intensity/%s/%s",
+		url.PathEscape(start.UTC().Format(nationalGridTimeFormat)), url.PathEscape(end.UTC().Format(nationalGridTimeFormat)))
+	var body struct {
+		Data []nationalGridPeriod `json:"data"`
+	}
+	if err := p.get(ctx, path, &body); err != nil {
+		return nil, err
+	}
+
+	points := make([]Point, 0, len(body.Data))
+	for _, period := range body.Data {
+		if period.Intensity.Actual == nil {
+			continue
+		}
+		t, err := time.Parse(nationalGridTimeFormat, period.From)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse period start %q: %w", period.From, err)
+		}
+		points = append(points, Point{Time: t, Intensity: *period.Intensity.Actual})
+	}
+	return points, nil
+}
+
+// decodeRegionalPeriods extracts the forecast periods of a regional response
+func decodeRegionalPeriods(data json.RawMessage) ([]nationalGridPeriod, error) {
+	type region struct {
+		Data []nationalGridPeriod `json:"data"`
+	}
+	var single region
+	if err := json.Unmarshal(data, &single); err == nil {
+		return single.Data, nil
+	}
+	var list []region
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("failed to decode regional forecast: %w", err)
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0].Data, nil
+}
+
+func (p *nationalGrid) get(ctx context.Context, path string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	return doJSON(p.httpClient, req, out)
+}
@@ -0,0 +1,153 @@
+package forecast
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/carbon-aware-kube/operator/internal/optimizer"
+)
+
+const (
+	// ProviderScheduler uses the carbon-aware scheduler API, which optimizes server-side
+	ProviderScheduler = "scheduler"
+	// ProviderElectricityMaps uses the Electricity Maps carbon intensity forecast API
+	ProviderElectricityMaps = "electricitymaps"
+	// ProviderWattTime uses the WattTime marginal emissions forecast API
+	ProviderWattTime = "watttime"
+	// ProviderCarbonAwareSDK uses the Green Software Foundation Carbon Aware SDK WebAPI
+	ProviderCarbonAwareSDK = "carbon-aware-sdk"
+	// ProviderNationalGrid uses the UK National Grid ESO carbon intensity API
+	ProviderNationalGrid = "nationalgrid"
+	// ProviderStatic reads forecasts from local CSV or JSON files, e.g. a mounted ConfigMap
+	ProviderStatic = "static"
+)
+
+// Point is a single carbon intensity forecast value
+type Point = optimizer.Point
+
+// Provider fetches carbon intensity forecasts from a data source
+type Provider interface {
+	// Name returns the provider name, e.g. "electricitymaps"
+	Name() string
+	// Forecast returns the forecast for a provider-specific location covering as much of
+	// [start, end] as the provider offers, ordered by time
+	Forecast(ctx context.Context, location string, start, end time.Time) ([]Point, error)
+}
+
+// historyProvider is implemented by providers that report the carbon intensity measured in the
+// past, as opposed to forecasts, so that the emissions of finished jobs can be estimated
+type historyProvider interface {
+	// History returns the measured intensities for a provider-specific location covering as
+	// much of [start, end] as the provider offers, ordered by time
+	History(ctx context.Context, location string, start, end time.Time) ([]Point, error)
+}
+
+// periodProvider is implemented by providers whose points are averages over fixed periods
+// rather than samples, so that their values are held until the next point
+type periodProvider interface {
+	FixedPeriods() bool
+}
+
+// zoneKeyedProvider is implemented by providers whose locations are "provider:region" keys
+// rather than provider-specific location codes
+type zoneKeyedProvider interface {
+	KeyedByZone() bool
+}
+
+// gridZoneProvider is implemented by providers whose locations are grid zones, so that the
+// grid zone of a cloud region can be used when no location is configured for it
+type gridZoneProvider interface {
+	UsesGridZones() bool
+}
+
+// Config selects and configures a forecast provider
+type Config struct {
+	// Name is one of the Provider* constants
+	Name string
+	// BaseURL overrides the provider's public API endpoint. Required for the Carbon Aware SDK
+	BaseURL string
+	// APIToken authenticates against Electricity Maps
+	APIToken string
+	// Username and Password authenticate against WattTime
+	Username string
+	Password string
+	// Path is the file or directory the static provider reads forecasts from
+	Path string
+	// HTTPClient is used for all requests. Defaults to a client with a 10 second timeout
+	HTTPClient *http.Client
+}
+
+// NewProvider creates the provider selected by cfg.Name
+func NewProvider(cfg Config) (Provider, error) {
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	baseURL := func(def string) string {
+		if cfg.BaseURL != "" {
+			return strings.TrimSuffix(cfg.BaseURL, "/")
+		}
+		return def
+	}
+
+	switch cfg.Name {
+	case ProviderElectricityMaps:
+		if cfg.APIToken == "" {
+			return nil, fmt.Errorf("%s requires an API token", cfg.Name)
+		}
+		return &electricityMaps{baseURL: baseURL(defaultElectricityMapsURL), token: cfg.APIToken, httpClient: httpClient}, nil
+	case ProviderWattTime:
+		if cfg.Username == "" || cfg.Password == "" {
+			return nil, fmt.Errorf("%s requires a username and password", cfg.Name)
+		}
+		return &wattTime{baseURL: baseURL(defaultWattTimeURL), username: cfg.Username, password: cfg.Password, httpClient: httpClient}, nil
+	case ProviderCarbonAwareSDK:
+		if cfg.BaseURL == "" {
+			return nil, fmt.Errorf("%s requires the URL of a Carbon Aware SDK WebAPI deployment", cfg.Name)
+		}
+		return &carbonAwareSDK{baseURL: baseURL(""), httpClient: httpClient}, nil
+	case ProviderNationalGrid:
+		return &nationalGrid{baseURL: baseURL(defaultNationalGridURL), httpClient: httpClient}, nil
+	case ProviderStatic:
+		if cfg.Path == "" {
+			return nil, fmt.Errorf("%s requires the path of the forecast files", cfg.Name)
+		}
+		return &static{path: cfg.Path, refreshInterval: defaultStaticRefreshInterval}, nil
+	default:
+		return nil, fmt.Errorf("unknown forecast provider %q", cfg.Name)
+	}
+}
+
+// doJSON sends req and decodes a successful JSON response into out
+func doJSON(httpClient *http.Client, req *http.Request, out any) error {
+	req.Header.Set("Accept", "application/json")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return &statusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// statusError is returned for non-200 responses
+type statusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("API returned non-OK status: %d, body: %s", e.StatusCode, e.Body)
+}
@@ -0,0 +1,235 @@
+package forecast
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestForecast(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Forecast Suite")
+}
+
+// fixtureServer serves recorded API responses from testdata by request path and
+// records the requests it received
+type fixtureServer struct {
+	*httptest.Server
+	fixtures map[string]string
+	requests []*http.Request
+}
+
+func newFixtureServer(fixtures map[string]string) *fixtureServer {
+	s := &fixtureServer{fixtures: fixtures}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.requests = append(s.requests, r)
+		name, ok := s.fixtures[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		body, err := os.ReadFile(filepath.Join("testdata", name))
+		Expect(err).NotTo(HaveOccurred())
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	}))
+	return s
+}
+
+var _ = Describe("Providers", func() {
+	var (
+		ctx    context.Context
+		server *fixtureServer
+		start  time.Time
+		end    time.Time
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		start = time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+		end = start.Add(4 * time.Hour)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("Electricity Maps", func() {
+		It("should parse the forecast and authenticate with the token", func() {
+			server = newFixtureServer(map[string]string{
+				"/v3/carbon-intensity/forecast": "electricitymaps_forecast.json",
+			})
+			provider, err := NewProvider(Config{Name: ProviderElectricityMaps, BaseURL: server.URL, APIToken: "em-token"})
+			Expect(err).NotTo(HaveOccurred())
+
+			points, err := provider.Forecast(ctx, "DE", start, end)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(points).To(HaveLen(4))
+			Expect(points[0]).To(Equal(Point{Time: start, Intensity: 371}))
+			Expect(points[2]).To(Equal(Point{Time: start.Add(2 * time.Hour), Intensity: 298}))
+
+			Expect(server.requests).To(HaveLen(1))
+			Expect(server.requests[0].URL.Query().Get("zone")).To(Equal("DE"))
+			Expect(server.requests[0].Header.Get("auth-token")).To(Equal("em-token"))
+		})
+
+		It("should parse the measured history and skip missing hours", func() {
+			server = newFixtureServer(map[string]string{
+				"/v3/carbon-intensity/past-range": "electricitymaps_past_range.json",
+			})
+			provider, err := NewProvider(Config{Name: ProviderElectricityMaps, BaseURL: server.URL, APIToken: "em-token"})
+			Expect(err).NotTo(HaveOccurred())
+
+			points, err := provider.(historyProvider).History(ctx, "DE", start.Add(20*time.Minute), end)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(points).To(Equal([]Point{
+				{Time: start, Intensity: 302},
+				{Time: start.Add(time.Hour), Intensity: 288},
+			}))
+
+			query := server.requests[0].URL.Query()
+			Expect(query.Get("zone")).To(Equal("DE"))
+			Expect(query.Get("start")).To(Equal("2025-06-01T10:00:00Z"))
+			Expect(query.Get("end")).To(Equal("2025-06-01T14:00:00Z"))
+			Expect(server.requests[0].Header.Get("auth-token")).To(Equal("em-token"))
+		})
+
+		It("should require a token", func() {
+			server = newFixtureServer(nil)
+			_, err := NewProvider(Config{Name: ProviderElectricityMaps})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("WattTime", func() {
+		It("should log in and convert lbs/MWh to gCO2eq/kWh", func() {
+			server = newFixtureServer(map[string]string{
+				"/login":       "watttime_login.json",
+				"/v3/forecast": "watttime_forecast.json",
+			})
+			provider, err := NewProvider(Config{Name: ProviderWattTime, BaseURL: server.URL, Username: "user", Password: "pass"})
+			Expect(err).NotTo(HaveOccurred())
+
+			points, err := provider.Forecast(ctx, "CAISO_NORTH", start, end)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(points).To(HaveLen(3))
+			Expect(points[0].Time).To(BeTemporally("==", start))
+			Expect(points[0].Intensity).To(BeNumerically("~", 453.59, 0.01))
+
+			By("Reusing the token for the next request")
+			_, err = provider.Forecast(ctx, "CAISO_NORTH", start, end)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(server.requests).To(HaveLen(3))
+
+			login, forecast := server.requests[0], server.requests[1]
+			username, password, ok := login.BasicAuth()
+			Expect(ok).To(BeTrue())
+			Expect(username).To(Equal("user"))
+			Expect(password).To(Equal("pass"))
+			Expect(forecast.Header.Get("Authorization")).To(Equal("Bearer wt-test-token"))
+			Expect(forecast.URL.Query().Get("region")).To(Equal("CAISO_NORTH"))
+			Expect(forecast.URL.Query().Get("signal_type")).To(Equal("co2_moer"))
+		})
+	})
+
+	Describe("Carbon Aware SDK", func() {
+		It("should request the window and parse the forecast data", func() {
+			server = newFixtureServer(map[string]string{
+				"/emissions/forecasts/current": "carbonawaresdk_forecast.json",
+			})
+			provider, err := NewProvider(Config{Name: ProviderCarbonAwareSDK, BaseURL: server.URL + "/"})
+			Expect(err).NotTo(HaveOccurred())
+
+			points, err := provider.Forecast(ctx, "eastus", start, end)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(points).To(HaveLen(3))
+			Expect(points[1].Time).To(BeTemporally("==", start.Add(5*time.Minute)))
+			Expect(points[1].Intensity).To(Equal(431.7))
+
+			query := server.requests[0].URL.Query()
+			Expect(query.Get("location")).To(Equal("eastus"))
+			Expect(query.Get("dataStartAt")).To(Equal("2025-06-01T10:00:00Z"))
+			Expect(query.Get("dataEndAt")).To(Equal("2025-06-01T14:00:00Z"))
+		})
+
+		It("should require a URL", func() {
+			server = newFixtureServer(nil)
+			_, err := NewProvider(Config{Name: ProviderCarbonAwareSDK})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("UK National Grid", func() {
+		It("should fetch the national forecast", func() {
+			server = newFixtureServer(map[string]string{
+				"/intensity/2025-06-01T10:00Z/fw48h": "nationalgrid_national.json",
+			})
+			provider, err := NewProvider(Config{Name: ProviderNationalGrid, BaseURL: server.URL})
+			Expect(err).NotTo(HaveOccurred())
+
+			points, err := provider.Forecast(ctx, "national", start, end)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(points).To(Equal([]Point{
+				{Time: start, Intensity: 156},
+				{Time: start.Add(30 * time.Minute), Intensity: 142},
+				{Time: start.Add(time.Hour), Intensity: 121},
+			}))
+		})
+
+		It("should fetch a regional forecast by region ID", func() {
+			server = newFixtureServer(map[string]string{
+				"/regional/intensity/2025-06-01T10:00Z/fw48h/regionid/13": "nationalgrid_regional.json",
+			})
+			provider, err := NewProvider(Config{Name: ProviderNationalGrid, BaseURL: server.URL})
+			Expect(err).NotTo(HaveOccurred())
+
+			points, err := provider.Forecast(ctx, "13", start, end)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(points).To(Equal([]Point{
+				{Time: start, Intensity: 98},
+				{Time: start.Add(30 * time.Minute), Intensity: 87},
+			}))
+		})
+
+		It("should fetch the measured national intensity", func() {
+			server = newFixtureServer(map[string]string{
+				"/intensity/2025-06-01T10:00Z/2025-06-01T14:00Z": "nationalgrid_history.json",
+			})
+			provider, err := NewProvider(Config{Name: ProviderNationalGrid, BaseURL: server.URL})
+			Expect(err).NotTo(HaveOccurred())
+
+			points, err := provider.(historyProvider).History(ctx, "national", start, end)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(points).To(Equal([]Point{
+				{Time: start, Intensity: 160},
+				{Time: start.Add(30 * time.Minute), Intensity: 138},
+			}))
+
+			_, err = provider.(historyProvider).History(ctx, "13", start, end)
+			Expect(err).To(MatchError(ContainSubstring("only for the national grid")))
+		})
+
+		It("should report API errors", func() {
+			server = newFixtureServer(nil)
+			provider, err := NewProvider(Config{Name: ProviderNationalGrid, BaseURL: server.URL})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = provider.Forecast(ctx, "RG10", start, end)
+			Expect(err).To(MatchError(ContainSubstring("404")))
+			Expect(server.requests[0].URL.Path).To(Equal("/regional/intensity/2025-06-01T10:00Z/fw48h/postcode/RG10"))
+		})
+	})
+
+	It("should reject unknown providers", func() {
+		server = newFixtureServer(nil)
+		_, err := NewProvider(Config{Name: "unknown"})
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,207 @@
+package forecast
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultStaticRefreshInterval is how often the static provider checks its files for changes
+const defaultStaticRefreshInterval = 30 * time.Second
+
+// static serves forecasts from CSV and JSON files, e.g. a mounted ConfigMap, without any
+// network calls. Path may be a single file or a directory whose *.csv and *.json files are
+// merged. Files are reloaded when they change.
+//
+// Locations are "provider:region" keys. CSV files need a header with the columns provider,
+// region, time and intensity. JSON files hold a list of
+// {"provider": ..., "region": ..., "points": [{"time": ..., "intensity": ...}]} objects.
+// Times are RFC 3339 and intensities are in gCO2eq/kWh.
+type static struct {
+	path            string
+	refreshInterval time.Duration
+
+	mu          sync.Mutex
+	forecasts   map[string][]Point
+	fingerprint string
+	lastCheck   time.Time
+}
+
+// staticZoneForecast is the JSON representation of one zone's forecast
+type staticZoneForecast struct {
+	Provider string `json:"provider"`
+	Region   string `json:"region"`
+	Points   []struct {
+		Time      time.Time `json:"time"`
+		Intensity float64   `json:"intensity"`
+	} `json:"points"`
+}
+
+func (p *static) Name() string {
+	return ProviderStatic
+}
+
+// KeyedByZone reports that locations are "provider:region" keys
+func (p *static) KeyedByZone() bool {
+	return true
+}
+
+func (p *static) Forecast(ctx context.Context, location string, start, end time.Time) ([]Point, error) {
+	forecasts, err := p.load()
+	if err != nil {
+		return nil, err
+	}
+	points, ok := forecasts[location]
+	if !ok {
+		return nil, fmt.Errorf("no static forecast for %q", location)
+	}
+	return points, nil
+}
+
+// load returns the current forecasts, re-reading the files if they changed since the last check
+func (p *static) load() (map[string][]Point, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.forecasts != nil && time.Since(p.lastCheck) < p.refreshInterval {
+		return p.forecasts, nil
+	}
+	p.lastCheck = time.Now()
+
+	files, fingerprint, err := p.files()
+	if err != nil {
+		return nil, err
+	}
+	if p.forecasts != nil && fingerprint == p.fingerprint {
+		return p.forecasts, nil
+	}
+
+	forecasts := make(map[string][]Point)
+	for _, file := range files {
+		if err := readStaticFile(file, forecasts); err != nil {
+			return nil, fmt.Errorf("failed to read static forecast %s: %w", file, err)
+		}
+	}
+	for _, points := range forecasts {
+		sort.SliceStable(points, func(i, j int) bool { return points[i].Time.Before(points[j].Time) })
+	}
+
+	p.forecasts = forecasts
+	p.fingerprint = fingerprint
+	return p.forecasts, nil
+}
+
+// files lists the forecast files and a fingerprint of their names, sizes and modification times.
+// Symlinks are followed, so that the atomic updates of mounted ConfigMaps are noticed.
+func (p *static) files() ([]string, string, error) {
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return nil, "", err
+	}
+
+	files := []string{p.path}
+	if info.IsDir() {
+		entries, err := os.ReadDir(p.path)
+		if err != nil {
+			return nil, "", err
+		}
+		files = files[:0]
+		for _, entry := range entries {
+			// Skip the hidden ..data and timestamped directories of mounted ConfigMaps
+			if strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+			if ext := filepath.Ext(entry.Name()); ext == ".csv" || ext == ".json" {
+				files = append(files, filepath.Join(p.path, entry.Name()))
+			}
+		}
+	}
+
+	var fingerprint strings.Builder
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, "", err
+		}
+		fmt.Fprintf(&fingerprint, "%s:%d:%d;", file, info.Size(), info.ModTime().UnixNano())
+	}
+	return files, fingerprint.String(), nil
+}
+
+// readStaticFile adds the forecasts of a CSV or JSON file to forecasts
+func readStaticFile(file string, forecasts map[string][]Point) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if filepath.Ext(file) == ".json" {
+		var zones []staticZoneForecast
+		if err := json.NewDecoder(f).Decode(&zones); err != nil {
+			return err
+		}
+		for _, zone := range zones {
+			if zone.Provider == "" || zone.Region == "" {
+				return errors.New("provider and region are required")
+			}
+			key := zone.Provider + ":" + zone.Region
+			for _, point := range zone.Points {
+				forecasts[key] = append(forecasts[key], Point{Time: point.Time, Intensity: point.Intensity})
+			}
+		}
+		return nil
+	}
+	return readStaticCSV(f, forecasts)
+}
+
+// readStaticCSV adds the rows of a CSV file with provider, region, time and intensity columns
+func readStaticCSV(r io.Reader, forecasts map[string][]Point) error {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("failed to read header: %w", err)
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"provider", "region", "time", "intensity"} {
+		if _, ok := columns[name]; !ok {
+			return fmt.Errorf("missing column %q", name)
+		}
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		line, _ := reader.FieldPos(0)
+		t, err := time.Parse(time.RFC3339, record[columns["time"]])
+		if err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		intensity, err := strconv.ParseFloat(record[columns["intensity"]], 64)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		key := record[columns["provider"]] + ":" + record[columns["region"]]
+		forecasts[key] = append(forecasts[key], Point{Time: t, Intensity: intensity})
+	}
+}
@@ -0,0 +1,89 @@
+package forecast
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	schedulingclient "github.com/carbon-aware-kube/operator/internal/client"
+)
+
+var _ = Describe("Static provider", func() {
+	var (
+		ctx   context.Context
+		start time.Time
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		start = time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+	})
+
+	It("should merge the CSV and JSON files of a directory", func() {
+		provider, err := NewProvider(Config{Name: ProviderStatic, Path: "testdata/static"})
+		Expect(err).NotTo(HaveOccurred())
+
+		points, err := provider.Forecast(ctx, "aws:us-east-1", start, start.Add(time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(points).To(Equal([]Point{
+			{Time: start, Intensity: 412.0},
+			{Time: start.Add(time.Hour), Intensity: 380.5},
+		}))
+
+		points, err = provider.Forecast(ctx, "azure:westeurope", start, start.Add(time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(points).To(HaveLen(2))
+
+		_, err = provider.Forecast(ctx, "aws:eu-west-1", start, start.Add(time.Hour))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should be queried by provider and region", func() {
+		provider, err := NewProvider(Config{Name: ProviderStatic, Path: "testdata/static/forecast.csv"})
+		Expect(err).NotTo(HaveOccurred())
+
+		resp, err := NewClient(provider, nil).GetOptimalSchedule(ctx, start, time.Hour, 0, []schedulingclient.CloudZone{
+			{Provider: "aws", Region: "us-east-1"},
+			{Provider: "gcp", Region: "europe-west1"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Ideal.Zone.Region).To(Equal("europe-west1"))
+		Expect(resp.Ideal.CO2Intensity).To(Equal(96.2))
+	})
+
+	It("should reload the files when they change", func() {
+		dir := GinkgoT().TempDir()
+		file := filepath.Join(dir, "forecast.csv")
+		Expect(os.WriteFile(file, []byte("provider,region,time,intensity\naws,us-east-1,2025-06-01T10:00:00Z,100\n"), 0o644)).To(Succeed())
+
+		provider := &static{path: dir}
+		points, err := provider.Forecast(ctx, "aws:us-east-1", start, start.Add(time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(points[0].Intensity).To(Equal(100.0))
+
+		Expect(os.WriteFile(file, []byte("provider,region,time,intensity\naws,us-east-1,2025-06-01T10:00:00Z,200.5\n"), 0o644)).To(Succeed())
+		Expect(os.Chtimes(file, time.Now(), time.Now().Add(time.Minute))).To(Succeed())
+
+		points, err = provider.Forecast(ctx, "aws:us-east-1", start, start.Add(time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(points[0].Intensity).To(Equal(200.5))
+	})
+
+	It("should report malformed files", func() {
+		dir := GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(dir, "forecast.csv"), []byte("region,time\nus-east-1,2025-06-01T10:00:00Z\n"), 0o644)).To(Succeed())
+
+		provider := &static{path: dir}
+		_, err := provider.Forecast(ctx, "aws:us-east-1", start, start.Add(time.Hour))
+		Expect(err).To(MatchError(ContainSubstring("missing column")))
+	})
+
+	It("should require a path", func() {
+		_, err := NewProvider(Config{Name: ProviderStatic})
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,133 @@
+package forecast
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	defaultWattTimeURL = "https://api.watttime.org"
+
+	// wattTimeTokenLifetime is how long a login token is reused. WattTime tokens expire after 30 minutes
+	wattTimeTokenLifetime = 25 * time.Minute
+
+	// wattTimeMaxHorizon is the longest forecast horizon WattTime offers
+	wattTimeMaxHorizon = 72
+
+	// lbsPerMWhToGramsPerKWh converts WattTime's lbs CO2/MWh to gCO2/kWh
+	lbsPerMWhToGramsPerKWh = 453.59237 / 1000
+)
+
+// wattTime queries the WattTime v3 marginal emissions (co2_moer) forecast.
+// Locations are WattTime region abbreviations, e.g. "CAISO_NORTH".
+type wattTime struct {
+	baseURL    string
+	username   string
+	password   string
+	httpClient *http.Client
+
+	mu          sync.Mutex
+	token       string
+	tokenExpiry time.Time
+}
+
+type wattTimeForecast struct {
+	Data []struct {
+		PointTime time.Time `json:"point_time"`
+		Value     float64   `json:"value"`
+	} `json:"data"`
+	Meta struct {
+		Units string `json:"units"`
+	} `json:"meta"`
+}
+
+func (p *wattTime) Name() string {
+	return ProviderWattTime
+}
+
+func (p *wattTime) Forecast(ctx context.Context, location string, start, end time.Time) ([]Point, error) {
+	body, err := p.fetchForecast(ctx, location, end)
+	var statusErr *statusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusUnauthorized {
+		// The token was revoked or expired early, log in again once
+		p.resetToken()
+		body, err = p.fetchForecast(ctx, location, end)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	scale := 1.0
+	if body.Meta.Units == "" || body.Meta.Units == "lbs_co2_per_mwh" {
+		scale = lbsPerMWhToGramsPerKWh
+	}
+	points := make([]Point, 0, len(body.Data))
+	for _, d := range body.Data {
+		points = append(points, Point{Time: d.PointTime, Intensity: d.Value * scale})
+	}
+	return points, nil
+}
+
+func (p *wattTime) fetchForecast(ctx context.Context, location string, end time.Time) (*wattTimeForecast, error) {
+	token, err := p.login(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	horizon := int(math.Ceil(time.Until(end).Hours()))
+	horizon = max(1, min(horizon, wattTimeMaxHorizon))
+	query := url.Values{
+		"region":        {location},
+		"signal_type":   {"co2_moer"},
+		"horizon_hours": {strconv.Itoa(horizon)},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/v3/forecast?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	var body wattTimeForecast
+	if err := doJSON(p.httpClient, req, &body); err != nil {
+		return nil, err
+	}
+	return &body, nil
+}
+
+// login returns a cached token, logging in with basic auth when it has expired
+func (p *wattTime) login(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.token != "" && time.Now().Before(p.tokenExpiry) {
+		return p.token, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/login", nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.SetBasicAuth(p.username, p.password)
+
+	var body struct {
+		Token string `json:"token"`
+	}
+	if err := doJSON(p.httpClient, req, &body); err != nil {
+		return "", fmt.Errorf("failed to log in to WattTime: %w", err)
+	}
+	p.token = body.Token
+	p.tokenExpiry = time.Now().Add(wattTimeTokenLifetime)
+	return p.token, nil
+}
+
+func (p *wattTime) resetToken() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.token = ""
+}
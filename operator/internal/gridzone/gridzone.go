@@ -0,0 +1,107 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package gridzone maps cloud regions to the electricity grid zones their forecasts are for.
+//
+// The built-in table in zones.csv uses Electricity Maps zone keys, e.g. "US-MIDA-PJM" for
+// aws:us-east-1. Entries can be overridden and added with the forecast.gridZones setting of the
+// operator configuration.
+package gridzone
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sync"
+
+	cloudinfo "github.com/carbon-aware-kube/operator/internal/introspection"
+)
+
+//go:embed zones.csv
+var zonesCSV []byte
+
+// builtin is the table parsed from zones.csv, keyed by "provider:region"
+var builtin = mustParse(zonesCSV)
+
+// Table maps "provider:region" keys to grid zones. It is safe for concurrent use.
+type Table struct {
+	mu        sync.RWMutex
+	overrides map[string]string
+}
+
+// NewTable returns a table of the built-in grid zones with overrides taking precedence
+func NewTable(overrides map[string]string) *Table {
+	t := &Table{}
+	t.SetOverrides(overrides)
+	return t
+}
+
+// SetOverrides replaces the entries that take precedence over the built-in grid zones
+func (t *Table) SetOverrides(overrides map[string]string) {
+	copied := make(map[string]string, len(overrides))
+	for key, zone := range overrides {
+		copied[key] = zone
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.overrides = copied
+}
+
+// Lookup returns the grid zone of a cloud region. The region of the grid provider already is a
+// grid zone. A nil table only knows the built-in grid zones.
+func (t *Table) Lookup(provider, region string) (string, bool) {
+	if provider == cloudinfo.ProviderGrid {
+		return region, region != ""
+	}
+
+	key := provider + ":" + region
+	if t != nil {
+		t.mu.RLock()
+		zone, ok := t.overrides[key]
+		t.mu.RUnlock()
+		if ok {
+			return zone, true
+		}
+	}
+	zone, ok := builtin[key]
+	return zone, ok
+}
+
+// mustParse parses a table with the columns provider, region and zone. Lines starting with #
+// are comments.
+func mustParse(data []byte) map[string]string {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comment = '#'
+	reader.FieldsPerRecord = 3
+
+	zones := make(map[string]string)
+	for line := 0; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return zones
+		}
+		if err != nil {
+			panic(fmt.Sprintf("invalid grid zone table: %v", err))
+		}
+		if line == 0 && record[0] == "provider" {
+			continue
+		}
+		zones[record[0]+":"+record[1]] = record[2]
+	}
+}
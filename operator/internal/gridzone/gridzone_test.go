@@ -0,0 +1,82 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gridzone
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	cloudinfo "github.com/carbon-aware-kube/operator/internal/introspection"
+)
+
+func TestGridZone(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "GridZone Suite")
+}
+
+// zoneOf returns the grid zone of a lookup, failing if the region is unknown
+func zoneOf(zone string, ok bool) string {
+	ExpectWithOffset(1, ok).To(BeTrue())
+	return zone
+}
+
+var _ = Describe("Table", func() {
+	It("should map well-known cloud regions to grid zones", func() {
+		table := NewTable(nil)
+		for _, tc := range []struct{ provider, region, zone string }{
+			{"aws", "us-east-1", "US-MIDA-PJM"},
+			{"gcp", "europe-west4", "NL"},
+			{"azure", "swedencentral", "SE-SE3"},
+		} {
+			zone, ok := table.Lookup(tc.provider, tc.region)
+			Expect(ok).To(BeTrue())
+			Expect(zone).To(Equal(tc.zone))
+		}
+
+		_, ok := table.Lookup("aws", "mars-north-1")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should prefer overrides and follow their updates", func() {
+		table := NewTable(map[string]string{"aws:us-east-1": "US-MIDA-DOM", "hetzner:fsn1": "DE"})
+		Expect(zoneOf(table.Lookup("aws", "us-east-1"))).To(Equal("US-MIDA-DOM"))
+		Expect(zoneOf(table.Lookup("hetzner", "fsn1"))).To(Equal("DE"))
+
+		table.SetOverrides(nil)
+		Expect(zoneOf(table.Lookup("aws", "us-east-1"))).To(Equal("US-MIDA-PJM"))
+		_, ok := table.Lookup("hetzner", "fsn1")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should use the region of grid locations as their grid zone", func() {
+		var table *Table
+		Expect(zoneOf(table.Lookup(cloudinfo.ProviderGrid, "DE"))).To(Equal("DE"))
+		Expect(zoneOf(table.Lookup("gcp", "europe-west3"))).To(Equal("DE"))
+	})
+
+	It("should parse the built-in table", func() {
+		// Two comment lines and the header precede the entries
+		Expect(builtin).To(HaveLen(strings.Count(string(zonesCSV), "\n") - 3))
+		for key, zone := range builtin {
+			Expect(key).To(MatchRegexp(`^(aws|gcp|azure):[a-z0-9-]+$`))
+			Expect(zone).To(MatchRegexp(`^[A-Z]{2}(-[A-Z0-9]+)*$`))
+		}
+	})
+})
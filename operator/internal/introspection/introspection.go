@@ -3,19 +3,56 @@ package cloudinfo
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const (
+	// GridRegionLabel is set by users on nodes of on-prem clusters to the grid zone they draw
+	// power from, e.g. "DE" or "US-MIDA-PJM". It takes precedence over the cloud region.
+	GridRegionLabel = "carbonaware.dev/grid-region"
+
+	// ProviderGrid is the provider of locations taken from GridRegionLabel. Their region is a
+	// grid zone rather than a cloud region.
+	ProviderGrid = "grid"
+
+	// ProviderUnknown is the provider of nodes that match no known cloud
+	ProviderUnknown = "unknown"
+)
+
 type CloudEnvironment struct {
-	Provider string // e.g. "gcp", "aws", "azure", "unknown"
+	Provider string // e.g. "gcp", "aws", "azure", "grid", "unknown"
 	Region   string
 	Zone     string
 }
 
+// providerIDPrefixes map the scheme of a node's spec.providerID to its provider. The provider ID
+// is set by the cloud controller manager, so it also identifies the cloud under distributions
+// such as OpenShift that do not add provider specific labels.
+var providerIDPrefixes = []struct {
+	prefix   string
+	provider string
+}{
+	{"aws://", "aws"},
+	{"gce://", "gcp"},
+	{"azure://", "azure"},
+	{"oci://", "oci"},
+	{"ibm://", "ibm"},
+	{"digitalocean://", "digitalocean"},
+	{"linode://", "linode"},
+	{"hcloud://", "hetzner"},
+	{"hrobot://", "hetzner"},
+	{"openstack://", "openstack"},
+	{"vsphere://", "vsphere"},
+	{"kind://", "kind"},
+	{"k3s://", "k3s"},
+}
+
 // DetectCloudEnvironment inspects node labels to infer cloud provider and region.
-// For GCP, this looks at topology.kubernetes.io/region and zone.
+// For GCP, this looks at topology.kubernetes.io/region and zone. The location most nodes are in
+// is returned; use a NodeInventory to follow multi-region clusters as they change.
 func DetectCloudEnvironment(ctx context.Context, k8sClient client.Client) (*CloudEnvironment, error) {
 	nodeList := &corev1.NodeList{}
 	if err := k8sClient.List(ctx, nodeList); err != nil {
@@ -25,20 +62,28 @@ func DetectCloudEnvironment(ctx context.Context, k8sClient client.Client) (*Clou
 		return nil, fmt.Errorf("no nodes found in cluster")
 	}
 
-	// Use first node as representative
-	node := nodeList.Items[0]
-	labels := node.Labels
-
-	region := labels["topology.kubernetes.io/region"]
-	zone := labels["topology.kubernetes.io/zone"]
-
-	provider := detectProvider(labels)
+	// Use the location with the most nodes as representative, the first seen on ties
+	counts := make(map[CloudEnvironment]int)
+	var representative CloudEnvironment
+	for i := range nodeList.Items {
+		loc := nodeLocation(&nodeList.Items[i])
+		counts[loc]++
+		if i == 0 || counts[loc] > counts[representative] {
+			representative = loc
+		}
+	}
+	return &representative, nil
+}
 
-	return &CloudEnvironment{
-		Provider: provider,
-		Region:   region,
-		Zone:     zone,
-	}, nil
+// detectNodeProvider infers the provider of a node from its provider ID, falling back to the
+// labels of managed Kubernetes services
+func detectNodeProvider(labels map[string]string, providerID string) string {
+	for _, p := range providerIDPrefixes {
+		if strings.HasPrefix(providerID, p.prefix) {
+			return p.provider
+		}
+	}
+	return detectProvider(labels)
 }
 
 func detectProvider(labels map[string]string) string {
@@ -49,7 +94,58 @@ func detectProvider(labels map[string]string) string {
 		return "aws"
 	case labels["kubernetes.azure.com/role"] != "":
 		return "azure"
+	case labels["oci.oraclecloud.com/fault-domain"] != "":
+		return "oci"
+	case labels["ibm-cloud.kubernetes.io/worker-id"] != "":
+		return "ibm"
+	case labels["doks.digitalocean.com/node-pool"] != "":
+		return "digitalocean"
+	case labels["lke.linode.com/pool-id"] != "":
+		return "linode"
+	case labels["instance.hetzner.cloud/provided-by"] != "":
+		return "hetzner"
+	case labels["node.kubernetes.io/instance-type"] == "k3s":
+		return "k3s"
 	default:
-		return "unknown"
+		return ProviderUnknown
+	}
+}
+
+// nodeLocation returns the location of a node. Nodes labeled with GridRegionLabel are located
+// in that grid zone, all others in their cloud region.
+func nodeLocation(node *corev1.Node) CloudEnvironment {
+	labels := node.Labels
+	if gridRegion := labels[GridRegionLabel]; gridRegion != "" {
+		return CloudEnvironment{
+			Provider: ProviderGrid,
+			Region:   gridRegion,
+			Zone:     labels["topology.kubernetes.io/zone"],
+		}
+	}
+	return CloudEnvironment{
+		Provider: detectNodeProvider(labels, node.Spec.ProviderID),
+		Region:   labels["topology.kubernetes.io/region"],
+		Zone:     labels["topology.kubernetes.io/zone"],
+	}
+}
+
+// DetectNodeLocations returns the distinct provider/region/zone combinations of the
+// cluster's nodes, in the order they were first seen. Nodes without a region label are skipped.
+func DetectNodeLocations(ctx context.Context, k8sClient client.Client) ([]CloudEnvironment, error) {
+	nodeList := &corev1.NodeList{}
+	if err := k8sClient.List(ctx, nodeList); err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	seen := make(map[CloudEnvironment]bool)
+	var locations []CloudEnvironment
+	for i := range nodeList.Items {
+		loc := nodeLocation(&nodeList.Items[i])
+		if loc.Region == "" || seen[loc] {
+			continue
+		}
+		seen[loc] = true
+		locations = append(locations, loc)
 	}
+	return locations, nil
 }
@@ -39,6 +39,57 @@ var _ = Describe("CloudInfo", func() {
 			labels := map[string]string{"foo": "bar"}
 			Expect(detectProvider(labels)).To(Equal("unknown"))
 		})
+
+		DescribeTable("should detect other managed Kubernetes services",
+			func(labels map[string]string, provider string) {
+				Expect(detectProvider(labels)).To(Equal(provider))
+			},
+			Entry("OCI", map[string]string{"oci.oraclecloud.com/fault-domain": "FAULT-DOMAIN-1"}, "oci"),
+			Entry("IBM Cloud", map[string]string{"ibm-cloud.kubernetes.io/worker-id": "kube-abc-w1"}, "ibm"),
+			Entry("DigitalOcean", map[string]string{"doks.digitalocean.com/node-pool": "pool-1"}, "digitalocean"),
+			Entry("Linode", map[string]string{"lke.linode.com/pool-id": "1234"}, "linode"),
+			Entry("Hetzner", map[string]string{"instance.hetzner.cloud/provided-by": "cloud"}, "hetzner"),
+			Entry("k3s", map[string]string{"node.kubernetes.io/instance-type": "k3s"}, "k3s"),
+		)
+	})
+
+	Describe("detectNodeProvider", func() {
+		DescribeTable("should detect the provider from the provider ID",
+			func(providerID, provider string) {
+				Expect(detectNodeProvider(map[string]string{"foo": "bar"}, providerID)).To(Equal(provider))
+			},
+			Entry("AWS", "aws:///us-east-1a/i-0123456789abcdef0", "aws"),
+			Entry("GCP", "gce://project/europe-west4-a/node-1", "gcp"),
+			Entry("Azure", "azure:///subscriptions/s/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/vm", "azure"),
+			Entry("Hetzner dedicated", "hrobot://123456", "hetzner"),
+			Entry("Kind", "kind://docker/kind/kind-control-plane", "kind"),
+			Entry("no provider ID", "", "unknown"),
+		)
+
+		It("should prefer the provider ID over labels", func() {
+			// e.g. OpenShift on AWS, whose nodes carry no EKS labels
+			labels := map[string]string{"node.openshift.io/os_id": "rhcos", "cloud.google.com/gke-nodepool": "pool"}
+			Expect(detectNodeProvider(labels, "aws:///us-east-2a/i-0abc")).To(Equal("aws"))
+		})
+	})
+
+	Describe("nodeLocation", func() {
+		It("should locate nodes with a grid region label in that grid zone", func() {
+			node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{
+				GridRegionLabel:                 "DE",
+				"topology.kubernetes.io/region": "dc-frankfurt",
+				"topology.kubernetes.io/zone":   "rack-1",
+			}}}
+			Expect(nodeLocation(node)).To(Equal(CloudEnvironment{Provider: ProviderGrid, Region: "DE", Zone: "rack-1"}))
+		})
+
+		It("should locate other nodes in their cloud region", func() {
+			node := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"topology.kubernetes.io/region": "fsn1"}},
+				Spec:       corev1.NodeSpec{ProviderID: "hcloud://1234"},
+			}
+			Expect(nodeLocation(node)).To(Equal(CloudEnvironment{Provider: "hetzner", Region: "fsn1"}))
+		})
 	})
 
 })
@@ -55,7 +106,7 @@ func (f *fakeClient) List(ctx context.Context, list client.ObjectList, opts ...c
 
 var _ = Describe("DetectCloudEnvironment", func() {
 	var (
-		ctx context.Context
+		ctx  context.Context
 		fake *fakeClient
 	)
 
@@ -103,7 +154,7 @@ var _ = Describe("DetectCloudEnvironment", func() {
 					nl.Items = []corev1.Node{{
 						ObjectMeta: metav1.ObjectMeta{
 							Labels: map[string]string{
-								"eks.amazonaws.com/nodegroup": "ng1",
+								"eks.amazonaws.com/nodegroup":   "ng1",
 								"topology.kubernetes.io/region": "us-east-1",
 								"topology.kubernetes.io/zone":   "us-east-1a",
 							},
@@ -134,7 +185,7 @@ var _ = Describe("DetectCloudEnvironment", func() {
 					nl.Items = []corev1.Node{{
 						ObjectMeta: metav1.ObjectMeta{
 							Labels: map[string]string{
-								"kubernetes.azure.com/role": "agent",
+								"kubernetes.azure.com/role":     "agent",
 								"topology.kubernetes.io/region": "westeurope",
 								"topology.kubernetes.io/zone":   "1",
 							},
@@ -165,7 +216,7 @@ var _ = Describe("DetectCloudEnvironment", func() {
 					nl.Items = []corev1.Node{{
 						ObjectMeta: metav1.ObjectMeta{
 							Labels: map[string]string{
-								"foo": "bar",
+								"foo":                           "bar",
 								"topology.kubernetes.io/region": "some-region",
 								"topology.kubernetes.io/zone":   "some-zone",
 							},
@@ -207,6 +258,29 @@ var _ = Describe("DetectCloudEnvironment", func() {
 		})
 	})
 
+	Context("with nodes in several regions", func() {
+		BeforeEach(func() {
+			fake = &fakeClient{
+				listFunc: func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+					node := func(region string) corev1.Node {
+						return corev1.Node{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{
+							"eks.amazonaws.com/nodegroup":   "ng1",
+							"topology.kubernetes.io/region": region,
+						}}}
+					}
+					list.(*corev1.NodeList).Items = []corev1.Node{node("eu-west-1"), node("us-east-1"), node("us-east-1")}
+					return nil
+				},
+			}
+		})
+
+		It("should detect the region most nodes are in", func() {
+			env, err := DetectCloudEnvironment(ctx, fake)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(env).To(Equal(&CloudEnvironment{Provider: "aws", Region: "us-east-1"}))
+		})
+	})
+
 	Context("with list error", func() {
 		BeforeEach(func() {
 			fake = &fakeClient{
@@ -225,4 +299,56 @@ var _ = Describe("DetectCloudEnvironment", func() {
 	})
 })
 
+var _ = Describe("DetectNodeLocations", func() {
+	var ctx context.Context
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	node := func(region, zone string) corev1.Node {
+		return corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{
+					"eks.amazonaws.com/nodegroup":   "ng1",
+					"topology.kubernetes.io/region": region,
+					"topology.kubernetes.io/zone":   zone,
+				},
+			},
+		}
+	}
+
+	It("should return each distinct location once", func() {
+		fake := &fakeClient{
+			listFunc: func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+				list.(*corev1.NodeList).Items = []corev1.Node{
+					node("us-east-1", "us-east-1a"),
+					node("us-east-1", "us-east-1a"),
+					node("eu-west-1", "eu-west-1b"),
+					node("", ""),
+				}
+				return nil
+			},
+		}
+
+		locations, err := DetectNodeLocations(ctx, fake)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(locations).To(Equal([]CloudEnvironment{
+			{Provider: "aws", Region: "us-east-1", Zone: "us-east-1a"},
+			{Provider: "aws", Region: "eu-west-1", Zone: "eu-west-1b"},
+		}))
+	})
+
+	It("should return an error when listing fails", func() {
+		fake := &fakeClient{
+			listFunc: func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+				return errors.New("fail-list")
+			},
+		}
+
+		_, err := DetectNodeLocations(ctx, fake)
+		Expect(err).To(HaveOccurred())
+	})
+})
+
 // End of test file
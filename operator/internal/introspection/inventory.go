@@ -0,0 +1,270 @@
+package cloudinfo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	toolscache "k8s.io/client-go/tools/cache"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// nodePoolLabels are the labels naming the node pool of a node, in order of precedence
+var nodePoolLabels = []string{
+	"cloud.google.com/gke-nodepool",
+	"eks.amazonaws.com/nodegroup",
+	"karpenter.sh/nodepool",
+	"kubernetes.azure.com/agentpool",
+	"agentpool",
+}
+
+// NodeGroup is a set of nodes in the same cloud location and node pool
+type NodeGroup struct {
+	CloudEnvironment
+	NodePool string
+	// Nodes are the names of the nodes in the group, sorted
+	Nodes []string
+}
+
+// inventoryNode is what the inventory keeps of a node
+type inventoryNode struct {
+	name     string
+	labels   map[string]string
+	location CloudEnvironment
+	nodePool string
+}
+
+// NodeInventory groups the cluster's nodes by provider, region and zone. It is kept up to date
+// by a Node informer, so that multi-region and hybrid clusters are not described by whichever
+// node happened to be listed first.
+type NodeInventory struct {
+	mu    sync.RWMutex
+	nodes map[string]inventoryNode
+}
+
+// NewNodeInventory returns an empty inventory. Nodes are added by SetupWithManager or Update.
+func NewNodeInventory() *NodeInventory {
+	return &NodeInventory{nodes: make(map[string]inventoryNode)}
+}
+
+// SetupWithManager keeps the inventory up to date from the manager's Node informer
+func (i *NodeInventory) SetupWithManager(mgr ctrl.Manager) error {
+	informer, err := mgr.GetCache().GetInformer(context.Background(), &corev1.Node{})
+	if err != nil {
+		return fmt.Errorf("failed to get node informer: %w", err)
+	}
+	_, err = informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if node, ok := obj.(*corev1.Node); ok {
+				i.Update(node)
+			}
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			if node, ok := obj.(*corev1.Node); ok {
+				i.Update(node)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if node, ok := obj.(*corev1.Node); ok {
+				i.Delete(node.Name)
+			}
+		},
+	})
+	return err
+}
+
+// Update adds a node to the inventory or refreshes its labels
+func (i *NodeInventory) Update(node *corev1.Node) {
+	nodeLabels := make(map[string]string, len(node.Labels))
+	for key, value := range node.Labels {
+		nodeLabels[key] = value
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.nodes[node.Name] = inventoryNode{
+		name:     node.Name,
+		labels:   nodeLabels,
+		location: nodeLocation(node),
+		nodePool: nodePool(nodeLabels),
+	}
+}
+
+// Delete removes a node from the inventory
+func (i *NodeInventory) Delete(name string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	delete(i.nodes, name)
+}
+
+// Groups returns the nodes grouped by location and node pool, sorted by provider, region, zone
+// and node pool
+func (i *NodeInventory) Groups() []NodeGroup {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	type groupKey struct {
+		location CloudEnvironment
+		nodePool string
+	}
+	byKey := make(map[groupKey]*NodeGroup)
+	for _, node := range i.nodes {
+		key := groupKey{location: node.location, nodePool: node.nodePool}
+		group, ok := byKey[key]
+		if !ok {
+			group = &NodeGroup{CloudEnvironment: node.location, NodePool: node.nodePool}
+			byKey[key] = group
+		}
+		group.Nodes = append(group.Nodes, node.name)
+	}
+
+	groups := make([]NodeGroup, 0, len(byKey))
+	for _, group := range byKey {
+		sort.Strings(group.Nodes)
+		groups = append(groups, *group)
+	}
+	sort.Slice(groups, func(a, b int) bool {
+		if groups[a].CloudEnvironment != groups[b].CloudEnvironment {
+			return lessLocation(groups[a].CloudEnvironment, groups[b].CloudEnvironment)
+		}
+		return groups[a].NodePool < groups[b].NodePool
+	})
+	return groups
+}
+
+// Locations returns the distinct locations of the nodes a pod with podSpec can be scheduled
+// on according to its nodeSelector and required node affinity, or of all nodes if podSpec is
+// nil. Nodes without a region or grid region label are skipped. Locations with more nodes come
+// first.
+func (i *NodeInventory) Locations(podSpec *corev1.PodSpec) []CloudEnvironment {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	counts := make(map[CloudEnvironment]int)
+	for _, node := range i.nodes {
+		if node.location.Region == "" {
+			continue
+		}
+		if podSpec != nil && !podSpecMatchesNode(podSpec, node.name, node.labels) {
+			continue
+		}
+		counts[node.location]++
+	}
+
+	locations := make([]CloudEnvironment, 0, len(counts))
+	for location := range counts {
+		locations = append(locations, location)
+	}
+	sort.Slice(locations, func(a, b int) bool {
+		if counts[locations[a]] != counts[locations[b]] {
+			return counts[locations[a]] > counts[locations[b]]
+		}
+		return lessLocation(locations[a], locations[b])
+	})
+	return locations
+}
+
+// nodePool returns the node pool of a node, or an empty string if it is not in one
+func nodePool(nodeLabels map[string]string) string {
+	for _, label := range nodePoolLabels {
+		if pool := nodeLabels[label]; pool != "" {
+			return pool
+		}
+	}
+	return ""
+}
+
+func lessLocation(a, b CloudEnvironment) bool {
+	if a.Provider != b.Provider {
+		return a.Provider < b.Provider
+	}
+	if a.Region != b.Region {
+		return a.Region < b.Region
+	}
+	return a.Zone < b.Zone
+}
+
+// podSpecMatchesNode reports whether the nodeSelector and the required node affinity of a pod
+// allow it on a node. Taints, resources and preferred affinity are not considered.
+func podSpecMatchesNode(podSpec *corev1.PodSpec, nodeName string, nodeLabels map[string]string) bool {
+	if !labels.SelectorFromSet(podSpec.NodeSelector).Matches(labels.Set(nodeLabels)) {
+		return false
+	}
+	if podSpec.Affinity == nil || podSpec.Affinity.NodeAffinity == nil ||
+		podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return true
+	}
+
+	// Terms are ORed, their requirements ANDed
+	for _, term := range podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		if nodeSelectorTermMatches(term, nodeName, nodeLabels) {
+			return true
+		}
+	}
+	return false
+}
+
+// nodeSelectorTermMatches reports whether a node matches a node selector term. Like the
+// scheduler, a term without requirements matches no node.
+func nodeSelectorTermMatches(term corev1.NodeSelectorTerm, nodeName string, nodeLabels map[string]string) bool {
+	if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+		return false
+	}
+	for _, req := range term.MatchExpressions {
+		if !nodeSelectorRequirementMatches(req, labels.Set(nodeLabels)) {
+			return false
+		}
+	}
+	// metadata.name is the only supported field
+	for _, req := range term.MatchFields {
+		if req.Key != "metadata.name" ||
+			!nodeSelectorRequirementMatches(req, labels.Set{req.Key: nodeName}) {
+			return false
+		}
+	}
+	return true
+}
+
+func nodeSelectorRequirementMatches(req corev1.NodeSelectorRequirement, set labels.Set) bool {
+	var op selection.Operator
+	switch req.Operator {
+	case corev1.NodeSelectorOpIn:
+		op = selection.In
+	case corev1.NodeSelectorOpNotIn:
+		op = selection.NotIn
+	case corev1.NodeSelectorOpExists:
+		op = selection.Exists
+	case corev1.NodeSelectorOpDoesNotExist:
+		op = selection.DoesNotExist
+	case corev1.NodeSelectorOpGt, corev1.NodeSelectorOpLt:
+		// Compare as integers like the scheduler does
+		if len(req.Values) != 1 || !set.Has(req.Key) {
+			return false
+		}
+		value, err := strconv.ParseInt(set.Get(req.Key), 10, 64)
+		limit, limitErr := strconv.ParseInt(req.Values[0], 10, 64)
+		if err != nil || limitErr != nil {
+			return false
+		}
+		if req.Operator == corev1.NodeSelectorOpGt {
+			return value > limit
+		}
+		return value < limit
+	default:
+		return false
+	}
+
+	requirement, err := labels.NewRequirement(req.Key, op, req.Values)
+	if err != nil {
+		return false
+	}
+	return requirement.Matches(set)
+}
@@ -0,0 +1,98 @@
+package cloudinfo
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("NodeInventory", func() {
+	node := func(name, pool, region, zone string, extraLabels map[string]string) *corev1.Node {
+		nodeLabels := map[string]string{
+			"eks.amazonaws.com/nodegroup":   pool,
+			"topology.kubernetes.io/region": region,
+			"topology.kubernetes.io/zone":   zone,
+		}
+		for key, value := range extraLabels {
+			nodeLabels[key] = value
+		}
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: nodeLabels}}
+	}
+
+	var inventory *NodeInventory
+
+	BeforeEach(func() {
+		inventory = NewNodeInventory()
+		inventory.Update(node("a", "general", "us-east-1", "us-east-1a", nil))
+		inventory.Update(node("b", "general", "us-east-1", "us-east-1a", nil))
+		inventory.Update(node("c", "gpu", "us-east-1", "us-east-1a", map[string]string{"gpu": "true", "cores": "8"}))
+		inventory.Update(node("d", "gpu", "eu-west-1", "eu-west-1b", map[string]string{"gpu": "true", "cores": "16"}))
+		inventory.Update(node("e", "", "", "", nil))
+	})
+
+	It("should group nodes by location and node pool", func() {
+		Expect(inventory.Groups()).To(Equal([]NodeGroup{
+			{CloudEnvironment: CloudEnvironment{Provider: "aws", Region: "eu-west-1", Zone: "eu-west-1b"}, NodePool: "gpu", Nodes: []string{"d"}},
+			{CloudEnvironment: CloudEnvironment{Provider: "aws", Region: "us-east-1", Zone: "us-east-1a"}, NodePool: "general", Nodes: []string{"a", "b"}},
+			{CloudEnvironment: CloudEnvironment{Provider: "aws", Region: "us-east-1", Zone: "us-east-1a"}, NodePool: "gpu", Nodes: []string{"c"}},
+			{CloudEnvironment: CloudEnvironment{Provider: "unknown"}, Nodes: []string{"e"}},
+		}))
+	})
+
+	It("should follow node updates and deletions", func() {
+		inventory.Update(node("d", "gpu", "eu-north-1", "eu-north-1a", nil))
+		inventory.Delete("a")
+		inventory.Delete("b")
+		Expect(inventory.Locations(nil)).To(Equal([]CloudEnvironment{
+			{Provider: "aws", Region: "eu-north-1", Zone: "eu-north-1a"},
+			{Provider: "aws", Region: "us-east-1", Zone: "us-east-1a"},
+		}))
+	})
+
+	It("should list locations with more nodes first", func() {
+		Expect(inventory.Locations(nil)).To(Equal([]CloudEnvironment{
+			{Provider: "aws", Region: "us-east-1", Zone: "us-east-1a"},
+			{Provider: "aws", Region: "eu-west-1", Zone: "eu-west-1b"},
+		}))
+	})
+
+	It("should only list the locations a nodeSelector allows", func() {
+		Expect(inventory.Locations(&corev1.PodSpec{
+			NodeSelector: map[string]string{"eks.amazonaws.com/nodegroup": "gpu", "topology.kubernetes.io/region": "eu-west-1"},
+		})).To(Equal([]CloudEnvironment{
+			{Provider: "aws", Region: "eu-west-1", Zone: "eu-west-1b"},
+		}))
+		Expect(inventory.Locations(&corev1.PodSpec{NodeSelector: map[string]string{"gpu": "false"}})).To(BeEmpty())
+	})
+
+	It("should only list the locations the required node affinity allows", func() {
+		affinity := func(terms ...corev1.NodeSelectorTerm) *corev1.PodSpec {
+			return &corev1.PodSpec{Affinity: &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: terms},
+			}}}
+		}
+
+		Expect(inventory.Locations(affinity(corev1.NodeSelectorTerm{
+			MatchExpressions: []corev1.NodeSelectorRequirement{
+				{Key: "gpu", Operator: corev1.NodeSelectorOpExists},
+				{Key: "cores", Operator: corev1.NodeSelectorOpGt, Values: []string{"8"}},
+			},
+		}))).To(Equal([]CloudEnvironment{
+			{Provider: "aws", Region: "eu-west-1", Zone: "eu-west-1b"},
+		}))
+
+		// Terms are ORed
+		Expect(inventory.Locations(affinity(
+			corev1.NodeSelectorTerm{MatchFields: []corev1.NodeSelectorRequirement{
+				{Key: "metadata.name", Operator: corev1.NodeSelectorOpIn, Values: []string{"a"}},
+			}},
+			corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{
+				{Key: "topology.kubernetes.io/region", Operator: corev1.NodeSelectorOpNotIn, Values: []string{"us-east-1"}},
+			}},
+		))).To(HaveLen(2))
+
+		Expect(inventory.Locations(affinity(corev1.NodeSelectorTerm{}))).To(BeEmpty())
+	})
+})
@@ -0,0 +1,257 @@
+// Package optimizer finds the start time and zone with the lowest carbon intensity for a job,
+// given carbon intensity forecasts. It produces the same result as the carbon-aware scheduler's
+// /v0/schedule endpoint so that forecasts can be optimized in-process.
+package optimizer
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	schedulingclient "github.com/carbon-aware-kube/operator/internal/client"
+)
+
+const (
+	// DefaultStep is the default spacing of candidate start times
+	DefaultStep = 5 * time.Minute
+
+	// DefaultNumOptions is the default number of best start times returned in Options
+	DefaultNumOptions = 10
+)
+
+// ErrNoCandidates is returned when no forecast covers any of the windows
+var ErrNoCandidates = errors.New("no forecast available for the requested windows")
+
+// Interpolation determines the intensity between two forecast points
+type Interpolation int
+
+const (
+	// Linear interpolates linearly between neighbouring points
+	Linear Interpolation = iota
+	// Step holds each point's value until the next point, as for forecasts of fixed periods
+	Step
+)
+
+// Point is a single carbon intensity forecast value
+type Point struct {
+	Time time.Time
+	// Intensity is the carbon intensity in gCO2eq/kWh
+	Intensity float64
+}
+
+// Series is the forecast for a single zone
+type Series struct {
+	Zone   schedulingclient.CloudZone
+	Points []Point
+}
+
+// Request describes the job to optimize
+type Request struct {
+	// Windows are the ranges of allowed start times. The first window's start is the naive case
+	Windows []schedulingclient.TimeRange
+	// Duration is how long the job runs. Zero compares the intensity at the start time only
+	Duration time.Duration
+	// Series holds a forecast per candidate zone. The first zone with a forecast is used for the naive case
+	Series []Series
+	// Interpolation is applied between forecast points. Defaults to Linear
+	Interpolation Interpolation
+	// Step is the spacing of candidate start times within a window. Defaults to DefaultStep
+	Step time.Duration
+	// NumOptions is the number of best start times returned in Options. Defaults to DefaultNumOptions
+	NumOptions int
+}
+
+// Optimize evaluates every candidate start time in every zone and returns the ideal, worst,
+// naive and median options along with the savings of the ideal option, the NumOptions best
+// options and all candidates in chronological order.
+//
+// Candidate start times are the window bounds, a grid of Step inside each window, and every
+// start or end time that aligns the job with a forecast point. Only start times whose job runs
+// entirely within the forecast's coverage are candidates, so a stale or short forecast narrows
+// the windows rather than being extrapolated.
+func Optimize(req Request) (*schedulingclient.ScheduleResponse, error) {
+	if req.Duration < 0 {
+		return nil, fmt.Errorf("invalid job duration %s", req.Duration)
+	}
+	if len(req.Windows) == 0 {
+		return nil, errors.New("at least one window is required")
+	}
+	step := req.Step
+	if step <= 0 {
+		step = DefaultStep
+	}
+	numOptions := req.NumOptions
+	if numOptions <= 0 {
+		numOptions = DefaultNumOptions
+	}
+
+	var (
+		options []schedulingclient.ScheduleOption
+		naive   *schedulingclient.ScheduleOption
+	)
+	for _, series := range req.Series {
+		if len(series.Points) == 0 {
+			continue
+		}
+		points := append([]Point(nil), series.Points...)
+		sort.SliceStable(points, func(i, j int) bool { return points[i].Time.Before(points[j].Time) })
+
+		from, to := Coverage(points, req.Interpolation)
+		for w, window := range req.Windows {
+			if window.Start.Before(from) {
+				window.Start = from
+			}
+			if latest := to.Add(-req.Duration); window.End.After(latest) {
+				window.End = latest
+			}
+			for i, start := range candidateStarts(points, window, req.Duration, step) {
+				option := schedulingclient.ScheduleOption{
+					Time:         start,
+					Zone:         series.Zone,
+					CO2Intensity: averageIntensity(points, req.Interpolation, start, req.Duration),
+				}
+				if naive == nil && w == 0 && i == 0 {
+					naive = &option
+				}
+				options = append(options, option)
+			}
+		}
+	}
+	if len(options) == 0 {
+		return nil, ErrNoCandidates
+	}
+	candidates := append([]schedulingclient.ScheduleOption(nil), options...)
+	schedulingclient.SortChronologically(candidates)
+
+	// Ties go to the earliest start time, and then to the zone listed first
+	sort.SliceStable(options, func(i, j int) bool {
+		if options[i].CO2Intensity != options[j].CO2Intensity {
+			return options[i].CO2Intensity < options[j].CO2Intensity
+		}
+		return options[i].Time.Before(options[j].Time)
+	})
+	if naive == nil {
+		// The first window is empty, so the naive case is the earliest option of any window
+		earliest := options[0]
+		for _, option := range options {
+			if option.Time.Before(earliest.Time) {
+				earliest = option
+			}
+		}
+		naive = &earliest
+	}
+
+	resp := &schedulingclient.ScheduleResponse{
+		Ideal:      options[0],
+		WorstCase:  options[len(options)-1],
+		NaiveCase:  *naive,
+		MedianCase: options[len(options)/2],
+		Options:    options[:min(len(options), numOptions)],
+		Candidates: candidates,
+	}
+	resp.CarbonSavings = schedulingclient.CarbonSavings{
+		VsWorstCase:  SavingsPct(resp.WorstCase.CO2Intensity, resp.Ideal.CO2Intensity),
+		VsNaiveCase:  SavingsPct(resp.NaiveCase.CO2Intensity, resp.Ideal.CO2Intensity),
+		VsMedianCase: SavingsPct(resp.MedianCase.CO2Intensity, resp.Ideal.CO2Intensity),
+	}
+	return resp, nil
+}
+
+// SavingsPct returns how much lower ideal is than reference, in percent
+func SavingsPct(reference, ideal float64) float64 {
+	if reference <= 0 {
+		return 0
+	}
+	return (reference - ideal) / reference * 100
+}
+
+// Coverage returns the range of time described by a forecast. Points of fixed periods cover
+// their period, taken as the spacing of the last two points. Points must be sorted by time.
+func Coverage(points []Point, interp Interpolation) (time.Time, time.Time) {
+	if len(points) == 0 {
+		return time.Time{}, time.Time{}
+	}
+	from, to := points[0].Time, points[len(points)-1].Time
+	if interp == Step && len(points) > 1 {
+		to = to.Add(to.Sub(points[len(points)-2].Time))
+	}
+	return from, to
+}
+
+// candidateStarts returns the sorted, distinct start times evaluated in a window. The window
+// start always comes first.
+func candidateStarts(points []Point, window schedulingclient.TimeRange, duration, step time.Duration) []time.Time {
+	if window.End.Before(window.Start) {
+		return nil
+	}
+
+	starts := []time.Time{window.Start, window.End}
+	for t := window.Start.Add(step); t.Before(window.End); t = t.Add(step) {
+		starts = append(starts, t)
+	}
+	inWindow := func(t time.Time) bool {
+		return !t.Before(window.Start) && !t.After(window.End)
+	}
+	for _, p := range points {
+		if inWindow(p.Time) {
+			starts = append(starts, p.Time)
+		}
+		if end := p.Time.Add(-duration); duration > 0 && inWindow(end) {
+			starts = append(starts, end)
+		}
+	}
+
+	sort.Slice(starts, func(i, j int) bool { return starts[i].Before(starts[j]) })
+	distinct := starts[:1]
+	for _, t := range starts[1:] {
+		if !t.Equal(distinct[len(distinct)-1]) {
+			distinct = append(distinct, t)
+		}
+	}
+	return distinct
+}
+
+// averageIntensity returns the mean intensity over [start, start+duration], or the intensity
+// at start if duration is zero. Points must be sorted by time.
+func averageIntensity(points []Point, interp Interpolation, start time.Time, duration time.Duration) float64 {
+	if duration <= 0 {
+		return intensityAt(points, interp, start)
+	}
+	end := start.Add(duration)
+
+	// Integrate piecewise between the forecast points inside the range. Both interpolations
+	// are exact: Step is constant and Linear is a straight line between breakpoints
+	var total float64
+	t := start
+	for t.Before(end) {
+		next := end
+		if i := sort.Search(len(points), func(k int) bool { return points[k].Time.After(t) }); i < len(points) && points[i].Time.Before(end) {
+			next = points[i].Time
+		}
+		dt := next.Sub(t).Seconds()
+		if interp == Step {
+			total += intensityAt(points, interp, t) * dt
+		} else {
+			total += (intensityAt(points, interp, t) + intensityAt(points, interp, next)) / 2 * dt
+		}
+		t = next
+	}
+	return total / duration.Seconds()
+}
+
+// intensityAt returns the interpolated intensity at t. Points must be sorted by time.
+func intensityAt(points []Point, interp Interpolation, t time.Time) float64 {
+	// i is the index of the first point after t
+	i := sort.Search(len(points), func(k int) bool { return points[k].Time.After(t) })
+	switch {
+	case i == 0:
+		return points[0].Intensity
+	case i == len(points) || interp == Step:
+		return points[i-1].Intensity
+	}
+
+	prev, next := points[i-1], points[i]
+	frac := t.Sub(prev.Time).Seconds() / next.Time.Sub(prev.Time).Seconds()
+	return prev.Intensity + frac*(next.Intensity-prev.Intensity)
+}
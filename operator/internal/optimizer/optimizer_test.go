@@ -0,0 +1,164 @@
+package optimizer
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	schedulingclient "github.com/carbon-aware-kube/operator/internal/client"
+)
+
+func TestOptimizer(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Optimizer Suite")
+}
+
+var _ = Describe("Optimize", func() {
+	var (
+		start = time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+		east  = schedulingclient.CloudZone{Provider: "aws", Region: "us-east-1"}
+		west  = schedulingclient.CloudZone{Provider: "aws", Region: "eu-west-1"}
+	)
+
+	hourly := func(values ...float64) []Point {
+		points := make([]Point, len(values))
+		for i, v := range values {
+			points[i] = Point{Time: start.Add(time.Duration(i) * time.Hour), Intensity: v}
+		}
+		return points
+	}
+
+	window := func(from, to time.Duration) schedulingclient.TimeRange {
+		return schedulingclient.TimeRange{Start: start.Add(from), End: start.Add(to)}
+	}
+
+	It("should find the lowest step-wise average and compute the savings", func() {
+		resp, err := Optimize(Request{
+			Windows:       []schedulingclient.TimeRange{window(0, 3*time.Hour)},
+			Duration:      2 * time.Hour,
+			Series:        []Series{{Zone: east, Points: hourly(400, 300, 100, 200, 500)}},
+			Interpolation: Step,
+			Step:          time.Hour,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		// Two hour averages for starts at +0h..+3h: 350, 200, 150, 350
+		Expect(resp.Ideal).To(Equal(schedulingclient.ScheduleOption{Time: start.Add(2 * time.Hour), Zone: east, CO2Intensity: 150}))
+		Expect(resp.NaiveCase.Time).To(Equal(start))
+		Expect(resp.NaiveCase.CO2Intensity).To(Equal(350.0))
+		Expect(resp.WorstCase.CO2Intensity).To(Equal(350.0))
+		Expect(resp.MedianCase.CO2Intensity).To(Equal(350.0))
+		Expect(resp.CarbonSavings.VsNaiveCase).To(BeNumerically("~", 57.142857, 1e-6))
+		Expect(resp.CarbonSavings.VsWorstCase).To(BeNumerically("~", 57.142857, 1e-6))
+		Expect(resp.Options).To(HaveLen(4))
+		Expect(resp.Options[1].Time).To(Equal(start.Add(time.Hour)))
+
+		// Candidates list every start time in chronological order
+		Expect(resp.Candidates).To(HaveLen(4))
+		for i, candidate := range resp.Candidates {
+			Expect(candidate.Time).To(Equal(start.Add(time.Duration(i) * time.Hour)))
+		}
+	})
+
+	It("should integrate linearly interpolated intensities", func() {
+		resp, err := Optimize(Request{
+			Windows:  []schedulingclient.TimeRange{window(0, 0)},
+			Duration: 2 * time.Hour,
+			Series:   []Series{{Zone: east, Points: hourly(100, 300, 100)}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		// Triangle from 100 up to 300 and back: the mean is 200
+		Expect(resp.Ideal.CO2Intensity).To(BeNumerically("~", 200, 1e-9))
+	})
+
+	It("should find optima between forecast points", func() {
+		resp, err := Optimize(Request{
+			Windows:  []schedulingclient.TimeRange{window(0, 2*time.Hour)},
+			Duration: time.Hour,
+			Series:   []Series{{Zone: east, Points: hourly(200, 100, 300)}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		// The average over [s, s+1h] is lowest where the intensities at both ends are equal
+		Expect(resp.Ideal.Time).To(Equal(start.Add(20 * time.Minute)))
+		Expect(resp.Ideal.CO2Intensity).To(BeNumerically("~", 400.0/3, 1e-9))
+	})
+
+	It("should only start jobs that run within the forecast", func() {
+		points := hourly(100, 300)
+		Expect(averageIntensity(points, Linear, start.Add(30*time.Minute), 0)).To(Equal(200.0))
+		Expect(averageIntensity(points, Step, start.Add(30*time.Minute), 0)).To(Equal(100.0))
+
+		// The forecast starts an hour into the window and ends two hours before its end
+		resp, err := Optimize(Request{
+			Windows:  []schedulingclient.TimeRange{window(-time.Hour, 3*time.Hour)},
+			Duration: 30 * time.Minute,
+			Series:   []Series{{Zone: east, Points: points}},
+			Step:     10 * time.Minute,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.NaiveCase.Time).To(Equal(start))
+		Expect(resp.Candidates[0].Time).To(Equal(start))
+		Expect(resp.Candidates[len(resp.Candidates)-1].Time).To(Equal(start.Add(30 * time.Minute)))
+
+		// Fixed periods cover the period of the last point
+		from, to := Coverage(points, Step)
+		Expect(from).To(Equal(start))
+		Expect(to).To(Equal(start.Add(2 * time.Hour)))
+
+		// A forecast that ends before the window fails like a missing one
+		_, err = Optimize(Request{
+			Windows:  []schedulingclient.TimeRange{window(2*time.Hour, 3*time.Hour)},
+			Duration: 30 * time.Minute,
+			Series:   []Series{{Zone: east, Points: points}},
+		})
+		Expect(err).To(MatchError(ErrNoCandidates))
+	})
+
+	It("should only consider start times inside the windows", func() {
+		resp, err := Optimize(Request{
+			Windows:  []schedulingclient.TimeRange{window(0, time.Hour), window(5*time.Hour, 6*time.Hour)},
+			Duration: time.Hour,
+			Series:   []Series{{Zone: east, Points: hourly(500, 500, 500, 50, 50, 300, 300, 300)}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		// The trough at 3h-4h lies between the windows
+		Expect(resp.Ideal.Time).To(BeTemporally(">=", start.Add(5*time.Hour)))
+		Expect(resp.NaiveCase.Time).To(Equal(start))
+		for _, option := range resp.Options {
+			inFirst := !option.Time.After(start.Add(time.Hour))
+			inSecond := !option.Time.Before(start.Add(5 * time.Hour))
+			Expect(inFirst || inSecond).To(BeTrue())
+		}
+	})
+
+	It("should compare zones and use the first zone for the naive case", func() {
+		resp, err := Optimize(Request{
+			Windows:  []schedulingclient.TimeRange{window(0, time.Hour)},
+			Duration: time.Hour,
+			Series: []Series{
+				{Zone: east},
+				{Zone: west, Points: hourly(300, 300, 300)},
+				{Zone: east, Points: hourly(400, 400, 400)},
+			},
+			NumOptions: 2,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Ideal.Zone).To(Equal(west))
+		Expect(resp.NaiveCase.Zone).To(Equal(west))
+		Expect(resp.WorstCase.Zone).To(Equal(east))
+		Expect(resp.Options).To(HaveLen(2))
+	})
+
+	It("should fail without forecasts", func() {
+		_, err := Optimize(Request{
+			Windows: []schedulingclient.TimeRange{window(0, time.Hour)},
+			Series:  []Series{{Zone: east}},
+		})
+		Expect(err).To(MatchError(ErrNoCandidates))
+
+		_, err = Optimize(Request{Series: []Series{{Zone: east, Points: hourly(1)}}})
+		Expect(err).To(HaveOccurred())
+	})
+})
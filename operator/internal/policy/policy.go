@@ -0,0 +1,297 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package policy resolves the CarbonPolicy or ClusterCarbonPolicy that applies to a
+// CarbonAwareJob and evaluates its defaults and constraints.
+package policy
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	batchv1alpha1 "github.com/carbon-aware-kube/operator/api/v1alpha1"
+)
+
+const (
+	// KindCarbonPolicy is the kind of namespaced policies
+	KindCarbonPolicy = "CarbonPolicy"
+
+	// KindClusterCarbonPolicy is the kind of cluster-scoped policies
+	KindClusterCarbonPolicy = "ClusterCarbonPolicy"
+)
+
+// Policy is a CarbonPolicy or ClusterCarbonPolicy with its time ranges resolved
+type Policy struct {
+	// Ref identifies the policy in the status of CarbonAwareJobs
+	Ref batchv1alpha1.PolicyReference
+	// Namespace is the namespace of a CarbonPolicy, empty for a ClusterCarbonPolicy
+	Namespace string
+	// Spec is the policy's settings
+	Spec batchv1alpha1.CarbonPolicySpec
+
+	// forbidden are the forbidden time ranges in their time zones
+	forbidden []timeRange
+}
+
+// timeRange is a forbidden time range in minutes of the day in a time zone
+type timeRange struct {
+	start, end int
+	location   *time.Location
+}
+
+// Resolve returns the policy that applies to a CarbonAwareJob, or nil if there is none. The first
+// matching CarbonPolicy in the job's namespace by name takes precedence over the first matching
+// ClusterCarbonPolicy by name. namespace is used for jobs that do not have one yet, such as jobs
+// being admitted. Clusters without the policy CRDs have no policies.
+func Resolve(ctx context.Context, c client.Reader, carbonAwareJob *batchv1alpha1.CarbonAwareJob, namespace string) (*Policy, error) {
+	if carbonAwareJob.Namespace != "" {
+		namespace = carbonAwareJob.Namespace
+	}
+	if namespace == "" {
+		return nil, nil
+	}
+	jobLabels := labels.Set(carbonAwareJob.Labels)
+
+	var policies batchv1alpha1.CarbonPolicyList
+	if err := c.List(ctx, &policies, client.InNamespace(namespace)); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list CarbonPolicies: %w", err)
+	}
+	sort.Slice(policies.Items, func(i, j int) bool { return policies.Items[i].Name < policies.Items[j].Name })
+	for _, item := range policies.Items {
+		matches, err := selects(item.Spec.Selector, jobLabels)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selector of CarbonPolicy %s/%s: %w", item.Namespace, item.Name, err)
+		}
+		if matches {
+			return New(KindCarbonPolicy, item.Namespace, item.Name, item.Spec)
+		}
+	}
+
+	var clusterPolicies batchv1alpha1.ClusterCarbonPolicyList
+	if err := c.List(ctx, &clusterPolicies); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list ClusterCarbonPolicies: %w", err)
+	}
+	if len(clusterPolicies.Items) == 0 {
+		return nil, nil
+	}
+	sort.Slice(clusterPolicies.Items, func(i, j int) bool { return clusterPolicies.Items[i].Name < clusterPolicies.Items[j].Name })
+
+	var ns corev1.Namespace
+	if err := c.Get(ctx, client.ObjectKey{Name: namespace}, &ns); err != nil {
+		return nil, fmt.Errorf("failed to get namespace %s: %w", namespace, err)
+	}
+	for _, item := range clusterPolicies.Items {
+		matches, err := selects(item.Spec.NamespaceSelector, labels.Set(ns.Labels))
+		if err != nil {
+			return nil, fmt.Errorf("invalid namespace selector of ClusterCarbonPolicy %s: %w", item.Name, err)
+		}
+		if !matches {
+			continue
+		}
+		if matches, err = selects(item.Spec.Selector, jobLabels); err != nil {
+			return nil, fmt.Errorf("invalid selector of ClusterCarbonPolicy %s: %w", item.Name, err)
+		}
+		if matches {
+			return New(KindClusterCarbonPolicy, "", item.Name, item.Spec.CarbonPolicySpec)
+		}
+	}
+	return nil, nil
+}
+
+// Get returns the policy a CarbonAwareJob in namespace refers to, or nil if it no longer exists
+func Get(ctx context.Context, c client.Reader, ref batchv1alpha1.PolicyReference, namespace string) (*Policy, error) {
+	switch ref.Kind {
+	case KindCarbonPolicy:
+		var item batchv1alpha1.CarbonPolicy
+		if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, &item); err != nil {
+			return nil, ignoreMissing(err)
+		}
+		return New(KindCarbonPolicy, item.Namespace, item.Name, item.Spec)
+	case KindClusterCarbonPolicy:
+		var item batchv1alpha1.ClusterCarbonPolicy
+		if err := c.Get(ctx, client.ObjectKey{Name: ref.Name}, &item); err != nil {
+			return nil, ignoreMissing(err)
+		}
+		return New(KindClusterCarbonPolicy, "", item.Name, item.Spec.CarbonPolicySpec)
+	}
+	return nil, fmt.Errorf("unknown policy kind %q", ref.Kind)
+}
+
+// ignoreMissing returns nil if a policy or its CRD does not exist
+func ignoreMissing(err error) error {
+	if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return nil
+	}
+	return err
+}
+
+// selects reports whether a label selector matches a set of labels. A nil selector matches everything.
+func selects(selector *metav1.LabelSelector, set labels.Set) (bool, error) {
+	if selector == nil {
+		return true, nil
+	}
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false, err
+	}
+	return s.Matches(set), nil
+}
+
+// New returns a policy of the given kind with its forbidden time ranges parsed. namespace is
+// empty for a ClusterCarbonPolicy
+func New(kind, namespace, name string, spec batchv1alpha1.CarbonPolicySpec) (*Policy, error) {
+	p := &Policy{
+		Ref:       batchv1alpha1.PolicyReference{Kind: kind, Name: name},
+		Namespace: namespace,
+		Spec:      spec,
+	}
+	for _, r := range spec.ForbiddenTimeRanges {
+		location, err := time.LoadLocation(r.TimeZone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time zone of %s: %w", p, err)
+		}
+		start, err := minuteOfDay(r.Start)
+		if err != nil {
+			return nil, fmt.Errorf("invalid forbidden time range of %s: %w", p, err)
+		}
+		end, err := minuteOfDay(r.End)
+		if err != nil {
+			return nil, fmt.Errorf("invalid forbidden time range of %s: %w", p, err)
+		}
+		p.forbidden = append(p.forbidden, timeRange{start: start, end: end, location: location})
+	}
+	return p, nil
+}
+
+// minuteOfDay parses a time of day in HH:MM format
+func minuteOfDay(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// String formats the policy as its kind and name, e.g. "CarbonPolicy team-a/default"
+func (p *Policy) String() string {
+	if p.Namespace != "" {
+		return fmt.Sprintf("%s %s/%s", p.Ref.Kind, p.Namespace, p.Ref.Name)
+	}
+	return fmt.Sprintf("%s %s", p.Ref.Kind, p.Ref.Name)
+}
+
+// ApplyDefaults sets the MaxDelay, MaxDuration and Fallback of a CarbonAwareJob spec that does
+// not set them from the policy. It does nothing if the policy is nil.
+func (p *Policy) ApplyDefaults(spec *batchv1alpha1.CarbonAwareJobSpec) {
+	if p == nil {
+		return
+	}
+	if spec.MaxDelay.Duration == 0 && spec.Deadline == nil && p.Spec.MaxDelay != nil {
+		spec.MaxDelay = *p.Spec.MaxDelay
+	}
+	if spec.MaxDuration == nil && p.Spec.MaxDuration != nil {
+		maxDuration := *p.Spec.MaxDuration
+		spec.MaxDuration = &maxDuration
+	}
+	if spec.Fallback == nil && p.Spec.Fallback != nil {
+		spec.Fallback = p.Spec.Fallback.DeepCopy()
+	}
+}
+
+// RegionAllowed reports whether a job may run in region. Every region is allowed if the policy
+// is nil or does not list allowed regions.
+func (p *Policy) RegionAllowed(region string) bool {
+	return p == nil || len(p.Spec.AllowedRegions) == 0 || slices.Contains(p.Spec.AllowedRegions, region)
+}
+
+// MinSavingsPercent returns the savings compared to running immediately a delay has to achieve
+func (p *Policy) MinSavingsPercent() float64 {
+	if p == nil || p.Spec.MinSavingsPercent == nil {
+		return 0
+	}
+	return float64(*p.Spec.MinSavingsPercent)
+}
+
+// Forbidden reports whether a job may not start at t
+func (p *Policy) Forbidden(t time.Time) bool {
+	if p == nil {
+		return false
+	}
+	for _, r := range p.forbidden {
+		if r.contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// NextAllowed returns the earliest time at or after t at which a job may start
+func (p *Policy) NextAllowed(t time.Time) time.Time {
+	if p == nil {
+		return t
+	}
+	// Every range moves t to its end, so overlapping ranges are left after as many steps
+	for i := 0; i <= len(p.forbidden); i++ {
+		moved := false
+		for _, r := range p.forbidden {
+			if r.contains(t) {
+				t = r.endAfter(t)
+				moved = true
+			}
+		}
+		if !moved {
+			return t
+		}
+	}
+	return t
+}
+
+// contains reports whether t is within the range
+func (r timeRange) contains(t time.Time) bool {
+	local := t.In(r.location)
+	minute := local.Hour()*60 + local.Minute()
+	if r.start < r.end {
+		return minute >= r.start && minute < r.end
+	}
+	// The range spans midnight
+	return minute >= r.start || minute < r.end
+}
+
+// endAfter returns the end of the range containing t
+func (r timeRange) endAfter(t time.Time) time.Time {
+	local := t.In(r.location)
+	end := time.Date(local.Year(), local.Month(), local.Day(), r.end/60, r.end%60, 0, 0, r.location)
+	if !end.After(local) {
+		end = end.AddDate(0, 0, 1)
+	}
+	return end
+}
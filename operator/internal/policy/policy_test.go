@@ -0,0 +1,190 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	batchv1alpha1 "github.com/carbon-aware-kube/operator/api/v1alpha1"
+)
+
+func TestPolicy(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Policy Suite")
+}
+
+var _ = Describe("Resolve", func() {
+	var (
+		ctx            context.Context
+		objects        []client.Object
+		carbonAwareJob *batchv1alpha1.CarbonAwareJob
+	)
+
+	newClient := func() client.Client {
+		scheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(batchv1alpha1.AddToScheme(scheme)).To(Succeed())
+		return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	}
+
+	resolve := func() *Policy {
+		p, err := Resolve(ctx, newClient(), carbonAwareJob, "")
+		Expect(err).NotTo(HaveOccurred())
+		return p
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		objects = []client.Object{
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"team": "a"}}},
+		}
+		carbonAwareJob = &batchv1alpha1.CarbonAwareJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "job", Namespace: "team-a", Labels: map[string]string{"tier": "batch"}},
+		}
+	})
+
+	It("should return nil without policies", func() {
+		Expect(resolve()).To(BeNil())
+	})
+
+	It("should prefer a CarbonPolicy in the job's namespace over ClusterCarbonPolicies", func() {
+		objects = append(objects,
+			&batchv1alpha1.ClusterCarbonPolicy{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}},
+			&batchv1alpha1.CarbonPolicy{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "team-b"}},
+			&batchv1alpha1.CarbonPolicy{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "team-a"}},
+			&batchv1alpha1.CarbonPolicy{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "team-a"}},
+		)
+		p := resolve()
+		Expect(p.Ref).To(Equal(batchv1alpha1.PolicyReference{Kind: KindCarbonPolicy, Name: "a"}))
+		Expect(p.String()).To(Equal("CarbonPolicy team-a/a"))
+	})
+
+	It("should skip policies whose selectors do not match", func() {
+		objects = append(objects,
+			&batchv1alpha1.CarbonPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "interactive", Namespace: "team-a"},
+				Spec: batchv1alpha1.CarbonPolicySpec{
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "interactive"}},
+				},
+			},
+			&batchv1alpha1.ClusterCarbonPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "a-team-b"},
+				Spec: batchv1alpha1.ClusterCarbonPolicySpec{
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "b"}},
+				},
+			},
+			&batchv1alpha1.ClusterCarbonPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "b-team-a"},
+				Spec: batchv1alpha1.ClusterCarbonPolicySpec{
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+					CarbonPolicySpec: batchv1alpha1.CarbonPolicySpec{
+						Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "batch"}},
+					},
+				},
+			},
+		)
+		p := resolve()
+		Expect(p.Ref).To(Equal(batchv1alpha1.PolicyReference{Kind: KindClusterCarbonPolicy, Name: "b-team-a"}))
+		Expect(p.String()).To(Equal("ClusterCarbonPolicy b-team-a"))
+	})
+
+	It("should reject invalid time zones", func() {
+		objects = append(objects, &batchv1alpha1.CarbonPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "team-a"},
+			Spec: batchv1alpha1.CarbonPolicySpec{
+				ForbiddenTimeRanges: []batchv1alpha1.TimeRange{{Start: "08:00", End: "10:00", TimeZone: "Mars/Olympus"}},
+			},
+		})
+		_, err := Resolve(ctx, newClient(), carbonAwareJob, "")
+		Expect(err).To(MatchError(ContainSubstring("invalid time zone of CarbonPolicy team-a/a")))
+	})
+})
+
+var _ = Describe("Policy", func() {
+	It("should only fill unset fields with its defaults", func() {
+		maxDelay := metav1.Duration{Duration: 8 * time.Hour}
+		maxDuration := metav1.Duration{Duration: 2 * time.Hour}
+		p := &Policy{Spec: batchv1alpha1.CarbonPolicySpec{
+			MaxDelay:    &maxDelay,
+			MaxDuration: &maxDuration,
+			Fallback:    &batchv1alpha1.FallbackPolicy{Strategy: batchv1alpha1.FallbackWindowEnd},
+		}}
+
+		spec := batchv1alpha1.CarbonAwareJobSpec{}
+		p.ApplyDefaults(&spec)
+		Expect(spec.MaxDelay).To(Equal(maxDelay))
+		Expect(spec.MaxDuration).To(Equal(&maxDuration))
+		Expect(spec.Fallback.Strategy).To(Equal(batchv1alpha1.FallbackWindowEnd))
+
+		deadline := metav1.NewTime(time.Now().Add(time.Hour))
+		spec = batchv1alpha1.CarbonAwareJobSpec{
+			Deadline:    &deadline,
+			MaxDuration: &metav1.Duration{Duration: time.Minute},
+			Fallback:    &batchv1alpha1.FallbackPolicy{Strategy: batchv1alpha1.FallbackImmediate},
+		}
+		p.ApplyDefaults(&spec)
+		Expect(spec.MaxDelay.Duration).To(BeZero())
+		Expect(spec.MaxDuration.Duration).To(Equal(time.Minute))
+		Expect(spec.Fallback.Strategy).To(Equal(batchv1alpha1.FallbackImmediate))
+
+		var none *Policy
+		none.ApplyDefaults(&spec)
+		Expect(none.RegionAllowed("anywhere")).To(BeTrue())
+		Expect(none.Forbidden(time.Now())).To(BeFalse())
+	})
+
+	It("should only allow the listed regions", func() {
+		p := &Policy{Spec: batchv1alpha1.CarbonPolicySpec{AllowedRegions: []string{"eu-west-1"}}}
+		Expect(p.RegionAllowed("eu-west-1")).To(BeTrue())
+		Expect(p.RegionAllowed("us-east-1")).To(BeFalse())
+	})
+
+	It("should forbid start times within its time ranges", func() {
+		p, err := New(KindCarbonPolicy, "team-a", "a", batchv1alpha1.CarbonPolicySpec{
+			ForbiddenTimeRanges: []batchv1alpha1.TimeRange{
+				{Start: "22:00", End: "02:00"},
+				{Start: "01:30", End: "03:00"},
+				{Start: "09:00", End: "17:00", TimeZone: "Europe/Berlin"},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		at := func(hour, minute int) time.Time { return time.Date(2025, 6, 1, hour, minute, 0, 0, time.UTC) }
+		Expect(p.Forbidden(at(21, 59))).To(BeFalse())
+		Expect(p.Forbidden(at(23, 0))).To(BeTrue())
+		Expect(p.Forbidden(at(3, 0))).To(BeFalse())
+		// 09:00 in Berlin is 07:00 UTC in summer
+		Expect(p.Forbidden(at(6, 59))).To(BeFalse())
+		Expect(p.Forbidden(at(7, 0))).To(BeTrue())
+		Expect(p.Forbidden(at(15, 0))).To(BeFalse())
+
+		// Overlapping ranges are left at the end of the last one
+		Expect(p.NextAllowed(at(23, 0))).To(Equal(time.Date(2025, 6, 2, 3, 0, 0, 0, time.UTC)))
+		Expect(p.NextAllowed(at(8, 0)).Equal(at(15, 0))).To(BeTrue())
+		Expect(p.NextAllowed(at(4, 0))).To(Equal(at(4, 0)))
+	})
+})
@@ -0,0 +1,123 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	batchv1alpha1 "github.com/carbon-aware-kube/operator/api/v1alpha1"
+	"github.com/carbon-aware-kube/operator/internal/config"
+)
+
+// log is for logging in this package.
+var podlog = logf.Log.WithName("pod-resource")
+
+// SetupPodWebhookWithManager registers the webhook that gates carbon-aware Pods in the manager.
+// The cap on the max-delay annotation is taken from the operator configuration and follows its
+// reloads.
+func SetupPodWebhookWithManager(mgr ctrl.Manager, cfg *config.Watcher) error {
+	defaulter := &PodCustomDefaulter{}
+	defaulter.ApplyConfig(cfg.Current())
+	cfg.Subscribe(defaulter.ApplyConfig)
+
+	return ctrl.NewWebhookManagedBy(mgr).For(&corev1.Pod{}).
+		WithDefaulter(defaulter).
+		Complete()
+}
+
+// Only Pods labelled carbonaware.dev/scheduling-gate=true outside the operator and system
+// namespaces are sent to this webhook. The marker cannot express selectors, so they are added by
+// config/default/webhook_pod_selector_patch.yaml and the Helm chart.
+// +kubebuilder:webhook:path=/mutate--v1-pod,mutating=true,failurePolicy=ignore,sideEffects=None,groups="",resources=pods,verbs=create,versions=v1,name=mpod-v1.kb.io,admissionReviewVersions=v1
+
+// PodCustomDefaulter adds the carbon-aware scheduling gate to Pods labelled
+// carbonaware.dev/scheduling-gate=true and annotated with carbonaware.dev/max-delay. The gate is removed by the pod scheduling gate controller at the
+// start time chosen from the forecast.
+type PodCustomDefaulter struct {
+	// MaxDelayLimit is the largest max-delay a Pod may request. Zero means no limit
+	MaxDelayLimit time.Duration
+
+	// settings guards the fields updated by ApplyConfig
+	settings sync.RWMutex
+}
+
+// ApplyConfig updates the limits from the operator configuration
+func (d *PodCustomDefaulter) ApplyConfig(cfg *config.OperatorConfig) {
+	d.settings.Lock()
+	defer d.settings.Unlock()
+	d.MaxDelayLimit = cfg.Jobs.MaxDelayLimit.Duration
+}
+
+var _ webhook.CustomDefaulter = &PodCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type Pod.
+func (d *PodCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return fmt.Errorf("expected a Pod object but got %T", obj)
+	}
+
+	if pod.Labels[batchv1alpha1.LabelSchedulingGate] != "true" {
+		return nil
+	}
+	value, ok := pod.Annotations[batchv1alpha1.AnnotationMaxDelay]
+	if !ok {
+		return nil
+	}
+	// Pods bound to a node, e.g. static Pods, cannot be held back
+	if pod.Spec.NodeName != "" {
+		return nil
+	}
+	podlog.Info("Gating carbon-aware Pod", "name", pod.GetName(), "generateName", pod.GetGenerateName())
+	d.settings.RLock()
+	defer d.settings.RUnlock()
+
+	maxDelay, err := time.ParseDuration(value)
+	if err != nil || maxDelay <= 0 {
+		return fmt.Errorf("annotation %s must be a positive duration, got %q", batchv1alpha1.AnnotationMaxDelay, value)
+	}
+	if d.MaxDelayLimit > 0 && maxDelay > d.MaxDelayLimit {
+		return fmt.Errorf("annotation %s must not exceed the cluster limit of %s", batchv1alpha1.AnnotationMaxDelay, d.MaxDelayLimit)
+	}
+	if value, ok := pod.Annotations[batchv1alpha1.AnnotationMaxDuration]; ok {
+		if maxDuration, err := time.ParseDuration(value); err != nil || maxDuration <= 0 {
+			return fmt.Errorf("annotation %s must be a positive duration, got %q", batchv1alpha1.AnnotationMaxDuration, value)
+		}
+	}
+
+	if pod.Labels == nil {
+		pod.Labels = map[string]string{}
+	}
+	pod.Labels[batchv1alpha1.LabelSchedulingGated] = "true"
+	for _, gate := range pod.Spec.SchedulingGates {
+		if gate.Name == batchv1alpha1.PodSchedulingGate {
+			return nil
+		}
+	}
+	pod.Spec.SchedulingGates = append(pod.Spec.SchedulingGates, corev1.PodSchedulingGate{Name: batchv1alpha1.PodSchedulingGate})
+
+	return nil
+}
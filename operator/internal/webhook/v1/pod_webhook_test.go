@@ -0,0 +1,106 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	batchv1alpha1 "github.com/carbon-aware-kube/operator/api/v1alpha1"
+)
+
+var _ = Describe("Pod Webhook", func() {
+	var (
+		obj       *corev1.Pod
+		defaulter PodCustomDefaulter
+	)
+
+	BeforeEach(func() {
+		obj = &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "webhook-test",
+				Namespace: "default",
+				Labels: map[string]string{
+					batchv1alpha1.LabelSchedulingGate: "true",
+				},
+				Annotations: map[string]string{
+					batchv1alpha1.AnnotationMaxDelay: "4h",
+				},
+			},
+			Spec: corev1.PodSpec{
+				Containers:    []corev1.Container{{Name: "test", Image: "busybox"}},
+				RestartPolicy: corev1.RestartPolicyNever,
+			},
+		}
+		defaulter = PodCustomDefaulter{MaxDelayLimit: 24 * time.Hour}
+	})
+
+	Context("When creating Pods under Defaulting Webhook", func() {
+		It("Should gate an annotated Pod once", func() {
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.SchedulingGates).To(Equal([]corev1.PodSchedulingGate{{Name: batchv1alpha1.PodSchedulingGate}}))
+			Expect(obj.Labels).To(HaveKeyWithValue(batchv1alpha1.LabelSchedulingGated, "true"))
+		})
+
+		It("Should leave Pods without the label or annotation or bound to a node alone", func() {
+			unlabelled := obj.DeepCopy()
+			delete(unlabelled.Labels, batchv1alpha1.LabelSchedulingGate)
+			Expect(defaulter.Default(ctx, unlabelled)).To(Succeed())
+			Expect(unlabelled.Spec.SchedulingGates).To(BeEmpty())
+			Expect(unlabelled.Labels).NotTo(HaveKey(batchv1alpha1.LabelSchedulingGated))
+
+			bound := obj.DeepCopy()
+			bound.Spec.NodeName = "node-1"
+			Expect(defaulter.Default(ctx, bound)).To(Succeed())
+			Expect(bound.Spec.SchedulingGates).To(BeEmpty())
+			Expect(bound.Labels).NotTo(HaveKey(batchv1alpha1.LabelSchedulingGated))
+
+			delete(obj.Annotations, batchv1alpha1.AnnotationMaxDelay)
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.SchedulingGates).To(BeEmpty())
+		})
+
+		It("Should reject invalid or excessive delays", func() {
+			obj.Annotations[batchv1alpha1.AnnotationMaxDelay] = "soon"
+			Expect(defaulter.Default(ctx, obj)).NotTo(Succeed())
+
+			obj.Annotations[batchv1alpha1.AnnotationMaxDelay] = "48h"
+			Expect(defaulter.Default(ctx, obj)).NotTo(Succeed())
+
+			obj.Annotations[batchv1alpha1.AnnotationMaxDelay] = "4h"
+			obj.Annotations[batchv1alpha1.AnnotationMaxDuration] = "0s"
+			Expect(defaulter.Default(ctx, obj)).NotTo(Succeed())
+		})
+	})
+
+	Context("When admitting Pods through the API server", func() {
+		AfterEach(func() {
+			_ = k8sClient.Delete(ctx, obj)
+		})
+
+		It("Should add the scheduling gate on create", func() {
+			Expect(k8sClient.Create(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.SchedulingGates).To(ContainElement(corev1.PodSchedulingGate{Name: batchv1alpha1.PodSchedulingGate}))
+			Expect(obj.Labels).To(HaveKeyWithValue(batchv1alpha1.LabelSchedulingGated, "true"))
+		})
+	})
+})
@@ -0,0 +1,135 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	batchv1alpha1 "github.com/carbon-aware-kube/operator/api/v1alpha1"
+	batchv1beta1 "github.com/carbon-aware-kube/operator/api/v1beta1"
+)
+
+var _ = Describe("CarbonAwareJob Conversion", func() {
+	var obj *batchv1alpha1.CarbonAwareJob
+
+	BeforeEach(func() {
+		scheduledTime := metav1.NewTime(time.Date(2025, 6, 1, 3, 0, 0, 0, time.UTC))
+		threshold := int32(150)
+		obj = &batchv1alpha1.CarbonAwareJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "conversion-test", Namespace: "default"},
+			Spec: batchv1alpha1.CarbonAwareJobSpec{
+				MaxDelay:    metav1.Duration{Duration: 6 * time.Hour},
+				MaxDuration: &metav1.Duration{Duration: time.Hour},
+				Strategy:    &batchv1alpha1.SchedulingStrategy{Type: batchv1alpha1.StrategyThreshold, ThresholdIntensity: &threshold},
+				Fallback:    &batchv1alpha1.FallbackPolicy{Strategy: batchv1alpha1.FallbackWindowEnd},
+				Placement:   &batchv1alpha1.Placement{Regions: []string{"us-east-1", "eu-west-1"}},
+				JobCreation: batchv1alpha1.JobCreationSuspended,
+				Template: batchv1alpha1.JobTemplateSpec{
+					Metadata: metav1.ObjectMeta{Labels: map[string]string{"team": "data"}},
+					Spec:     batchv1.JobSpec{BackoffLimit: &threshold},
+				},
+			},
+			Status: batchv1alpha1.CarbonAwareJobStatus{
+				ScheduledTime:              &scheduledTime,
+				SchedulingState:            "Pending",
+				CarbonIntensity:            "120.50 gCO2eq/kWh",
+				CarbonIntensityGramsPerKWh: resource.NewMilliQuantity(120500, resource.DecimalSI),
+				CarbonSavings: &batchv1alpha1.CarbonSavings{
+					VsNaiveCase:        "-40.00%",
+					VsNaiveCasePercent: resource.NewMilliQuantity(40000, resource.DecimalSI),
+				},
+				SchedulingDecision: &batchv1alpha1.SchedulingDecision{
+					OptimalTime:                 &scheduledTime,
+					OptimalIntensity:            "120.50 gCO2eq/kWh",
+					OptimalIntensityGramsPerKWh: resource.NewMilliQuantity(120500, resource.DecimalSI),
+					MedianIntensityGramsPerKWh:  resource.NewMilliQuantity(180000, resource.DecimalSI),
+					Strategy:                    "Threshold",
+					Region:                      "eu-west-1",
+				},
+				Alternatives: []batchv1alpha1.ScheduleAlternative{{
+					Time:                       metav1.NewTime(scheduledTime.Add(time.Hour)),
+					Region:                     "us-east-1",
+					GridZone:                   "US-MIDA-PJM",
+					CarbonIntensityGramsPerKWh: resource.NewMilliQuantity(130000, resource.DecimalSI),
+				}},
+			},
+		}
+	})
+
+	It("Should convert to v1beta1 and back without losing fields", func() {
+		hub := &batchv1beta1.CarbonAwareJob{}
+		Expect(obj.ConvertTo(hub)).To(Succeed())
+		Expect(hub.Spec.Strategy.Type).To(Equal(batchv1beta1.StrategyThreshold))
+		Expect(hub.Spec.Fallback.Strategy).To(Equal(batchv1beta1.FallbackWindowEnd))
+		Expect(hub.Spec.JobCreation).To(Equal(batchv1beta1.JobCreationSuspended))
+		Expect(hub.Status.SchedulingState).To(Equal(batchv1beta1.SchedulingStatePending))
+		Expect(hub.Status.SchedulingDecision.Strategy).To(Equal(batchv1beta1.DecisionStrategy("Threshold")))
+		Expect(hub.Status.SchedulingDecision.OptimalIntensity.String()).To(Equal("120500m"))
+		Expect(hub.Status.SchedulingDecision.MedianIntensity.String()).To(Equal("180"))
+		Expect(hub.Status.Alternatives).To(HaveLen(1))
+		Expect(hub.Status.Alternatives[0].CarbonIntensity.String()).To(Equal("130"))
+
+		converted := &batchv1alpha1.CarbonAwareJob{}
+		Expect(converted.ConvertFrom(hub)).To(Succeed())
+		Expect(converted.Spec).To(Equal(obj.Spec))
+		Expect(converted.Status.SchedulingDecision.OptimalIntensity).To(Equal("120.50 gCO2eq/kWh"))
+		Expect(converted.Status.SchedulingDecision.OptimalIntensityGramsPerKWh.Cmp(*obj.Status.SchedulingDecision.OptimalIntensityGramsPerKWh)).To(BeZero())
+		Expect(converted.Status.SchedulingDecision.MedianIntensityGramsPerKWh.Cmp(*obj.Status.SchedulingDecision.MedianIntensityGramsPerKWh)).To(BeZero())
+		Expect(converted.Status.CarbonSavings.VsNaiveCase).To(Equal("-40.00%"))
+		Expect(converted.Status.SchedulingState).To(Equal("Pending"))
+		Expect(converted.Status.Alternatives).To(HaveLen(1))
+		alternative := converted.Status.Alternatives[0]
+		Expect(alternative.Time).To(Equal(obj.Status.Alternatives[0].Time))
+		Expect(alternative.Region).To(Equal("us-east-1"))
+		Expect(alternative.GridZone).To(Equal("US-MIDA-PJM"))
+		Expect(alternative.CarbonIntensityGramsPerKWh.Cmp(*obj.Status.Alternatives[0].CarbonIntensityGramsPerKWh)).To(BeZero())
+	})
+
+	It("Should parse display strings of jobs scheduled before numeric fields existed", func() {
+		obj.Status.CarbonIntensityGramsPerKWh = nil
+		obj.Status.CarbonSavings.VsNaiveCasePercent = nil
+		obj.Status.SchedulingDecision.OptimalIntensityGramsPerKWh = nil
+		obj.Status.SchedulingDecision.ImmediateIntensity = "unknown"
+
+		hub := &batchv1beta1.CarbonAwareJob{}
+		Expect(obj.ConvertTo(hub)).To(Succeed())
+		Expect(hub.Status.CarbonIntensityGramsPerKWh.AsApproximateFloat64()).To(BeNumerically("~", 120.5, 1e-9))
+		Expect(hub.Status.CarbonSavings.VsNaiveCasePercent.AsApproximateFloat64()).To(BeNumerically("~", 40, 1e-9))
+		Expect(hub.Status.SchedulingDecision.OptimalIntensity.AsApproximateFloat64()).To(BeNumerically("~", 120.5, 1e-9))
+		Expect(hub.Status.SchedulingDecision.ImmediateIntensity).To(BeNil())
+	})
+
+	It("Should show unknown intensities of fallback decisions", func() {
+		hub := &batchv1beta1.CarbonAwareJob{
+			Status: batchv1beta1.CarbonAwareJobStatus{
+				SchedulingState:    batchv1beta1.SchedulingStatePending,
+				SchedulingDecision: &batchv1beta1.SchedulingDecision{Strategy: batchv1beta1.DecisionFallback},
+			},
+		}
+
+		converted := &batchv1alpha1.CarbonAwareJob{}
+		Expect(converted.ConvertFrom(hub)).To(Succeed())
+		Expect(converted.Status.SchedulingDecision.OptimalIntensity).To(Equal("unknown"))
+		Expect(converted.Status.SchedulingDecision.OptimalIntensityGramsPerKWh).To(BeNil())
+	})
+})
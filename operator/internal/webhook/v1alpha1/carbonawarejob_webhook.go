@@ -0,0 +1,344 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	batchv1alpha1 "github.com/carbon-aware-kube/operator/api/v1alpha1"
+	batchv1beta1 "github.com/carbon-aware-kube/operator/api/v1beta1"
+	"github.com/carbon-aware-kube/operator/internal/config"
+	"github.com/carbon-aware-kube/operator/internal/forecast"
+	"github.com/carbon-aware-kube/operator/internal/policy"
+)
+
+// log is for logging in this package.
+var carbonawarejoblog = logf.Log.WithName("carbonawarejob-resource")
+
+// SetupCarbonAwareJobWebhookWithManager registers the webhook for CarbonAwareJob in the manager.
+// The cluster default for MaxDuration and the cap on MaxDelay are taken from the operator
+// configuration and follow its reloads. Defaults of CarbonPolicies are read through the manager's client.
+func SetupCarbonAwareJobWebhookWithManager(mgr ctrl.Manager, cfg *config.Watcher) error {
+	defaulter := &CarbonAwareJobCustomDefaulter{Client: mgr.GetClient()}
+	validator := &CarbonAwareJobCustomValidator{}
+	defaulter.ApplyConfig(cfg.Current())
+	validator.ApplyConfig(cfg.Current())
+	cfg.Subscribe(defaulter.ApplyConfig)
+	cfg.Subscribe(validator.ApplyConfig)
+
+	return ctrl.NewWebhookManagedBy(mgr).For(&batchv1alpha1.CarbonAwareJob{}).
+		WithValidator(validator).
+		WithDefaulter(defaulter).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-batch-carbonaware-dev-v1alpha1-carbonawarejob,mutating=true,failurePolicy=fail,sideEffects=None,groups=batch.carbonaware.dev,resources=carbonawarejobs,verbs=create,versions=v1alpha1,name=mcarbonawarejob-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// CarbonAwareJobCustomDefaulter sets default values on CarbonAwareJobs when they are created. Updates
+// are not defaulted, so that jobs created before the webhook keep the spec they were scheduled with.
+type CarbonAwareJobCustomDefaulter struct {
+	// Client reads the CarbonPolicies and ClusterCarbonPolicies whose defaults apply to a job.
+	// Policies are not applied if nil
+	Client client.Reader
+
+	// DefaultMaxDuration is the MaxDuration of jobs that set neither MaxDuration nor activeDeadlineSeconds
+	DefaultMaxDuration time.Duration
+
+	// settings guards the fields updated by ApplyConfig
+	settings sync.RWMutex
+}
+
+// ApplyConfig updates the defaults from the operator configuration
+func (d *CarbonAwareJobCustomDefaulter) ApplyConfig(cfg *config.OperatorConfig) {
+	d.settings.Lock()
+	defer d.settings.Unlock()
+	d.DefaultMaxDuration = cfg.Jobs.DefaultMaxDuration.Duration
+}
+
+var _ webhook.CustomDefaulter = &CarbonAwareJobCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind CarbonAwareJob.
+func (d *CarbonAwareJobCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	carbonAwareJob, ok := obj.(*batchv1alpha1.CarbonAwareJob)
+	if !ok {
+		return fmt.Errorf("expected a CarbonAwareJob object but got %T", obj)
+	}
+	carbonawarejoblog.Info("Defaulting for CarbonAwareJob", "name", carbonAwareJob.GetName())
+	d.settings.RLock()
+	defer d.settings.RUnlock()
+
+	// A job cannot run longer than its active deadline, so that is a better estimate than any default
+	activeDeadline := carbonAwareJob.Spec.Template.Spec.ActiveDeadlineSeconds
+	if carbonAwareJob.Spec.MaxDuration == nil && activeDeadline != nil && *activeDeadline > 0 {
+		carbonAwareJob.Spec.MaxDuration = &metav1.Duration{Duration: time.Duration(*activeDeadline) * time.Second}
+	}
+
+	// The defaults of the job's policy take precedence over the cluster default
+	if d.Client != nil {
+		// Objects being created may leave their namespace to the request
+		var namespace string
+		if req, err := admission.RequestFromContext(ctx); err == nil {
+			namespace = req.Namespace
+		}
+		jobPolicy, err := policy.Resolve(ctx, d.Client, carbonAwareJob, namespace)
+		if err != nil {
+			return err
+		}
+		jobPolicy.ApplyDefaults(&carbonAwareJob.Spec)
+	}
+
+	if carbonAwareJob.Spec.MaxDuration == nil {
+		maxDuration := d.DefaultMaxDuration
+		if maxDuration <= 0 {
+			maxDuration = batchv1alpha1.DefaultMaxDuration
+		}
+		carbonAwareJob.Spec.MaxDuration = &metav1.Duration{Duration: maxDuration}
+	}
+
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-batch-carbonaware-dev-v1alpha1-carbonawarejob,mutating=false,failurePolicy=fail,sideEffects=None,groups=batch.carbonaware.dev,resources=carbonawarejobs,verbs=create;update,versions=v1alpha1,name=vcarbonawarejob-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// CarbonAwareJobCustomValidator validates CarbonAwareJobs when they are created or updated
+type CarbonAwareJobCustomValidator struct {
+	// MaxDelayLimit is the largest MaxDelay a job may request. Zero means no limit
+	MaxDelayLimit time.Duration
+
+	// RankedOptionsOnly is set when forecasts come from the scheduler, which only reports the
+	// ranked options rather than every start time. Strategies that search the forecast in time
+	// order are rejected
+	RankedOptionsOnly bool
+
+	// settings guards the fields updated by ApplyConfig
+	settings sync.RWMutex
+}
+
+// ApplyConfig updates the limits from the operator configuration
+func (v *CarbonAwareJobCustomValidator) ApplyConfig(cfg *config.OperatorConfig) {
+	v.settings.Lock()
+	defer v.settings.Unlock()
+	v.MaxDelayLimit = cfg.Jobs.MaxDelayLimit.Duration
+	v.RankedOptionsOnly = cfg.Forecast.Provider == forecast.ProviderScheduler
+}
+
+var _ webhook.CustomValidator = &CarbonAwareJobCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type CarbonAwareJob.
+func (v *CarbonAwareJobCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	carbonAwareJob, ok := obj.(*batchv1alpha1.CarbonAwareJob)
+	if !ok {
+		return nil, fmt.Errorf("expected a CarbonAwareJob object but got %T", obj)
+	}
+	carbonawarejoblog.Info("Validation for CarbonAwareJob upon creation", "name", carbonAwareJob.GetName())
+
+	allErrs := v.validateSpec(carbonAwareJob)
+	allErrs = append(allErrs, v.validateStrategy(carbonAwareJob)...)
+	allErrs = append(allErrs, validateDeadline(carbonAwareJob, time.Now())...)
+	return nil, invalidError(carbonAwareJob, allErrs)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type CarbonAwareJob.
+func (v *CarbonAwareJobCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldJob, ok := oldObj.(*batchv1alpha1.CarbonAwareJob)
+	if !ok {
+		return nil, fmt.Errorf("expected a CarbonAwareJob object for the oldObj but got %T", oldObj)
+	}
+	carbonAwareJob, ok := newObj.(*batchv1alpha1.CarbonAwareJob)
+	if !ok {
+		return nil, fmt.Errorf("expected a CarbonAwareJob object for the newObj but got %T", newObj)
+	}
+	carbonawarejoblog.Info("Validation for CarbonAwareJob upon update", "name", carbonAwareJob.GetName())
+
+	// Metadata updates such as adding or removing the finalizer must not be blocked by jobs
+	// that were admitted before the webhook or under different limits
+	if apiequality.Semantic.DeepEqual(oldJob.Spec, carbonAwareJob.Spec) {
+		return nil, nil
+	}
+
+	allErrs := v.validateSpec(carbonAwareJob)
+	// Jobs admitted before the forecast backend changed keep their strategy
+	if !apiequality.Semantic.DeepEqual(oldJob.Spec.Strategy, carbonAwareJob.Spec.Strategy) {
+		allErrs = append(allErrs, v.validateStrategy(carbonAwareJob)...)
+	}
+	allErrs = append(allErrs, validateWindowUnchanged(oldJob, carbonAwareJob)...)
+	return nil, invalidError(carbonAwareJob, allErrs)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type CarbonAwareJob.
+func (v *CarbonAwareJobCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateSpec checks the scheduling window and expected duration of a CarbonAwareJob
+func (v *CarbonAwareJobCustomValidator) validateSpec(carbonAwareJob *batchv1alpha1.CarbonAwareJob) field.ErrorList {
+	v.settings.RLock()
+	defer v.settings.RUnlock()
+
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+	spec := carbonAwareJob.Spec
+
+	maxDelay := spec.MaxDelay.Duration
+	switch {
+	case maxDelay < 0:
+		allErrs = append(allErrs, field.Invalid(specPath.Child("maxDelay"), spec.MaxDelay.Duration.String(), "must be positive"))
+	case maxDelay == 0 && spec.Deadline == nil:
+		allErrs = append(allErrs, field.Invalid(specPath.Child("maxDelay"), spec.MaxDelay.Duration.String(), "must be positive unless deadline is set"))
+	case v.MaxDelayLimit > 0:
+		if err := v.validateWindowLimit(carbonAwareJob, time.Now()); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+
+	if spec.MaxDuration != nil {
+		maxDurationPath := specPath.Child("maxDuration")
+		if spec.MaxDuration.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(maxDurationPath, spec.MaxDuration.Duration.String(), "must be positive"))
+		} else if activeDeadline := spec.Template.Spec.ActiveDeadlineSeconds; activeDeadline != nil &&
+			time.Duration(*activeDeadline)*time.Second < spec.MaxDuration.Duration {
+			// The Job would be killed before the time the schedule was planned for
+			allErrs = append(allErrs, field.Invalid(
+				specPath.Child("template", "spec", "activeDeadlineSeconds"), *activeDeadline,
+				fmt.Sprintf("must not be shorter than maxDuration (%s)", spec.MaxDuration.Duration)))
+		}
+	}
+
+	return allErrs
+}
+
+// validateStrategy rejects the Threshold and FirstBelowPercentile strategies when the forecast
+// backend cannot report every start time of the window for them to search
+func (v *CarbonAwareJobCustomValidator) validateStrategy(carbonAwareJob *batchv1alpha1.CarbonAwareJob) field.ErrorList {
+	v.settings.RLock()
+	defer v.settings.RUnlock()
+
+	strategy := carbonAwareJob.Spec.Strategy
+	if !v.RankedOptionsOnly || strategy == nil {
+		return nil
+	}
+	switch strategy.Type {
+	case batchv1alpha1.StrategyThreshold, batchv1alpha1.StrategyFirstBelowPercentile:
+		return field.ErrorList{field.Forbidden(field.NewPath("spec", "strategy", "type"),
+			fmt.Sprintf("%s requires a forecast provider queried directly; the carbon-aware scheduler only reports ranked options", strategy.Type))}
+	}
+	return nil
+}
+
+// validateWindowLimit rejects jobs whose scheduling window, from now until the latest start time
+// that MaxDelay and Deadline allow, exceeds the cluster limit
+func (v *CarbonAwareJobCustomValidator) validateWindowLimit(carbonAwareJob *batchv1alpha1.CarbonAwareJob, now time.Time) *field.Error {
+	specPath := field.NewPath("spec")
+	spec := carbonAwareJob.Spec
+
+	path, value := specPath.Child("maxDelay"), spec.MaxDelay.Duration.String()
+	windowEnd := submissionTime(carbonAwareJob, now).Add(spec.MaxDelay.Duration)
+	if spec.Deadline != nil {
+		maxDuration := batchv1alpha1.DefaultMaxDuration
+		if spec.MaxDuration != nil && spec.MaxDuration.Duration > 0 {
+			maxDuration = spec.MaxDuration.Duration
+		}
+		if latestStart := spec.Deadline.Add(-maxDuration); spec.MaxDelay.Duration <= 0 || latestStart.Before(windowEnd) {
+			path, value = specPath.Child("deadline"), spec.Deadline.UTC().Format(time.RFC3339)
+			windowEnd = latestStart
+		}
+	}
+
+	if window := windowEnd.Sub(now); window > v.MaxDelayLimit {
+		return field.Invalid(path, value, fmt.Sprintf("allows a scheduling window of %s, which exceeds the cluster limit of %s",
+			window.Round(time.Second), v.MaxDelayLimit))
+	}
+	return nil
+}
+
+// validateDeadline rejects new jobs whose deadline has passed or leaves less than MaxDuration to
+// run. Existing jobs are not checked, because their deadline passes while they run.
+func validateDeadline(carbonAwareJob *batchv1alpha1.CarbonAwareJob, now time.Time) field.ErrorList {
+	deadline := carbonAwareJob.Spec.Deadline
+	if deadline == nil {
+		return nil
+	}
+	deadlinePath := field.NewPath("spec", "deadline")
+	value := deadline.UTC().Format(time.RFC3339)
+
+	if deadline.Time.Before(now) {
+		return field.ErrorList{field.Invalid(deadlinePath, value, "must not be in the past")}
+	}
+	if maxDuration := carbonAwareJob.Spec.MaxDuration; maxDuration != nil && deadline.Add(-maxDuration.Duration).Before(now) {
+		return field.ErrorList{field.Invalid(deadlinePath, value,
+			fmt.Sprintf("leaves less than maxDuration (%s) to run the job", maxDuration.Duration))}
+	}
+	return nil
+}
+
+// submissionTime returns when a CarbonAwareJob was submitted, or now for jobs being created
+func submissionTime(carbonAwareJob *batchv1alpha1.CarbonAwareJob, now time.Time) time.Time {
+	if carbonAwareJob.Status.SubmissionTime != nil {
+		return carbonAwareJob.Status.SubmissionTime.Time
+	}
+	if !carbonAwareJob.CreationTimestamp.IsZero() {
+		return carbonAwareJob.CreationTimestamp.Time
+	}
+	return now
+}
+
+// validateWindowUnchanged rejects changes to the scheduling window once a start time has been
+// chosen, because the controller keeps the window it scheduled the job in. Until then, e.g. while
+// the suspended Job of a job in Suspended mode waits for a forecast, the window is derived from
+// the spec again on every attempt.
+func validateWindowUnchanged(oldJob, carbonAwareJob *batchv1alpha1.CarbonAwareJob) field.ErrorList {
+	switch batchv1beta1.SchedulingState(oldJob.Status.SchedulingState) {
+	case "", batchv1beta1.SchedulingStateNew, batchv1beta1.SchedulingStateForecastUnavailable:
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+	message := fmt.Sprintf("cannot be changed once the job has been scheduled (state %s)", oldJob.Status.SchedulingState)
+	if oldJob.Spec.MaxDelay != carbonAwareJob.Spec.MaxDelay {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("maxDelay"), message))
+	}
+	if !apiequality.Semantic.DeepEqual(oldJob.Spec.MaxDuration, carbonAwareJob.Spec.MaxDuration) {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("maxDuration"), message))
+	}
+	if !apiequality.Semantic.DeepEqual(oldJob.Spec.Deadline, carbonAwareJob.Spec.Deadline) {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("deadline"), message))
+	}
+	return allErrs
+}
+
+// invalidError converts validation errors into an Invalid API error
+func invalidError(carbonAwareJob *batchv1alpha1.CarbonAwareJob, allErrs field.ErrorList) error {
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(batchv1alpha1.GroupVersion.WithKind("CarbonAwareJob").GroupKind(), carbonAwareJob.Name, allErrs)
+}
@@ -0,0 +1,270 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	batchv1alpha1 "github.com/carbon-aware-kube/operator/api/v1alpha1"
+)
+
+var _ = Describe("CarbonAwareJob Webhook", func() {
+	var (
+		obj       *batchv1alpha1.CarbonAwareJob
+		oldObj    *batchv1alpha1.CarbonAwareJob
+		validator CarbonAwareJobCustomValidator
+		defaulter CarbonAwareJobCustomDefaulter
+	)
+
+	BeforeEach(func() {
+		obj = &batchv1alpha1.CarbonAwareJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "webhook-test",
+				Namespace: "default",
+			},
+			Spec: batchv1alpha1.CarbonAwareJobSpec{
+				MaxDelay: metav1.Duration{Duration: 4 * time.Hour},
+				Template: batchv1alpha1.JobTemplateSpec{
+					Spec: batchv1.JobSpec{
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers:    []corev1.Container{{Name: "test", Image: "busybox"}},
+								RestartPolicy: corev1.RestartPolicyNever,
+							},
+						},
+					},
+				},
+			},
+		}
+		oldObj = obj.DeepCopy()
+		validator = CarbonAwareJobCustomValidator{MaxDelayLimit: 24 * time.Hour}
+		defaulter = CarbonAwareJobCustomDefaulter{DefaultMaxDuration: 30 * time.Minute}
+	})
+
+	Context("When creating CarbonAwareJob under Defaulting Webhook", func() {
+		It("Should fill MaxDuration from the cluster default", func() {
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.MaxDuration).To(Equal(&metav1.Duration{Duration: 30 * time.Minute}))
+		})
+
+		It("Should prefer activeDeadlineSeconds over the cluster default", func() {
+			activeDeadline := int64(600)
+			obj.Spec.Template.Spec.ActiveDeadlineSeconds = &activeDeadline
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.MaxDuration).To(Equal(&metav1.Duration{Duration: 10 * time.Minute}))
+		})
+
+		It("Should keep an explicit MaxDuration", func() {
+			obj.Spec.MaxDuration = &metav1.Duration{Duration: 2 * time.Hour}
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.MaxDuration.Duration).To(Equal(2 * time.Hour))
+		})
+
+		It("Should fill unset fields from the job's CarbonPolicy", func() {
+			policyScheme := runtime.NewScheme()
+			Expect(batchv1alpha1.AddToScheme(policyScheme)).To(Succeed())
+			defaulter.Client = fake.NewClientBuilder().WithScheme(policyScheme).WithObjects(&batchv1alpha1.CarbonPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "defaults", Namespace: "default"},
+				Spec: batchv1alpha1.CarbonPolicySpec{
+					MaxDelay:    &metav1.Duration{Duration: 12 * time.Hour},
+					MaxDuration: &metav1.Duration{Duration: 3 * time.Hour},
+					Fallback:    &batchv1alpha1.FallbackPolicy{Strategy: batchv1alpha1.FallbackWindowEnd},
+				},
+			}).Build()
+
+			obj.Spec.MaxDelay = metav1.Duration{}
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.MaxDelay.Duration).To(Equal(12 * time.Hour))
+			Expect(obj.Spec.MaxDuration.Duration).To(Equal(3 * time.Hour))
+			Expect(obj.Spec.Fallback.Strategy).To(Equal(batchv1alpha1.FallbackWindowEnd))
+		})
+	})
+
+	Context("When creating or updating CarbonAwareJob under Validating Webhook", func() {
+		It("Should admit a valid job", func() {
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should reject a negative or zero MaxDelay", func() {
+			obj.Spec.MaxDelay = metav1.Duration{Duration: -time.Hour}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("spec.maxDelay")))
+
+			obj.Spec.MaxDelay = metav1.Duration{}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("spec.maxDelay")))
+		})
+
+		It("Should allow a zero MaxDelay when a deadline is set", func() {
+			obj.Spec.MaxDelay = metav1.Duration{}
+			obj.Spec.Deadline = &metav1.Time{Time: time.Now().Add(6 * time.Hour)}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should reject a MaxDelay above the cluster limit", func() {
+			obj.Spec.MaxDelay = metav1.Duration{Duration: 48 * time.Hour}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("cluster limit")))
+
+			validator.MaxDelayLimit = 0
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should reject a deadline that cannot be met on create", func() {
+			obj.Spec.MaxDuration = &metav1.Duration{Duration: time.Hour}
+			obj.Spec.Deadline = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("must not be in the past")))
+
+			obj.Spec.Deadline = &metav1.Time{Time: time.Now().Add(30 * time.Minute)}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("leaves less than maxDuration")))
+
+			// Jobs that are already running are not rejected when their deadline passes
+			oldObj = obj.DeepCopy()
+			obj.Spec.Fallback = &batchv1alpha1.FallbackPolicy{Strategy: batchv1alpha1.FallbackWindowEnd}
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().NotTo(HaveOccurred())
+
+			obj.Spec.Deadline = &metav1.Time{Time: time.Now().Add(2 * time.Hour)}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should reject a deadline that allows a window above the cluster limit", func() {
+			obj.Spec.MaxDelay = metav1.Duration{}
+			obj.Spec.MaxDuration = &metav1.Duration{Duration: time.Hour}
+			obj.Spec.Deadline = &metav1.Time{Time: time.Now().Add(72 * time.Hour)}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(And(ContainSubstring("spec.deadline"), ContainSubstring("cluster limit"))))
+
+			// The shorter of MaxDelay and the deadline bounds the window
+			obj.Spec.MaxDelay = metav1.Duration{Duration: 4 * time.Hour}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+
+			obj.Spec.MaxDelay = metav1.Duration{}
+			obj.Spec.Deadline = &metav1.Time{Time: time.Now().Add(20 * time.Hour)}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should reject strategies the scheduler cannot serve", func() {
+			threshold := int32(200)
+			obj.Spec.Strategy = &batchv1alpha1.SchedulingStrategy{Type: batchv1alpha1.StrategyThreshold, ThresholdIntensity: &threshold}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+
+			validator.RankedOptionsOnly = true
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("spec.strategy.type")))
+
+			// Existing jobs keep their strategy when other fields change
+			oldObj = obj.DeepCopy()
+			obj.Spec.MaxDelay = metav1.Duration{Duration: 8 * time.Hour}
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().NotTo(HaveOccurred())
+
+			obj.Spec.Strategy = &batchv1alpha1.SchedulingStrategy{Type: batchv1alpha1.StrategyOptimal}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should reject an activeDeadlineSeconds shorter than MaxDuration", func() {
+			activeDeadline := int64(600)
+			obj.Spec.Template.Spec.ActiveDeadlineSeconds = &activeDeadline
+			obj.Spec.MaxDuration = &metav1.Duration{Duration: time.Hour}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("activeDeadlineSeconds")))
+
+			obj.Spec.MaxDuration = &metav1.Duration{Duration: 10 * time.Minute}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should reject window changes once the job has been scheduled", func() {
+			obj.Spec.MaxDelay = metav1.Duration{Duration: 8 * time.Hour}
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().NotTo(HaveOccurred())
+
+			// A deferred job has no Job while it waits, but its window is fixed
+			oldObj.Status.SchedulingState = "Pending"
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().To(MatchError(ContainSubstring("cannot be changed")))
+
+			oldObj.Status.SchedulingState = "Scheduled"
+			oldObj.Status.JobName = "webhook-test-1"
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().To(MatchError(ContainSubstring("cannot be changed")))
+
+			obj.Spec.MaxDelay = oldObj.Spec.MaxDelay
+			obj.Finalizers = []string{"batch.carbonaware.dev/finalizer"}
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should allow window changes to a suspended Job before it has been scheduled", func() {
+			oldObj.Spec.JobCreation = batchv1alpha1.JobCreationSuspended
+			oldObj.Status.JobName = "webhook-test-1"
+			obj = oldObj.DeepCopy()
+			obj.Spec.MaxDelay = metav1.Duration{Duration: 8 * time.Hour}
+			obj.Spec.MaxDuration = &metav1.Duration{Duration: time.Hour}
+
+			for _, state := range []string{"New", "ForecastUnavailable"} {
+				oldObj.Status.SchedulingState = state
+				Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().NotTo(HaveOccurred(), state)
+			}
+
+			oldObj.Status.SchedulingState = "Pending"
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.maxDelay")))
+			Expect(err).To(MatchError(ContainSubstring("spec.maxDuration")))
+		})
+	})
+
+	Context("When admitting CarbonAwareJobs through the API server", func() {
+		AfterEach(func() {
+			_ = k8sClient.Delete(ctx, obj)
+		})
+
+		It("Should default MaxDuration on create", func() {
+			Expect(k8sClient.Create(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.MaxDuration).NotTo(BeNil())
+			Expect(obj.Spec.MaxDuration.Duration).To(Equal(batchv1alpha1.DefaultMaxDuration))
+		})
+
+		It("Should reject an invalid MaxDelay", func() {
+			obj.Spec.MaxDelay = metav1.Duration{Duration: -time.Hour}
+			err := k8sClient.Create(ctx, obj)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue(), "unexpected error: %v", err)
+		})
+
+		It("Should reject a deadline in the past", func() {
+			obj.Spec.Deadline = &metav1.Time{Time: time.Now().Add(-time.Hour)}
+			err := k8sClient.Create(ctx, obj)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue(), "unexpected error: %v", err)
+			Expect(err).To(MatchError(ContainSubstring("spec.deadline")))
+		})
+
+		It("Should reject a deadline-only job whose window exceeds the cluster limit", func() {
+			obj.Spec.MaxDelay = metav1.Duration{}
+			obj.Spec.Deadline = &metav1.Time{Time: time.Now().Add(30 * 24 * time.Hour)}
+			err := k8sClient.Create(ctx, obj)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue(), "unexpected error: %v", err)
+			Expect(err).To(MatchError(ContainSubstring("cluster limit")))
+		})
+
+		It("Should reject window changes after the job has been scheduled", func() {
+			Expect(k8sClient.Create(ctx, obj)).To(Succeed())
+			obj.Status.SchedulingState = "Pending"
+			Expect(k8sClient.Status().Update(ctx, obj)).To(Succeed())
+
+			obj.Spec.MaxDelay = metav1.Duration{Duration: 8 * time.Hour}
+			err := k8sClient.Update(ctx, obj)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue(), "unexpected error: %v", err)
+		})
+	})
+})